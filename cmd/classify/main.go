@@ -0,0 +1,51 @@
+package main
+
+import (
+	"context"
+	"flag"
+
+	"github.com/zalhonan/remotejobs-site/internal/db"
+	"github.com/zalhonan/remotejobs-site/internal/db/repository"
+	"github.com/zalhonan/remotejobs-site/internal/domain/service"
+	"github.com/zalhonan/remotejobs-site/internal/logger"
+	"go.uber.org/zap"
+)
+
+// Определяет основную технологию по ключевым словам справочника технологий для вакансий, у которых она не заполнена
+func main() {
+	batchSize := flag.Int("batch", service.DefaultClassifyBatchSize, "количество вакансий в одном пакете")
+	flag.Parse()
+
+	// Инициализация логгера
+	appLogger, err := logger.InitLogger()
+	if err != nil {
+		panic("Cannot init logger: " + err.Error())
+	}
+	defer appLogger.Sync()
+
+	ctx := context.Background()
+
+	// Инициализация соединения с базой данных
+	database, err := db.InitDB(ctx, appLogger)
+	if err != nil {
+		appLogger.Fatal("Не удалось инициализировать базу данных", zap.Error(err))
+	}
+	defer database.Close()
+
+	jobRepo := repository.NewJobRepository(database, appLogger)
+	techRepo := repository.NewTechnologyRepository(database, appLogger)
+	classifierService := service.NewClassifierService(jobRepo, techRepo, appLogger)
+
+	stats, err := classifierService.ClassifyMissing(ctx, *batchSize)
+	if err != nil {
+		appLogger.Fatal("Не удалось классифицировать вакансии",
+			zap.Error(err),
+			zap.Int("processed", stats.Processed),
+		)
+	}
+
+	appLogger.Info("Классификация вакансий завершена",
+		zap.Int("processed", stats.Processed),
+		zap.Int("classified", stats.Classified),
+	)
+}
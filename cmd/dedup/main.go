@@ -0,0 +1,50 @@
+package main
+
+import (
+	"context"
+	"flag"
+
+	"github.com/zalhonan/remotejobs-site/internal/db"
+	"github.com/zalhonan/remotejobs-site/internal/db/repository"
+	"github.com/zalhonan/remotejobs-site/internal/domain/service"
+	"github.com/zalhonan/remotejobs-site/internal/logger"
+	"go.uber.org/zap"
+)
+
+// Ищет повторы среди всех уже сохраненных вакансий и связывает их с каноническими вакансиями
+func main() {
+	batchSize := flag.Int("batch", service.DefaultDedupBatchSize, "количество вакансий в одном пакете")
+	flag.Parse()
+
+	// Инициализация логгера
+	appLogger, err := logger.InitLogger()
+	if err != nil {
+		panic("Cannot init logger: " + err.Error())
+	}
+	defer appLogger.Sync()
+
+	ctx := context.Background()
+
+	// Инициализация соединения с базой данных
+	database, err := db.InitDB(ctx, appLogger)
+	if err != nil {
+		appLogger.Fatal("Не удалось инициализировать базу данных", zap.Error(err))
+	}
+	defer database.Close()
+
+	jobRepo := repository.NewJobRepository(database, appLogger)
+	dedupService := service.NewDedupService(jobRepo, appLogger)
+
+	stats, err := dedupService.Backfill(ctx, *batchSize)
+	if err != nil {
+		appLogger.Fatal("Не удалось найти повторы вакансий",
+			zap.Error(err),
+			zap.Int("processed", stats.Processed),
+		)
+	}
+
+	appLogger.Info("Поиск повторов вакансий завершен",
+		zap.Int("processed", stats.Processed),
+		zap.Int("duplicates", stats.Duplicates),
+	)
+}
@@ -0,0 +1,54 @@
+package main
+
+import (
+	"context"
+	"flag"
+
+	"github.com/zalhonan/remotejobs-site/internal/db"
+	"github.com/zalhonan/remotejobs-site/internal/db/repository"
+	"github.com/zalhonan/remotejobs-site/internal/domain/service"
+	"github.com/zalhonan/remotejobs-site/internal/logger"
+	"go.uber.org/zap"
+)
+
+// Пересчитывает данные, извлекаемые из текста вакансий (зарплата, уровень позиции, ограничения по месту работы),
+// и подготовленный к показу текст (очищенный HTML, превью, мета-описание) для всех уже сохраненных вакансий
+func main() {
+	batchSize := flag.Int("batch", service.DefaultEnrichBatchSize, "количество вакансий в одном пакете")
+	flag.Parse()
+
+	// Инициализация логгера
+	appLogger, err := logger.InitLogger()
+	if err != nil {
+		panic("Cannot init logger: " + err.Error())
+	}
+	defer appLogger.Sync()
+
+	ctx := context.Background()
+
+	// Инициализация соединения с базой данных
+	database, err := db.InitDB(ctx, appLogger)
+	if err != nil {
+		appLogger.Fatal("Не удалось инициализировать базу данных", zap.Error(err))
+	}
+	defer database.Close()
+
+	jobRepo := repository.NewJobRepository(database, appLogger)
+	seniorityRuleRepo := repository.NewSeniorityRuleRepository(database, appLogger)
+	enrichService := service.NewEnrichService(jobRepo, seniorityRuleRepo, appLogger)
+
+	stats, err := enrichService.Backfill(ctx, *batchSize)
+	if err != nil {
+		appLogger.Fatal("Не удалось пересчитать данные вакансий",
+			zap.Error(err),
+			zap.Int("processed", stats.Processed),
+		)
+	}
+
+	appLogger.Info("Пересчет данных вакансий завершен",
+		zap.Int("processed", stats.Processed),
+		zap.Int("withSalary", stats.WithSalary),
+		zap.Int("withSeniority", stats.WithSeniority),
+		zap.Int("withLocation", stats.WithLocation),
+	)
+}
@@ -0,0 +1,94 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"github.com/zalhonan/remotejobs-site/internal/db"
+	"github.com/zalhonan/remotejobs-site/internal/db/repository"
+	"github.com/zalhonan/remotejobs-site/internal/domain/service"
+	"github.com/zalhonan/remotejobs-site/internal/logger"
+	"github.com/zalhonan/remotejobs-site/internal/source"
+	"github.com/zalhonan/remotejobs-site/internal/source/telegram"
+	"go.uber.org/zap"
+)
+
+// Импортирует вакансии из файла result.json, созданного экспортом истории канала в Telegram Desktop.
+// Посты, уже сохраненные парсером или прошлым импортом, пропускаются
+func main() {
+	file := flag.String("file", "", "путь к result.json экспорта канала")
+	channel := flag.String("channel", "", "имя канала в ссылке t.me/<channel>, обязательно для публичного канала (для закрытого ссылки ведут на t.me/c/<id канала>)")
+	flag.Parse()
+
+	// Инициализация логгера
+	appLogger, err := logger.InitLogger()
+	if err != nil {
+		panic("Cannot init logger: " + err.Error())
+	}
+	defer appLogger.Sync()
+
+	if *file == "" {
+		appLogger.Fatal("Не указан файл экспорта (-file)")
+	}
+
+	// Прерываем импорт по сигналу после сохранения текущего поста
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	exportFile, err := os.Open(*file)
+	if err != nil {
+		appLogger.Fatal("Не удалось открыть файл экспорта", zap.Error(err), zap.String("file", *file))
+	}
+	defer exportFile.Close()
+
+	export, err := telegram.ParseExport(exportFile, telegram.DefaultBaseURL, *channel)
+	if err != nil {
+		appLogger.Fatal("Не удалось прочитать файл экспорта", zap.Error(err), zap.String("file", *file))
+	}
+
+	appLogger.Info("Экспорт канала прочитан",
+		zap.String("name", export.Name),
+		zap.Int64("id", export.ID),
+		zap.Int("posts", len(export.Posts)),
+	)
+
+	// Инициализация соединения с базой данных
+	database, err := db.InitDB(ctx, appLogger)
+	if err != nil {
+		appLogger.Fatal("Не удалось инициализировать базу данных", zap.Error(err))
+	}
+	defer database.Close()
+
+	jobRepo := repository.NewJobRepository(database, appLogger)
+	channelRepo := repository.NewTelegramChannelRepository(database, appLogger)
+	sourceRepo := repository.NewSourceRepository(database, appLogger)
+	seniorityRuleRepo := repository.NewSeniorityRuleRepository(database, appLogger)
+	techRepo := repository.NewTechnologyRepository(database, appLogger)
+
+	enrichService := service.NewEnrichService(jobRepo, seniorityRuleRepo, appLogger)
+	classifierService := service.NewClassifierService(jobRepo, techRepo, appLogger)
+	dedupService := service.NewDedupService(jobRepo, appLogger)
+
+	// Импорт не обращается к Telegram и другим источникам
+	client := telegram.NewClient(telegram.DefaultBaseURL, 0, appLogger)
+	ingestService := service.NewIngestService(
+		jobRepo, channelRepo, sourceRepo, enrichService, classifierService, dedupService, client, source.NewRegistry(), appLogger,
+	)
+
+	stats, err := ingestService.ImportPosts(ctx, export.Posts)
+	if err != nil {
+		appLogger.Fatal("Не удалось импортировать посты",
+			zap.Error(err),
+			zap.Int("posts", stats.Posts),
+			zap.Int("inserted", stats.Inserted),
+		)
+	}
+
+	appLogger.Info("Импорт постов завершен",
+		zap.Int("posts", stats.Posts),
+		zap.Int("inserted", stats.Inserted),
+	)
+}
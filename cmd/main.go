@@ -15,6 +15,8 @@ import (
 	"github.com/zalhonan/remotejobs-site/internal/logger"
 	"github.com/zalhonan/remotejobs-site/internal/middleware"
 	"github.com/zalhonan/remotejobs-site/internal/router"
+	"github.com/zalhonan/remotejobs-site/internal/source"
+	"github.com/zalhonan/remotejobs-site/internal/source/telegram"
 	"go.uber.org/zap"
 )
 
@@ -42,10 +44,37 @@ func main() {
 	// Создаем репозитории
 	jobRepo := repository.NewJobRepository(database, appLogger)
 	techRepo := repository.NewTechnologyRepository(database, appLogger)
+	stopWordRepo := repository.NewStopWordRepository(database, appLogger)
+	channelRepo := repository.NewTelegramChannelRepository(database, appLogger)
+	sourceRepo := repository.NewSourceRepository(database, appLogger)
+	seniorityRuleRepo := repository.NewSeniorityRuleRepository(database, appLogger)
+	ingestClientRepo := repository.NewIngestClientRepository(database, appLogger)
 
 	// Создаем сервисы
-	jobService := service.NewJobService(jobRepo, techRepo, appLogger)
-	technologyService := service.NewTechnologyService(techRepo, appLogger)
+	jobService := service.NewJobService(jobRepo, techRepo, stopWordRepo, appLogger)
+	technologyService := service.NewTechnologyService(techRepo, stopWordRepo, appLogger)
+	lifecycleService := service.NewLifecycleService(jobRepo, appLogger)
+	enrichService := service.NewEnrichService(jobRepo, seniorityRuleRepo, appLogger)
+	classifierService := service.NewClassifierService(jobRepo, techRepo, appLogger)
+	dedupService := service.NewDedupService(jobRepo, appLogger)
+	ingestClientService := service.NewIngestClientService(ingestClientRepo, appLogger)
+	stopWordService := service.NewStopWordService(stopWordRepo, jobRepo, appLogger)
+	moderationService := service.NewModerationService(jobRepo, techRepo, stopWordService, appLogger)
+	channelService := service.NewTelegramChannelService(channelRepo, stopWordService, appLogger)
+	jobAdminService := service.NewJobAdminService(jobRepo, techRepo, enrichService, appLogger)
+
+	// Вакансии от внешних парсеров сохраняются так же, как посты каналов. Сервер не загружает каналы
+	// и источники сам, поэтому клиент Telegram и реестр источников ему не нужны для работы
+	ingestService := service.NewIngestService(
+		jobRepo, channelRepo, sourceRepo, enrichService, classifierService, dedupService,
+		telegram.NewClient(telegram.DefaultBaseURL, 0, appLogger), source.NewRegistry(), appLogger,
+	)
+
+	// Пересчитываем количество вакансий по технологиям и обновляем статусы вакансий в фоне, пока работает сервер
+	refreshCtx, stopRefresh := context.WithCancel(ctx)
+	defer stopRefresh()
+	go technologyService.RunCountRefresher(refreshCtx, service.DefaultCountRefreshInterval)
+	go lifecycleService.RunStatusUpdater(refreshCtx, service.DefaultStatusUpdateInterval)
 
 	// Создаем рендерер шаблонов
 	templateRenderer, err := handler.NewTemplateRenderer("templates", "layout/base.html", appLogger)
@@ -56,9 +85,21 @@ func main() {
 	// Создаем обработчики
 	homeHandler := handler.NewHomeHandler(jobService, technologyService, templateRenderer, appLogger)
 	jobHandler := handler.NewJobHandler(jobService, technologyService, templateRenderer, appLogger)
+	apiHandler := handler.NewAPIHandler(jobService, technologyService, appLogger)
+	ingestHandler := handler.NewIngestHandler(ingestService, ingestClientService, appLogger)
+	adminHandler := handler.NewAdminHandler(
+		technologyService, classifierService, moderationService, stopWordService, channelService, jobAdminService,
+		templateRenderer, appLogger,
+	)
+
+	// Админка доступна только при заданных имени пользователя и пароле
+	adminAuth := middleware.NewAdminAuth(os.Getenv("ADMIN_USER"), os.Getenv("ADMIN_PASSWORD"), appLogger)
+	if !adminAuth.Enabled() {
+		appLogger.Warn("Админка выключена: не заданы переменные окружения ADMIN_USER и ADMIN_PASSWORD")
+	}
 
 	// Создаем маршрутизатор
-	appRouter := router.NewRouter(homeHandler, jobHandler, appLogger)
+	appRouter := router.NewRouter(homeHandler, jobHandler, apiHandler, ingestHandler, adminHandler, adminAuth, appLogger)
 
 	// Создаем middleware для заголовков безопасности
 	// Устанавливаем useHTTPS в false, так как пока мы не используем HTTPS
@@ -0,0 +1,118 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/zalhonan/remotejobs-site/internal/db"
+	"github.com/zalhonan/remotejobs-site/internal/db/repository"
+	"github.com/zalhonan/remotejobs-site/internal/domain/service"
+	"github.com/zalhonan/remotejobs-site/internal/logger"
+	"github.com/zalhonan/remotejobs-site/internal/source"
+	"github.com/zalhonan/remotejobs-site/internal/source/feed"
+	"github.com/zalhonan/remotejobs-site/internal/source/telegram"
+	"go.uber.org/zap"
+)
+
+// Загружает новые посты каналов Telegram из веб-превью t.me/s/<tag> и публикации источников из таблицы sources
+// (ленты RSS/Atom) и сохраняет их как вакансии
+func main() {
+	baseURL := flag.String("base-url", "", "адрес веб-превью Telegram (по умолчанию TELEGRAM_BASE_URL или https://t.me)")
+	channel := flag.String("channel", "", "обработать только указанный канал")
+	sourceName := flag.String("source", "", "обработать только указанный источник из таблицы sources")
+	maxPages := flag.Int("max-pages", service.DefaultIngestMaxPages, "максимальное количество страниц канала за один проход")
+	delay := flag.Duration("delay", time.Second, "пауза между запросами к Telegram")
+	interval := flag.Duration("interval", 15*time.Minute, "интервал между проходами по каналам")
+	once := flag.Bool("once", false, "выполнить один проход и завершиться")
+	flag.Parse()
+
+	// Инициализация логгера
+	appLogger, err := logger.InitLogger()
+	if err != nil {
+		panic("Cannot init logger: " + err.Error())
+	}
+	defer appLogger.Sync()
+
+	// Завершаем работу по сигналу после окончания текущего запроса
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	// Инициализация соединения с базой данных
+	database, err := db.InitDB(ctx, appLogger)
+	if err != nil {
+		appLogger.Fatal("Не удалось инициализировать базу данных", zap.Error(err))
+	}
+	defer database.Close()
+
+	// Адрес веб-превью настраивается, чтобы парсер можно было проверить на локальной заглушке
+	if *baseURL == "" {
+		*baseURL = os.Getenv("TELEGRAM_BASE_URL")
+	}
+	if *baseURL == "" {
+		*baseURL = telegram.DefaultBaseURL
+	}
+
+	jobRepo := repository.NewJobRepository(database, appLogger)
+	channelRepo := repository.NewTelegramChannelRepository(database, appLogger)
+	sourceRepo := repository.NewSourceRepository(database, appLogger)
+	seniorityRuleRepo := repository.NewSeniorityRuleRepository(database, appLogger)
+	techRepo := repository.NewTechnologyRepository(database, appLogger)
+
+	enrichService := service.NewEnrichService(jobRepo, seniorityRuleRepo, appLogger)
+	classifierService := service.NewClassifierService(jobRepo, techRepo, appLogger)
+	dedupService := service.NewDedupService(jobRepo, appLogger)
+	client := telegram.NewClient(*baseURL, *delay, appLogger)
+
+	// Адаптеры источников по значению sources.kind
+	sources := source.NewRegistry()
+	sources.Register(feed.Kind, feed.NewFactory(appLogger))
+
+	ingestService := service.NewIngestService(
+		jobRepo, channelRepo, sourceRepo, enrichService, classifierService, dedupService, client, sources, appLogger,
+	)
+
+	appLogger.Info("Парсер каналов Telegram и источников запущен",
+		zap.String("baseUrl", *baseURL),
+		zap.Strings("sourceKinds", sources.Kinds()),
+		zap.Duration("interval", *interval),
+	)
+
+	for {
+		var stats service.IngestStats
+		switch {
+		case *channel != "":
+			stats, err = ingestService.IngestTag(ctx, *channel, *maxPages)
+		case *sourceName != "":
+			stats, err = ingestService.IngestSource(ctx, *sourceName)
+		default:
+			stats, err = ingestService.IngestAll(ctx, *maxPages)
+		}
+
+		if err != nil && ctx.Err() == nil {
+			appLogger.Error("Ошибка при загрузке постов", zap.Error(err))
+		} else {
+			appLogger.Info("Проход по каналам и источникам завершен",
+				zap.Int("channels", stats.Channels),
+				zap.Int("sources", stats.Sources),
+				zap.Int("posts", stats.Posts),
+				zap.Int("inserted", stats.Inserted),
+				zap.Int("failed", stats.Failed),
+			)
+		}
+
+		if *once {
+			return
+		}
+
+		select {
+		case <-ctx.Done():
+			appLogger.Info("Парсер каналов Telegram и источников остановлен")
+			return
+		case <-time.After(*interval):
+		}
+	}
+}
@@ -0,0 +1,164 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/zalhonan/remotejobs-site/internal/db"
+	"github.com/zalhonan/remotejobs-site/internal/db/repository"
+	"github.com/zalhonan/remotejobs-site/internal/domain/entity"
+	"github.com/zalhonan/remotejobs-site/internal/domain/service"
+	"github.com/zalhonan/remotejobs-site/internal/logger"
+	"go.uber.org/zap"
+)
+
+// dateLayout формат дат во флагах -from и -to
+const dateLayout = "2006-01-02"
+
+// Заново определяет основную технологию вакансий после изменения ключевых слов технологий
+// и выводит отчет о вакансиях, перешедших между технологиями
+func main() {
+	batchSize := flag.Int("batch", service.DefaultClassifyBatchSize, "количество вакансий в одном пакете")
+	dryRun := flag.Bool("dry-run", false, "только показать изменения, не сохраняя их")
+	technologies := flag.String("technology", "", "текущие основные технологии вакансий через запятую (по умолчанию - любые)")
+	unclassified := flag.Bool("unclassified", false, "только вакансии без основной технологии")
+	from := flag.String("from", "", "вакансии, опубликованные не раньше даты (ГГГГ-ММ-ДД)")
+	to := flag.String("to", "", "вакансии, опубликованные раньше даты (ГГГГ-ММ-ДД)")
+	verbose := flag.Bool("v", false, "выводить каждую вакансию, у которой сменилась технология")
+	flag.Parse()
+
+	// Инициализация логгера
+	appLogger, err := logger.InitLogger()
+	if err != nil {
+		panic("Cannot init logger: " + err.Error())
+	}
+	defer appLogger.Sync()
+
+	filter, err := parseFilter(*technologies, *unclassified, *from, *to)
+	if err != nil {
+		appLogger.Fatal("Некорректные параметры выборки вакансий", zap.Error(err))
+	}
+
+	ctx := context.Background()
+
+	// Инициализация соединения с базой данных
+	database, err := db.InitDB(ctx, appLogger)
+	if err != nil {
+		appLogger.Fatal("Не удалось инициализировать базу данных", zap.Error(err))
+	}
+	defer database.Close()
+
+	jobRepo := repository.NewJobRepository(database, appLogger)
+	techRepo := repository.NewTechnologyRepository(database, appLogger)
+	classifierService := service.NewClassifierService(jobRepo, techRepo, appLogger)
+
+	report, err := classifierService.Reclassify(ctx, filter, *batchSize, *dryRun)
+	if err != nil {
+		appLogger.Fatal("Не удалось классифицировать вакансии повторно, изменения не сохранены",
+			zap.Error(err),
+			zap.Int("processed", report.Processed),
+		)
+	}
+
+	printReport(report, *verbose)
+
+	appLogger.Info("Повторная классификация вакансий завершена",
+		zap.Int("processed", report.Processed),
+		zap.Int("changed", len(report.Changes)),
+		zap.Bool("applied", report.Applied),
+	)
+}
+
+// parseFilter собирает выборку вакансий из флагов командной строки
+func parseFilter(technologies string, unclassified bool, from, to string) (entity.ClassifyFilter, error) {
+	filter := entity.ClassifyFilter{Unclassified: unclassified}
+
+	for _, technology := range strings.Split(technologies, ",") {
+		if technology = strings.TrimSpace(technology); technology != "" {
+			filter.Technologies = append(filter.Technologies, technology)
+		}
+	}
+
+	if unclassified && len(filter.Technologies) > 0 {
+		return filter, fmt.Errorf("флаги -technology и -unclassified нельзя использовать вместе")
+	}
+
+	var err error
+	if from != "" {
+		if filter.PostedFrom, err = time.Parse(dateLayout, from); err != nil {
+			return filter, fmt.Errorf("некорректная дата -from: %w", err)
+		}
+	}
+	if to != "" {
+		if filter.PostedTo, err = time.Parse(dateLayout, to); err != nil {
+			return filter, fmt.Errorf("некорректная дата -to: %w", err)
+		}
+	}
+
+	return filter, nil
+}
+
+// printReport выводит сводку переходов между технологиями и, если нужно, список вакансий
+func printReport(report service.ReclassifyReport, verbose bool) {
+	w := tabwriter.NewWriter(os.Stdout, 0, 4, 2, ' ', 0)
+	defer w.Flush()
+
+	if report.Applied {
+		fmt.Fprintf(w, "Изменения сохранены. Обработано вакансий: %d, сменили технологию: %d\n", report.Processed, len(report.Changes))
+	} else {
+		fmt.Fprintf(w, "Пробный запуск, изменения не сохранены. Обработано вакансий: %d, сменили бы технологию: %d\n", report.Processed, len(report.Changes))
+	}
+
+	if len(report.Changes) == 0 {
+		return
+	}
+
+	type move struct {
+		from, to string
+		count    int
+	}
+
+	var moves []move
+	for pair, count := range report.Moves() {
+		moves = append(moves, move{from: pair[0], to: pair[1], count: count})
+	}
+	sort.Slice(moves, func(i, j int) bool {
+		if moves[i].count != moves[j].count {
+			return moves[i].count > moves[j].count
+		}
+		if moves[i].from != moves[j].from {
+			return moves[i].from < moves[j].from
+		}
+		return moves[i].to < moves[j].to
+	})
+
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "БЫЛО\tСТАЛО\tВАКАНСИЙ")
+	for _, m := range moves {
+		fmt.Fprintf(w, "%s\t%s\t%d\n", technologyLabel(m.from), technologyLabel(m.to), m.count)
+	}
+
+	if !verbose {
+		return
+	}
+
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "ID\tБЫЛО\tСТАЛО\tЗАГОЛОВОК")
+	for _, change := range report.Changes {
+		fmt.Fprintf(w, "%d\t%s\t%s\t%s\n", change.JobID, technologyLabel(change.From), technologyLabel(change.To), change.Title)
+	}
+}
+
+// technologyLabel возвращает название технологии для отчета
+func technologyLabel(technology string) string {
+	if technology == "" {
+		return "(нет)"
+	}
+	return technology
+}
@@ -0,0 +1,51 @@
+package main
+
+import (
+	"context"
+	"flag"
+
+	"github.com/zalhonan/remotejobs-site/internal/db"
+	"github.com/zalhonan/remotejobs-site/internal/db/repository"
+	"github.com/zalhonan/remotejobs-site/internal/domain/service"
+	"github.com/zalhonan/remotejobs-site/internal/logger"
+	"go.uber.org/zap"
+)
+
+// Формирует слаги латиницей для вакансий со слагами неправильного вида (или для всех вакансий с флагом -all)
+func main() {
+	batchSize := flag.Int("batch", service.DefaultSlugBatchSize, "количество вакансий в одном пакете")
+	all := flag.Bool("all", false, "пересчитать слаги всех вакансий, а не только слаги неправильного вида")
+	flag.Parse()
+
+	// Инициализация логгера
+	appLogger, err := logger.InitLogger()
+	if err != nil {
+		panic("Cannot init logger: " + err.Error())
+	}
+	defer appLogger.Sync()
+
+	ctx := context.Background()
+
+	// Инициализация соединения с базой данных
+	database, err := db.InitDB(ctx, appLogger)
+	if err != nil {
+		appLogger.Fatal("Не удалось инициализировать базу данных", zap.Error(err))
+	}
+	defer database.Close()
+
+	jobRepo := repository.NewJobRepository(database, appLogger)
+	slugService := service.NewSlugService(jobRepo, appLogger)
+
+	stats, err := slugService.Backfill(ctx, *batchSize, *all)
+	if err != nil {
+		appLogger.Fatal("Не удалось пересчитать слаги вакансий",
+			zap.Error(err),
+			zap.Int("processed", stats.Processed),
+		)
+	}
+
+	appLogger.Info("Пересчет слагов вакансий завершен",
+		zap.Int("processed", stats.Processed),
+		zap.Int("updated", stats.Updated),
+	)
+}
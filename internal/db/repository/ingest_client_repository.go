@@ -0,0 +1,72 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/jackc/pgx/v4/pgxpool"
+	"github.com/zalhonan/remotejobs-site/internal/domain/entity"
+	"go.uber.org/zap"
+)
+
+// ingestClientColumns список колонок, читаемых в порядке ingestClientScanTargets
+const ingestClientColumns = "id, name, secret, enabled, date_client_added, items_received, date_last_request"
+
+type IngestClientRepository struct {
+	db     *pgxpool.Pool
+	logger *zap.Logger
+}
+
+// NewIngestClientRepository создает новый репозиторий для работы с клиентами API загрузки вакансий
+func NewIngestClientRepository(db *pgxpool.Pool, logger *zap.Logger) *IngestClientRepository {
+	return &IngestClientRepository{
+		db:     db,
+		logger: logger,
+	}
+}
+
+// ingestClientScanTargets возвращает указатели на поля клиента в порядке колонок ingestClientColumns
+func ingestClientScanTargets(client *entity.IngestClient) []interface{} {
+	return []interface{}{
+		&client.ID,
+		&client.Name,
+		&client.Secret,
+		&client.Enabled,
+		&client.DateClientAdded,
+		&client.ItemsReceived,
+		&client.DateLastRequest,
+	}
+}
+
+// GetByName возвращает клиента по его имени
+func (r *IngestClientRepository) GetByName(ctx context.Context, name string) (entity.IngestClient, error) {
+	query := `
+		SELECT ` + ingestClientColumns + `
+		FROM ingest_clients
+		WHERE name = $1
+	`
+
+	var client entity.IngestClient
+	err := r.db.QueryRow(ctx, query, name).Scan(ingestClientScanTargets(&client)...)
+	if err != nil {
+		return entity.IngestClient{}, fmt.Errorf("не удалось получить клиента API %s: %w", name, err)
+	}
+
+	return client, nil
+}
+
+// RecordRequest увеличивает счетчик полученных от клиента вакансий и сохраняет дату запроса
+func (r *IngestClientRepository) RecordRequest(ctx context.Context, id int64, itemsReceived int) error {
+	query := `
+		UPDATE ingest_clients
+		SET items_received = items_received + $2,
+			date_last_request = NOW()
+		WHERE id = $1
+	`
+
+	if _, err := r.db.Exec(ctx, query, id, itemsReceived); err != nil {
+		return fmt.Errorf("не удалось обновить статистику клиента API с ID=%d: %w", id, err)
+	}
+
+	return nil
+}
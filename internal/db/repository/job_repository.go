@@ -2,15 +2,35 @@ package repository
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"time"
 
+	"github.com/jackc/pgx/v4"
 	"github.com/jackc/pgx/v4/pgxpool"
 	"github.com/zalhonan/remotejobs-site/internal/domain/entity"
 	"go.uber.org/zap"
 )
 
+// jobColumns список колонок, читаемых в порядке jobScanTargets
+const jobColumns = `id, content, coalesce(title, ''), source_link, coalesce(main_technology, ''), coalesce(content_pure, ''),
+	slug, stop_words, coalesce(salary_min, 0), coalesce(salary_max, 0), coalesce(salary_currency, ''),
+	coalesce(salary_period, ''), coalesce(salary_tax, ''), seniority, location_constraints, location_countries,
+	coalesce(simhash, 0), coalesce(canonical_id, 0), coalesce(content_sanitized, ''), coalesce(content_preview, ''),
+	coalesce(meta_description, ''), status, hidden, coalesce(moderation, ''), date_posted, date_parsed`
+
+// ErrSlugTaken слаг уже занят другой вакансией
+var ErrSlugTaken = errors.New("слаг уже занят другой вакансией")
+
+// ErrJobNotFound вакансия не найдена
+var ErrJobNotFound = errors.New("вакансия не найдена")
+
+// slugConstraint ограничение уникальности слага вакансии
+const slugConstraint = "jobs_raw_slug_key"
+
 type JobRepository struct {
-	db     *pgxpool.Pool
+	db     querier
 	logger *zap.Logger
 }
 
@@ -22,36 +42,52 @@ func NewJobRepository(db *pgxpool.Pool, logger *zap.Logger) *JobRepository {
 	}
 }
 
-// GetLatest возвращает последние вакансии с пагинацией
-func (r *JobRepository) GetLatest(ctx context.Context, limit, offset int) ([]entity.JobRaw, error) {
-	query := `
-		SELECT id, content, title, source_link, main_technology, content_pure, slug, date_posted, date_parsed
-		FROM jobs_raw
-		WHERE main_technology IS NOT NULL AND main_technology != ''
-		ORDER BY date_posted DESC
-		LIMIT $1 OFFSET $2
-	`
+// InTx выполняет fn с репозиторием, все запросы которого идут в одной транзакции.
+// Транзакция фиксируется, если fn завершилась без ошибки, и откатывается иначе
+func (r *JobRepository) InTx(ctx context.Context, fn func(repo *JobRepository) error) error {
+	return inTx(ctx, r.db, func(tx pgx.Tx) error {
+		return fn(&JobRepository{db: tx, logger: r.logger})
+	})
+}
 
-	rows, err := r.db.Query(ctx, query, limit, offset)
-	if err != nil {
-		return nil, fmt.Errorf("не удалось получить список вакансий: %w", err)
+// jobScanTargets возвращает указатели на поля вакансии в порядке колонок jobColumns
+func jobScanTargets(job *entity.JobRaw) []interface{} {
+	return []interface{}{
+		&job.ID,
+		&job.Content,
+		&job.Title,
+		&job.SourceLink,
+		&job.MainTechnology,
+		&job.ContentPure,
+		&job.Slug,
+		&job.StopWords,
+		&job.Salary.Min,
+		&job.Salary.Max,
+		&job.Salary.Currency,
+		&job.Salary.Period,
+		&job.Salary.Tax,
+		&job.Seniority,
+		&job.Location.Constraints,
+		&job.Location.Countries,
+		&job.SimHash,
+		&job.CanonicalID,
+		&job.ContentSanitized,
+		&job.ContentPreview,
+		&job.MetaDescription,
+		&job.Status,
+		&job.Hidden,
+		&job.Moderation,
+		&job.DatePosted,
+		&job.DateParsed,
 	}
-	defer rows.Close()
+}
 
+// scanJobs читает строки вакансий из результата запроса
+func scanJobs(rows pgx.Rows) ([]entity.JobRaw, error) {
 	jobs := make([]entity.JobRaw, 0)
 	for rows.Next() {
 		var job entity.JobRaw
-		if err := rows.Scan(
-			&job.ID,
-			&job.Content,
-			&job.Title,
-			&job.SourceLink,
-			&job.MainTechnology,
-			&job.ContentPure,
-			&job.Slug,
-			&job.DatePosted,
-			&job.DateParsed,
-		); err != nil {
+		if err := rows.Scan(jobScanTargets(&job)...); err != nil {
 			return nil, fmt.Errorf("не удалось обработать строку вакансии: %w", err)
 		}
 		jobs = append(jobs, job)
@@ -64,38 +100,454 @@ func (r *JobRepository) GetLatest(ctx context.Context, limit, offset int) ([]ent
 	return jobs, nil
 }
 
+// queryJobs выполняет выборку вакансий по условиям с сортировкой и пагинацией
+func (r *JobRepository) queryJobs(ctx context.Context, from string, where *whereClause, orderBy string, limit, offset int) ([]entity.JobRaw, error) {
+	query := fmt.Sprintf(`
+		SELECT %s
+		FROM %s
+		WHERE %s
+		ORDER BY %s
+		LIMIT %s OFFSET %s
+	`, jobColumns, from, where, orderBy, where.arg(limit), where.arg(offset))
+
+	rows, err := r.db.Query(ctx, query, where.args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	return scanJobs(rows)
+}
+
+// countJobs возвращает количество вакансий, удовлетворяющих условиям.
+// Если maxCount больше нуля, подсчет останавливается на maxCount строках
+func (r *JobRepository) countJobs(ctx context.Context, where *whereClause, maxCount int) (int, error) {
+	query := "SELECT COUNT(*) FROM jobs_raw WHERE " + where.String()
+	if maxCount > 0 {
+		query = fmt.Sprintf("SELECT COUNT(*) FROM (SELECT 1 FROM jobs_raw WHERE %s LIMIT %s) AS capped", where, where.arg(maxCount))
+	}
+
+	var count int
+	err := r.db.QueryRow(ctx, query, where.args...).Scan(&count)
+	return count, err
+}
+
+// GetLatest возвращает последние вакансии с пагинацией
+func (r *JobRepository) GetLatest(ctx context.Context, filter entity.JobFilter, limit, offset int) ([]entity.JobRaw, error) {
+	where := newWhereClause(visibleJobCondition)
+	where.applyFilter(filter)
+
+	jobs, err := r.queryJobs(ctx, "jobs_raw", where, "date_posted DESC, id DESC", limit, offset)
+	if err != nil {
+		return nil, fmt.Errorf("не удалось получить список вакансий: %w", err)
+	}
+
+	return jobs, nil
+}
+
 // GetByTechnology возвращает вакансии по конкретной технологии с пагинацией
-func (r *JobRepository) GetByTechnology(ctx context.Context, technology string, limit, offset int) ([]entity.JobRaw, error) {
+func (r *JobRepository) GetByTechnology(ctx context.Context, technology string, filter entity.JobFilter, limit, offset int) ([]entity.JobRaw, error) {
+	where := newWhereClause(visibleJobCondition)
+	where.add("main_technology = " + where.arg(technology))
+	where.applyFilter(filter)
+
+	jobs, err := r.queryJobs(ctx, "jobs_raw", where, "date_posted DESC, id DESC", limit, offset)
+	if err != nil {
+		return nil, fmt.Errorf("не удалось получить список вакансий по технологии %s: %w", technology, err)
+	}
+
+	return jobs, nil
+}
+
+// GetByCursor возвращает вакансии, расположенные до или после курсора в списке,
+// отсортированном по (date_posted, id). Пустой список технологий означает все технологии.
+// Результат всегда упорядочен от новых вакансий к старым
+func (r *JobRepository) GetByCursor(
+	ctx context.Context,
+	technologies []string,
+	filter entity.JobFilter,
+	cursor entity.JobCursor,
+	direction entity.CursorDirection,
+	limit int,
+) ([]entity.JobRaw, error) {
+	where := newWhereClause(visibleJobCondition)
+	if len(technologies) > 0 {
+		where.add("main_technology = ANY(" + where.arg(technologies) + ")")
+	}
+	where.applyFilter(filter)
+
+	orderBy := "date_posted DESC, id DESC"
+	if !cursor.IsZero() {
+		comparison := "<"
+		if direction == entity.CursorNewer {
+			// Берем ближайшие к курсору более новые вакансии, поэтому сортируем по возрастанию
+			comparison = ">"
+			orderBy = "date_posted ASC, id ASC"
+		}
+		where.add(fmt.Sprintf("(date_posted, id) %s (%s, %s)", comparison, where.arg(cursor.DatePosted), where.arg(cursor.ID)))
+	}
+
+	jobs, err := r.queryJobs(ctx, "jobs_raw", where, orderBy, limit, 0)
+	if err != nil {
+		return nil, fmt.Errorf("не удалось получить список вакансий по курсору %s: %w", cursor, err)
+	}
+
+	if !cursor.IsZero() && direction == entity.CursorNewer {
+		for i, j := 0, len(jobs)-1; i < j; i, j = i+1, j-1 {
+			jobs[i], jobs[j] = jobs[j], jobs[i]
+		}
+	}
+
+	return jobs, nil
+}
+
+// GetByID возвращает вакансию по её ID
+func (r *JobRepository) GetByID(ctx context.Context, id int64) (entity.JobRaw, error) {
 	query := `
-		SELECT id, content, title, source_link, main_technology, content_pure, slug, date_posted, date_parsed
+		SELECT ` + jobColumns + `
 		FROM jobs_raw
-		WHERE main_technology = $1
-		ORDER BY date_posted DESC
-		LIMIT $2 OFFSET $3
+		WHERE id = $1 AND ` + visibleJobCondition
+
+	var job entity.JobRaw
+	err := r.db.QueryRow(ctx, query, id).Scan(jobScanTargets(&job)...)
+	if err != nil {
+		return entity.JobRaw{}, fmt.Errorf("не удалось получить вакансию с ID=%d: %w", id, err)
+	}
+
+	return job, nil
+}
+
+// GetRedirect возвращает ID вакансии, на которую перенаправляется адрес удаленного повтора с ID = oldID.
+// Возвращает false, если перенаправления нет
+func (r *JobRepository) GetRedirect(ctx context.Context, oldID int64) (int64, bool, error) {
+	var jobID int64
+	err := r.db.QueryRow(ctx, `SELECT job_id FROM job_redirects WHERE old_id = $1`, oldID).Scan(&jobID)
+	if err == pgx.ErrNoRows {
+		return 0, false, nil
+	}
+	if err != nil {
+		return 0, false, fmt.Errorf("не удалось получить перенаправление для вакансии с ID=%d: %w", oldID, err)
+	}
+
+	return jobID, true, nil
+}
+
+// GetRelated возвращает вакансии, похожие на указанную: сначала с той же основной технологией
+// и похожим заголовком (сходство по триграммам pg_trgm), затем более свежие
+func (r *JobRepository) GetRelated(ctx context.Context, job entity.JobRaw, filter entity.JobFilter, limit int) ([]entity.JobRaw, error) {
+	where := newWhereClause(visibleJobCondition)
+	idArg := where.arg(job.ID)
+	technologyArg := where.arg(job.MainTechnology)
+	titleArg := where.arg(job.Title)
+	where.add("id != " + idArg)
+	where.add(fmt.Sprintf("(main_technology = %s OR title %% %s)", technologyArg, titleArg))
+	where.applyFilter(filter)
+
+	orderBy := fmt.Sprintf(
+		"(CASE WHEN main_technology = %s THEN 1 ELSE 0 END) + similarity(title, %s) DESC, date_posted DESC, id DESC",
+		technologyArg, titleArg,
+	)
+
+	jobs, err := r.queryJobs(ctx, "jobs_raw", where, orderBy, limit, 0)
+	if err != nil {
+		return nil, fmt.Errorf("не удалось получить похожие вакансии для ID=%d: %w", job.ID, err)
+	}
+
+	return jobs, nil
+}
+
+// GetBatchAfterID возвращает вакансии с ID больше afterID по возрастанию ID, включая не показываемые на сайте.
+// Используется для пакетной обработки всей таблицы
+func (r *JobRepository) GetBatchAfterID(ctx context.Context, afterID int64, limit int) ([]entity.JobRaw, error) {
+	query := `
+		SELECT ` + jobColumns + `
+		FROM jobs_raw
+		WHERE id > $1
+		ORDER BY id
+		LIMIT $2
 	`
 
-	rows, err := r.db.Query(ctx, query, technology, limit, offset)
+	rows, err := r.db.Query(ctx, query, afterID, limit)
 	if err != nil {
-		return nil, fmt.Errorf("не удалось получить список вакансий по технологии %s: %w", technology, err)
+		return nil, fmt.Errorf("не удалось получить пакет вакансий после ID=%d: %w", afterID, err)
+	}
+	defer rows.Close()
+
+	return scanJobs(rows)
+}
+
+// Create сохраняет новую вакансию, если вакансии с такой же ссылкой на источник еще нет. Ссылка уникальна
+// (idx_jobs_raw_source_link), поэтому пост, загружаемый одновременно несколькими процессами, сохраняется один раз.
+// Слаг сохраняется в виде "{id}-{job.Slug}". Возвращает ID вакансии и false, если она уже была сохранена,
+// и ErrSlugTaken, если такой слаг уже занят
+func (r *JobRepository) Create(ctx context.Context, job entity.JobRaw) (int64, bool, error) {
+	query := `
+		WITH new_job AS (
+			SELECT nextval(pg_get_serial_sequence('jobs_raw', 'id')) AS id
+		)
+		INSERT INTO jobs_raw (
+			id, content, title, content_pure, source_link, main_technology, slug, stop_words,
+			salary_min, salary_max, salary_currency, salary_period, salary_tax,
+			seniority, location_constraints, location_countries, technology_scores, simhash, canonical_id,
+			content_sanitized, content_preview, meta_description, date_posted
+		)
+		SELECT
+			new_job.id, $1::TEXT, NULLIF($2::TEXT, ''), $3::TEXT, $4::VARCHAR, NULLIF($5::VARCHAR, ''),
+			new_job.id || '-' || $6::TEXT, $7::TEXT[],
+			NULLIF($8::BIGINT, 0), NULLIF($9::BIGINT, 0), NULLIF($10::VARCHAR, ''), NULLIF($11::VARCHAR, ''), NULLIF($12::VARCHAR, ''),
+			$13::TEXT[], $14::TEXT[], $15::TEXT[], $16::JSONB, NULLIF($17::BIGINT, 0), NULLIF($18::BIGINT, 0),
+			NULLIF($19::TEXT, ''), NULLIF($20::TEXT, ''), NULLIF($21::TEXT, ''), $22::TIMESTAMPTZ
+		FROM new_job
+		ON CONFLICT (source_link) DO NOTHING
+		RETURNING id
+	`
+
+	scores, err := marshalTechnologyScores(job.TechnologyScores)
+	if err != nil {
+		return 0, false, err
+	}
+
+	var id int64
+	err = r.db.QueryRow(ctx, query,
+		job.Content,
+		job.Title,
+		job.ContentPure,
+		job.SourceLink,
+		job.MainTechnology,
+		job.Slug,
+		job.StopWords,
+		job.Salary.Min,
+		job.Salary.Max,
+		job.Salary.Currency,
+		job.Salary.Period,
+		job.Salary.Tax,
+		job.Seniority,
+		job.Location.Constraints,
+		job.Location.Countries,
+		scores,
+		job.SimHash,
+		job.CanonicalID,
+		job.ContentSanitized,
+		job.ContentPreview,
+		job.MetaDescription,
+		job.DatePosted,
+	).Scan(&id)
+	if err == pgx.ErrNoRows {
+		return 0, false, nil
+	}
+	if isUniqueViolation(err, slugConstraint) {
+		return 0, false, ErrSlugTaken
+	}
+	if err != nil {
+		return 0, false, fmt.Errorf("не удалось сохранить вакансию %s: %w", job.SourceLink, err)
+	}
+
+	return id, true, nil
+}
+
+// UpdateEnrichment сохраняет данные, извлеченные из текста вакансий, и подготовленный к показу текст
+func (r *JobRepository) UpdateEnrichment(ctx context.Context, jobs []entity.JobRaw) error {
+	query := `
+		UPDATE jobs_raw
+		SET salary_min = NULLIF($2, 0),
+			salary_max = NULLIF($3, 0),
+			salary_currency = NULLIF($4, ''),
+			salary_period = NULLIF($5, ''),
+			salary_tax = NULLIF($6, ''),
+			seniority = $7,
+			location_constraints = $8,
+			location_countries = $9,
+			content_sanitized = NULLIF($10, ''),
+			content_preview = NULLIF($11, ''),
+			meta_description = NULLIF($12, '')
+		WHERE id = $1
+	`
+
+	batch := &pgx.Batch{}
+	for _, job := range jobs {
+		batch.Queue(query,
+			job.ID,
+			job.Salary.Min,
+			job.Salary.Max,
+			job.Salary.Currency,
+			job.Salary.Period,
+			job.Salary.Tax,
+			job.Seniority,
+			job.Location.Constraints,
+			job.Location.Countries,
+			job.ContentSanitized,
+			job.ContentPreview,
+			job.MetaDescription,
+		)
+	}
+
+	results := r.db.SendBatch(ctx, batch)
+	defer results.Close()
+
+	for _, job := range jobs {
+		if _, err := results.Exec(); err != nil {
+			return fmt.Errorf("не удалось обновить данные вакансии с ID=%d: %w", job.ID, err)
+		}
+	}
+
+	return nil
+}
+
+// GetClassifyBatchAfterID возвращает вакансии из выборки для классификации с ID больше afterID по возрастанию ID,
+// включая не показываемые на сайте. Вакансии, проверенные модератором, не выбираются
+func (r *JobRepository) GetClassifyBatchAfterID(ctx context.Context, filter entity.ClassifyFilter, afterID int64, limit int) ([]entity.JobRaw, error) {
+	where := classifyWhereClause(filter)
+	where.add("id > " + where.arg(afterID))
+
+	query := fmt.Sprintf(`
+		SELECT %s
+		FROM jobs_raw
+		WHERE %s
+		ORDER BY id
+		LIMIT %s
+	`, jobColumns, where, where.arg(limit))
+
+	rows, err := r.db.Query(ctx, query, where.args...)
+	if err != nil {
+		return nil, fmt.Errorf("не удалось получить пакет вакансий для классификации после ID=%d: %w", afterID, err)
+	}
+	defer rows.Close()
+
+	return scanJobs(rows)
+}
+
+// GetClassifyBatchBeforeID возвращает вакансии из выборки для классификации с ID меньше beforeID
+// по убыванию ID, то есть сначала самые новые. beforeID = 0 - начать с последней вакансии
+func (r *JobRepository) GetClassifyBatchBeforeID(ctx context.Context, filter entity.ClassifyFilter, beforeID int64, limit int) ([]entity.JobRaw, error) {
+	where := classifyWhereClause(filter)
+	if beforeID > 0 {
+		where.add("id < " + where.arg(beforeID))
+	}
+
+	query := fmt.Sprintf(`
+		SELECT %s
+		FROM jobs_raw
+		WHERE %s
+		ORDER BY id DESC
+		LIMIT %s
+	`, jobColumns, where, where.arg(limit))
+
+	rows, err := r.db.Query(ctx, query, where.args...)
+	if err != nil {
+		return nil, fmt.Errorf("не удалось получить пакет вакансий для классификации до ID=%d: %w", beforeID, err)
+	}
+	defer rows.Close()
+
+	return scanJobs(rows)
+}
+
+// classifyWhereClause условия выборки вакансий для классификации
+func classifyWhereClause(filter entity.ClassifyFilter) *whereClause {
+	// Технологию, назначенную модератором, классификатор не меняет
+	where := newWhereClause("moderation IS NULL")
+	if filter.Unclassified {
+		where.add("(main_technology IS NULL OR main_technology = '')")
+	}
+	if len(filter.Technologies) > 0 {
+		where.add("main_technology = ANY(" + where.arg(filter.Technologies) + ")")
+	}
+	if !filter.PostedFrom.IsZero() {
+		where.add("date_posted >= " + where.arg(filter.PostedFrom))
+	}
+	if !filter.PostedTo.IsZero() {
+		where.add("date_posted < " + where.arg(filter.PostedTo))
+	}
+	return where
+}
+
+// UpdateClassification сохраняет основную технологию вакансий и оценки классификатора
+func (r *JobRepository) UpdateClassification(ctx context.Context, jobs []entity.JobRaw) error {
+	query := `
+		UPDATE jobs_raw
+		SET main_technology = NULLIF($2::VARCHAR, ''),
+			technology_scores = $3::JSONB
+		WHERE id = $1
+	`
+
+	batch := &pgx.Batch{}
+	for _, job := range jobs {
+		scores, err := marshalTechnologyScores(job.TechnologyScores)
+		if err != nil {
+			return err
+		}
+		batch.Queue(query, job.ID, job.MainTechnology, scores)
+	}
+
+	results := r.db.SendBatch(ctx, batch)
+	defer results.Close()
+
+	for _, job := range jobs {
+		if _, err := results.Exec(); err != nil {
+			return fmt.Errorf("не удалось обновить технологию вакансии с ID=%d: %w", job.ID, err)
+		}
+	}
+
+	return nil
+}
+
+// unclassifiedJobCondition условие для вакансий, технология которых не определена
+const unclassifiedJobCondition = "(main_technology IS NULL OR main_technology = '')"
+
+// moderationQueueWhere собирает условия очереди модерации и возвращает их вместе с условием неуверенной
+// классификации. Оценки технологий хранятся в technology_scores по убыванию, поэтому лучшая оценка - первая
+func moderationQueueWhere(filter entity.ModerationFilter) (*whereClause, string) {
+	// В очередь попадают только вакансии, которые показывались бы на сайте с технологией: активные,
+	// не повторы и без стоп-слов - те же условия, что и у списков вакансий
+	where := newWhereClause("moderation IS NULL")
+	where.applyFilter(entity.JobFilter{StopWords: filter.StopWords})
+
+	topScore := "(technology_scores->0->>'score')::FLOAT8"
+	lowConfidence := fmt.Sprintf(
+		"(main_technology != '' AND jsonb_typeof(technology_scores) = 'array' AND (%s < %s OR coalesce((technology_scores->1->>'score')::FLOAT8, 0) >= %s * %s))",
+		topScore, where.arg(filter.LowScore), topScore, where.arg(filter.CloseRatio),
+	)
+
+	return where, lowConfidence
+}
+
+// GetModerationQueue возвращает вакансии очереди модерации от новых к старым вместе с оценками технологий
+func (r *JobRepository) GetModerationQueue(ctx context.Context, filter entity.ModerationFilter, limit, offset int) ([]entity.JobRaw, error) {
+	where, lowConfidence := moderationQueueWhere(filter)
+	switch filter.Reason {
+	case entity.ModerationReasonUnclassified:
+		where.add(unclassifiedJobCondition)
+	case entity.ModerationReasonLowConfidence:
+		where.add(lowConfidence)
+	default:
+		where.add("(" + unclassifiedJobCondition + " OR " + lowConfidence + ")")
+	}
+
+	query := fmt.Sprintf(`
+		SELECT %s, technology_scores
+		FROM jobs_raw
+		WHERE %s
+		ORDER BY date_posted DESC, id DESC
+		LIMIT %s OFFSET %s
+	`, jobColumns, where, where.arg(limit), where.arg(offset))
+
+	rows, err := r.db.Query(ctx, query, where.args...)
+	if err != nil {
+		return nil, fmt.Errorf("не удалось получить очередь модерации: %w", err)
 	}
 	defer rows.Close()
 
 	jobs := make([]entity.JobRaw, 0)
 	for rows.Next() {
 		var job entity.JobRaw
-		if err := rows.Scan(
-			&job.ID,
-			&job.Content,
-			&job.Title,
-			&job.SourceLink,
-			&job.MainTechnology,
-			&job.ContentPure,
-			&job.Slug,
-			&job.DatePosted,
-			&job.DateParsed,
-		); err != nil {
+		var scores []byte
+		if err := rows.Scan(append(jobScanTargets(&job), &scores)...); err != nil {
 			return nil, fmt.Errorf("не удалось обработать строку вакансии: %w", err)
 		}
+		if len(scores) > 0 {
+			if err := json.Unmarshal(scores, &job.TechnologyScores); err != nil {
+				return nil, fmt.Errorf("не удалось разобрать оценки технологий вакансии с ID=%d: %w", job.ID, err)
+			}
+		}
 		jobs = append(jobs, job)
 	}
 
@@ -106,26 +558,62 @@ func (r *JobRepository) GetByTechnology(ctx context.Context, technology string,
 	return jobs, nil
 }
 
-// GetByID возвращает вакансию по её ID
-func (r *JobRepository) GetByID(ctx context.Context, id int64) (entity.JobRaw, error) {
+// CountModerationQueue возвращает количество вакансий в очереди модерации по причинам
+func (r *JobRepository) CountModerationQueue(ctx context.Context, filter entity.ModerationFilter) (entity.ModerationCounts, error) {
+	where, lowConfidence := moderationQueueWhere(filter)
+	query := fmt.Sprintf(`
+		SELECT
+			COUNT(*) FILTER (WHERE %s),
+			COUNT(*) FILTER (WHERE %s)
+		FROM jobs_raw
+		WHERE %s
+	`, unclassifiedJobCondition, lowConfidence, where)
+
+	var counts entity.ModerationCounts
+	if err := r.db.QueryRow(ctx, query, where.args...).Scan(&counts.Unclassified, &counts.LowConfidence); err != nil {
+		return entity.ModerationCounts{}, fmt.Errorf("не удалось посчитать вакансии в очереди модерации: %w", err)
+	}
+
+	return counts, nil
+}
+
+// UpdateModeration сохраняет решение модератора по вакансии. Если technology не пустая,
+// она становится основной технологией вакансии. Пустое решение снимает отметку модератора:
+// вакансия снова считается непроверенной. Возвращает ErrJobNotFound, если вакансии нет
+func (r *JobRepository) UpdateModeration(ctx context.Context, id int64, moderation, technology string) error {
+	query := `
+		UPDATE jobs_raw
+		SET moderation = NULLIF($2::VARCHAR, ''),
+			main_technology = coalesce(NULLIF($3::VARCHAR, ''), main_technology),
+			date_moderated = CASE WHEN $2::VARCHAR = '' THEN NULL ELSE NOW() END
+		WHERE id = $1
+	`
+
+	tag, err := r.db.Exec(ctx, query, id, moderation, technology)
+	if err != nil {
+		return fmt.Errorf("не удалось сохранить решение модератора по вакансии с ID=%d: %w", id, err)
+	}
+	if tag.RowsAffected() == 0 {
+		return ErrJobNotFound
+	}
+
+	return nil
+}
+
+// GetByIDForAdmin возвращает вакансию по её ID, в том числе не показываемую на сайте.
+// Возвращает ErrJobNotFound, если вакансии нет
+func (r *JobRepository) GetByIDForAdmin(ctx context.Context, id int64) (entity.JobRaw, error) {
 	query := `
-		SELECT id, content, title, source_link, main_technology, content_pure, slug, date_posted, date_parsed
+		SELECT ` + jobColumns + `
 		FROM jobs_raw
-		WHERE id = $1 AND main_technology IS NOT NULL AND main_technology != ''
+		WHERE id = $1
 	`
 
 	var job entity.JobRaw
-	err := r.db.QueryRow(ctx, query, id).Scan(
-		&job.ID,
-		&job.Content,
-		&job.Title,
-		&job.SourceLink,
-		&job.MainTechnology,
-		&job.ContentPure,
-		&job.Slug,
-		&job.DatePosted,
-		&job.DateParsed,
-	)
+	err := r.db.QueryRow(ctx, query, id).Scan(jobScanTargets(&job)...)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return entity.JobRaw{}, ErrJobNotFound
+	}
 	if err != nil {
 		return entity.JobRaw{}, fmt.Errorf("не удалось получить вакансию с ID=%d: %w", id, err)
 	}
@@ -133,12 +621,407 @@ func (r *JobRepository) GetByID(ctx context.Context, id int64) (entity.JobRaw, e
 	return job, nil
 }
 
-// GetTotalCount возвращает общее количество вакансий
-func (r *JobRepository) GetTotalCount(ctx context.Context) (int, error) {
-	query := "SELECT COUNT(*) FROM jobs_raw WHERE main_technology IS NOT NULL AND main_technology != ''"
+// UpdateByAdmin сохраняет заголовок, основную технологию и текст вакансии, измененные в админке,
+// вместе с пересчитанными из текста данными. Вакансия отмечается проверенной модератором, чтобы повторная
+// классификация не меняла выбранную технологию. Возвращает ErrJobNotFound, если вакансии нет
+func (r *JobRepository) UpdateByAdmin(ctx context.Context, job entity.JobRaw) error {
+	query := `
+		UPDATE jobs_raw
+		SET title = NULLIF($2, ''),
+			main_technology = NULLIF($3, ''),
+			content = $4,
+			content_pure = $5,
+			salary_min = NULLIF($6, 0),
+			salary_max = NULLIF($7, 0),
+			salary_currency = NULLIF($8, ''),
+			salary_period = NULLIF($9, ''),
+			salary_tax = NULLIF($10, ''),
+			seniority = $11,
+			location_constraints = $12,
+			location_countries = $13,
+			content_sanitized = NULLIF($14, ''),
+			content_preview = NULLIF($15, ''),
+			meta_description = NULLIF($16, ''),
+			moderation = coalesce(moderation, '` + entity.ModerationApproved + `'),
+			date_moderated = coalesce(date_moderated, NOW())
+		WHERE id = $1
+	`
 
-	var count int
-	err := r.db.QueryRow(ctx, query).Scan(&count)
+	tag, err := r.db.Exec(ctx, query,
+		job.ID,
+		job.Title,
+		job.MainTechnology,
+		job.Content,
+		job.ContentPure,
+		job.Salary.Min,
+		job.Salary.Max,
+		job.Salary.Currency,
+		job.Salary.Period,
+		job.Salary.Tax,
+		job.Seniority,
+		job.Location.Constraints,
+		job.Location.Countries,
+		job.ContentSanitized,
+		job.ContentPreview,
+		job.MetaDescription,
+	)
+	if err != nil {
+		return fmt.Errorf("не удалось сохранить вакансию с ID=%d: %w", job.ID, err)
+	}
+	if tag.RowsAffected() == 0 {
+		return ErrJobNotFound
+	}
+
+	return nil
+}
+
+// SetHidden скрывает вакансию с сайта или снова показывает её. Возвращает ErrJobNotFound, если вакансии нет
+func (r *JobRepository) SetHidden(ctx context.Context, id int64, hidden bool) error {
+	tag, err := r.db.Exec(ctx, "UPDATE jobs_raw SET hidden = $2 WHERE id = $1", id, hidden)
+	if err != nil {
+		return fmt.Errorf("не удалось изменить видимость вакансии с ID=%d: %w", id, err)
+	}
+	if tag.RowsAffected() == 0 {
+		return ErrJobNotFound
+	}
+
+	return nil
+}
+
+// Remove переводит вакансию в статус removed. Строка остается в базе: повторы по-прежнему ссылаются
+// на неё через canonical_id, а уникальная ссылка на источник не дает загрузить пост снова.
+// Возвращает ErrJobNotFound, если вакансии нет
+func (r *JobRepository) Remove(ctx context.Context, id int64) error {
+	query := "UPDATE jobs_raw SET status = $2, date_status_changed = NOW() WHERE id = $1"
+	tag, err := r.db.Exec(ctx, query, id, entity.JobStatusRemoved)
+	if err != nil {
+		return fmt.Errorf("не удалось удалить вакансию с ID=%d: %w", id, err)
+	}
+	if tag.RowsAffected() == 0 {
+		return ErrJobNotFound
+	}
+
+	return nil
+}
+
+// AddAuditEntry сохраняет запись журнала изменений вакансии
+func (r *JobRepository) AddAuditEntry(ctx context.Context, entry entity.JobAuditEntry) error {
+	changes, err := json.Marshal(entry.Changes)
+	if err != nil {
+		return fmt.Errorf("не удалось сериализовать изменения вакансии с ID=%d: %w", entry.JobID, err)
+	}
+
+	query := `
+		INSERT INTO job_audit_log (job_id, action, actor, comment, changes)
+		VALUES ($1, $2, $3, $4, $5::JSONB)
+	`
+
+	if _, err := r.db.Exec(ctx, query, entry.JobID, entry.Action, entry.Actor, entry.Comment, string(changes)); err != nil {
+		return fmt.Errorf("не удалось сохранить запись журнала вакансии с ID=%d: %w", entry.JobID, err)
+	}
+
+	return nil
+}
+
+// GetAuditLog возвращает записи журнала изменений от новых к старым: вакансии jobID
+// или всех вакансий, если jobID равен 0
+func (r *JobRepository) GetAuditLog(ctx context.Context, jobID int64, limit int) ([]entity.JobAuditEntry, error) {
+	where := newWhereClause()
+	if jobID != 0 {
+		where.add("job_id = " + where.arg(jobID))
+	}
+
+	query := fmt.Sprintf(`
+		SELECT id, job_id, action, actor, comment, changes, date_created
+		FROM job_audit_log
+		WHERE %s
+		ORDER BY id DESC
+		LIMIT %s
+	`, where, where.arg(limit))
+
+	rows, err := r.db.Query(ctx, query, where.args...)
+	if err != nil {
+		return nil, fmt.Errorf("не удалось получить журнал изменений вакансий: %w", err)
+	}
+	defer rows.Close()
+
+	entries := make([]entity.JobAuditEntry, 0)
+	for rows.Next() {
+		var entry entity.JobAuditEntry
+		var changes []byte
+		if err := rows.Scan(&entry.ID, &entry.JobID, &entry.Action, &entry.Actor, &entry.Comment, &changes, &entry.DateCreated); err != nil {
+			return nil, fmt.Errorf("не удалось обработать строку журнала изменений: %w", err)
+		}
+		if err := json.Unmarshal(changes, &entry.Changes); err != nil {
+			return nil, fmt.Errorf("не удалось разобрать изменения записи журнала с ID=%d: %w", entry.ID, err)
+		}
+		entries = append(entries, entry)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("ошибка при обработке результатов запроса: %w", err)
+	}
+
+	return entries, nil
+}
+
+// CountStopWordMatches возвращает для каждого слова количество вакансий, в заголовке или тексте которых оно
+// встречается, и сколько из них сейчас показываются на сайте: активны, не помечены стоп-словами
+// и не содержат слов из existing (стоп-слова, уже действующие в справочнике). Результат в порядке words
+func (r *JobRepository) CountStopWordMatches(ctx context.Context, words, existing []string) ([]entity.StopWordImpact, error) {
+	where := newWhereClause(visibleJobCondition)
+	where.applyFilter(entity.JobFilter{StopWords: existing})
+	wordsArg := where.arg(words)
+	patternsArg := where.arg(wordPatterns(words))
+
+	query := fmt.Sprintf(`
+		SELECT w.word,
+			COUNT(j.id),
+			COUNT(j.id) FILTER (WHERE %s)
+		FROM unnest(%s::TEXT[], %s::TEXT[]) WITH ORDINALITY AS w(word, pattern, position)
+		LEFT JOIN jobs_raw AS j ON %s ~* w.pattern
+		GROUP BY w.word, w.position
+		ORDER BY w.position
+	`, where, wordsArg, patternsArg, jobTextExpression)
+
+	rows, err := r.db.Query(ctx, query, where.args...)
+	if err != nil {
+		return nil, fmt.Errorf("не удалось посчитать вакансии со стоп-словами: %w", err)
+	}
+	defer rows.Close()
+
+	impacts := make([]entity.StopWordImpact, 0, len(words))
+	for rows.Next() {
+		var impact entity.StopWordImpact
+		if err := rows.Scan(&impact.Word, &impact.Matched, &impact.Visible); err != nil {
+			return nil, fmt.Errorf("не удалось обработать строку со стоп-словом: %w", err)
+		}
+		impacts = append(impacts, impact)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("ошибка при обработке результатов запроса: %w", err)
+	}
+
+	return impacts, nil
+}
+
+// MarkStopWord добавляет стоп-слово в колонку stop_words вакансий, в заголовке или тексте которых оно встречается.
+// Возвращает количество помеченных вакансий
+func (r *JobRepository) MarkStopWord(ctx context.Context, word string) (int64, error) {
+	query := `
+		UPDATE jobs_raw
+		SET stop_words = array_append(coalesce(stop_words, '{}'), $1::TEXT)
+		WHERE ` + jobTextExpression + ` ~* $2
+			AND NOT ($1::TEXT = ANY(coalesce(stop_words, '{}')))
+	`
+
+	tag, err := r.db.Exec(ctx, query, word, wordPatterns([]string{word})[0])
+	if err != nil {
+		return 0, fmt.Errorf("не удалось пометить вакансии стоп-словом %s: %w", word, err)
+	}
+
+	return tag.RowsAffected(), nil
+}
+
+// UnmarkStopWord убирает стоп-слово из колонки stop_words вакансий. Возвращает количество измененных вакансий
+func (r *JobRepository) UnmarkStopWord(ctx context.Context, word string) (int64, error) {
+	query := `
+		UPDATE jobs_raw
+		SET stop_words = array_remove(stop_words, $1::TEXT)
+		WHERE $1::TEXT = ANY(stop_words)
+	`
+
+	tag, err := r.db.Exec(ctx, query, word)
+	if err != nil {
+		return 0, fmt.Errorf("не удалось убрать стоп-слово %s из вакансий: %w", word, err)
+	}
+
+	return tag.RowsAffected(), nil
+}
+
+// FindNearDuplicateCandidates возвращает отпечатки вакансий, опубликованных в период [from, to], у которых
+// совпадает хотя бы одна полоса SimHash. Если beforeID больше нуля, учитываются только вакансии с меньшим ID.
+// Совпадение полосы не означает, что тексты почти одинаковы, расстояние между SimHash проверяется отдельно
+func (r *JobRepository) FindNearDuplicateCandidates(
+	ctx context.Context,
+	simHash int64,
+	beforeID int64,
+	from, to time.Time,
+	limit int,
+) ([]entity.JobFingerprint, error) {
+	where := newWhereClause()
+	where.add("simhash_bands && simhash_bands(" + where.arg(simHash) + "::BIGINT)")
+	where.add("date_posted BETWEEN " + where.arg(from) + " AND " + where.arg(to))
+	if beforeID > 0 {
+		where.add("id < " + where.arg(beforeID))
+	}
+
+	query := fmt.Sprintf(`
+		SELECT id, simhash, coalesce(canonical_id, 0)
+		FROM jobs_raw
+		WHERE %s
+		ORDER BY id
+		LIMIT %s
+	`, where, where.arg(limit))
+
+	rows, err := r.db.Query(ctx, query, where.args...)
+	if err != nil {
+		return nil, fmt.Errorf("не удалось найти кандидатов в повторы вакансии: %w", err)
+	}
+	defer rows.Close()
+
+	candidates := make([]entity.JobFingerprint, 0)
+	for rows.Next() {
+		var candidate entity.JobFingerprint
+		if err := rows.Scan(&candidate.ID, &candidate.SimHash, &candidate.CanonicalID); err != nil {
+			return nil, fmt.Errorf("не удалось обработать строку отпечатка вакансии: %w", err)
+		}
+		candidates = append(candidates, candidate)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("ошибка при обработке результатов запроса: %w", err)
+	}
+
+	return candidates, nil
+}
+
+// UpdateDeduplication сохраняет SimHash вакансии и ID канонической вакансии (0 - вакансия сама каноническая)
+func (r *JobRepository) UpdateDeduplication(ctx context.Context, id, simHash, canonicalID int64) error {
+	query := `
+		UPDATE jobs_raw
+		SET simhash = NULLIF($2::BIGINT, 0),
+			canonical_id = NULLIF($3::BIGINT, 0)
+		WHERE id = $1
+	`
+
+	if _, err := r.db.Exec(ctx, query, id, simHash, canonicalID); err != nil {
+		return fmt.Errorf("не удалось обновить повторы вакансии с ID=%d: %w", id, err)
+	}
+
+	return nil
+}
+
+// GetDuplicates возвращает остальные публикации той же вакансии в других источниках:
+// каноническую вакансию и её повторы, кроме удаленных и не показываемых на сайте, от ранних к поздним
+func (r *JobRepository) GetDuplicates(ctx context.Context, job entity.JobRaw) ([]entity.JobRaw, error) {
+	rootID := entity.JobFingerprint{ID: job.ID, CanonicalID: job.CanonicalID}.RootID()
+
+	query := `
+		SELECT ` + jobColumns + `
+		FROM jobs_raw
+		WHERE (id = $1 OR canonical_id = $1) AND id != $2 AND status != $3 AND ` + visibleJobCondition + `
+		ORDER BY date_posted, id
+	`
+
+	rows, err := r.db.Query(ctx, query, rootID, job.ID, entity.JobStatusRemoved)
+	if err != nil {
+		return nil, fmt.Errorf("не удалось получить повторы вакансии с ID=%d: %w", job.ID, err)
+	}
+	defer rows.Close()
+
+	return scanJobs(rows)
+}
+
+// UpdateSlug сохраняет слаг вакансии. Возвращает ErrSlugTaken, если слаг занят другой вакансией
+func (r *JobRepository) UpdateSlug(ctx context.Context, id int64, slug string) error {
+	_, err := r.db.Exec(ctx, "UPDATE jobs_raw SET slug = $2 WHERE id = $1", id, slug)
+	if isUniqueViolation(err, slugConstraint) {
+		return ErrSlugTaken
+	}
+	if err != nil {
+		return fmt.Errorf("не удалось обновить слаг вакансии с ID=%d: %w", id, err)
+	}
+
+	return nil
+}
+
+// UpdateStatuses переводит вакансии в следующие статусы жизненного цикла по возрасту. Пороги берутся
+// из технологии вакансии, а если они не заданы или у вакансии нет технологии - из defaults.
+// Статус меняется только вперед, поэтому увеличение порога не возвращает вакансии в списки.
+// Возвращает количество вакансий, переведенных в каждый статус
+func (r *JobRepository) UpdateStatuses(ctx context.Context, defaults entity.JobLifecycle, now time.Time) (map[string]int64, error) {
+	query := `
+		WITH thresholds AS (
+			SELECT j.id,
+				CASE
+					WHEN j.date_posted < $1::TIMESTAMPTZ - make_interval(days => coalesce(t.remove_after_days, $4::INT)) THEN $8::TEXT
+					WHEN j.date_posted < $1::TIMESTAMPTZ - make_interval(days => coalesce(t.archive_after_days, $3::INT)) THEN $7::TEXT
+					WHEN j.date_posted < $1::TIMESTAMPTZ - make_interval(days => coalesce(t.expire_after_days, $2::INT)) THEN $6::TEXT
+					ELSE $5::TEXT
+				END AS status
+			FROM jobs_raw AS j
+			LEFT JOIN technologies AS t ON t.technology = j.main_technology
+			WHERE j.status != $8::TEXT
+		),
+		updated AS (
+			UPDATE jobs_raw AS j
+			SET status = thresholds.status,
+				date_status_changed = $1::TIMESTAMPTZ
+			FROM thresholds
+			WHERE j.id = thresholds.id
+				AND array_position(ARRAY[$5::TEXT, $6::TEXT, $7::TEXT, $8::TEXT], thresholds.status)
+					> array_position(ARRAY[$5::TEXT, $6::TEXT, $7::TEXT, $8::TEXT], j.status::TEXT)
+			RETURNING j.status
+		)
+		SELECT status, COUNT(*)
+		FROM updated
+		GROUP BY status
+	`
+
+	rows, err := r.db.Query(ctx, query,
+		now,
+		defaults.ExpireAfterDays,
+		defaults.ArchiveAfterDays,
+		defaults.RemoveAfterDays,
+		entity.JobStatusActive,
+		entity.JobStatusExpired,
+		entity.JobStatusArchived,
+		entity.JobStatusRemoved,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("не удалось обновить статусы вакансий: %w", err)
+	}
+	defer rows.Close()
+
+	changed := make(map[string]int64)
+	for rows.Next() {
+		var status string
+		var count int64
+		if err := rows.Scan(&status, &count); err != nil {
+			return nil, fmt.Errorf("не удалось обработать строку статуса: %w", err)
+		}
+		changed[status] = count
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("ошибка при обработке результатов запроса: %w", err)
+	}
+
+	return changed, nil
+}
+
+// marshalTechnologyScores преобразует оценки классификатора в JSON. Пустые оценки сохраняются как NULL
+func marshalTechnologyScores(scores []entity.TechnologyScore) (interface{}, error) {
+	if len(scores) == 0 {
+		return nil, nil
+	}
+
+	data, err := json.Marshal(scores)
+	if err != nil {
+		return nil, fmt.Errorf("не удалось преобразовать оценки технологий в JSON: %w", err)
+	}
+
+	return string(data), nil
+}
+
+// GetTotalCount возвращает общее количество вакансий, но не больше maxCount (0 - без ограничения)
+func (r *JobRepository) GetTotalCount(ctx context.Context, filter entity.JobFilter, maxCount int) (int, error) {
+	where := newWhereClause(visibleJobCondition)
+	where.applyFilter(filter)
+
+	count, err := r.countJobs(ctx, where, maxCount)
 	if err != nil {
 		return 0, fmt.Errorf("не удалось получить общее количество вакансий: %w", err)
 	}
@@ -146,15 +1029,51 @@ func (r *JobRepository) GetTotalCount(ctx context.Context) (int, error) {
 	return count, nil
 }
 
-// GetTotalCountByTechnology возвращает общее количество вакансий по технологии
-func (r *JobRepository) GetTotalCountByTechnology(ctx context.Context, technology string) (int, error) {
-	query := "SELECT COUNT(*) FROM jobs_raw WHERE main_technology = $1"
+// GetTotalCountByTechnology возвращает общее количество вакансий по технологии, но не больше maxCount
+func (r *JobRepository) GetTotalCountByTechnology(ctx context.Context, technology string, filter entity.JobFilter, maxCount int) (int, error) {
+	where := newWhereClause(visibleJobCondition)
+	where.add("main_technology = " + where.arg(technology))
+	where.applyFilter(filter)
 
-	var count int
-	err := r.db.QueryRow(ctx, query, technology).Scan(&count)
+	count, err := r.countJobs(ctx, where, maxCount)
 	if err != nil {
 		return 0, fmt.Errorf("не удалось получить количество вакансий по технологии %s: %w", technology, err)
 	}
 
 	return count, nil
 }
+
+// Search возвращает вакансии, найденные полнотекстовым поиском, отсортированные по релевантности
+func (r *JobRepository) Search(ctx context.Context, searchQuery string, filter entity.JobFilter, limit, offset int) ([]entity.JobRaw, error) {
+	where := newWhereClause()
+	queryArg := where.arg(searchQuery)
+	where.add(visibleJobCondition)
+	where.add("search_vector @@ search.q")
+	where.applyFilter(filter)
+
+	from := fmt.Sprintf(`jobs_raw, (
+			SELECT websearch_to_tsquery('russian', %[1]s) || websearch_to_tsquery('english', %[1]s) AS q
+		) AS search`, queryArg)
+
+	jobs, err := r.queryJobs(ctx, from, where, "ts_rank(search_vector, search.q) DESC, date_posted DESC, id DESC", limit, offset)
+	if err != nil {
+		return nil, fmt.Errorf("не удалось выполнить поиск вакансий по запросу %q: %w", searchQuery, err)
+	}
+
+	return jobs, nil
+}
+
+// GetTotalCountBySearch возвращает количество вакансий, найденных полнотекстовым поиском, но не больше maxCount
+func (r *JobRepository) GetTotalCountBySearch(ctx context.Context, searchQuery string, filter entity.JobFilter, maxCount int) (int, error) {
+	where := newWhereClause(visibleJobCondition)
+	queryArg := where.arg(searchQuery)
+	where.add(fmt.Sprintf("search_vector @@ (websearch_to_tsquery('russian', %[1]s) || websearch_to_tsquery('english', %[1]s))", queryArg))
+	where.applyFilter(filter)
+
+	count, err := r.countJobs(ctx, where, maxCount)
+	if err != nil {
+		return 0, fmt.Errorf("не удалось получить количество вакансий по запросу %q: %w", searchQuery, err)
+	}
+
+	return count, nil
+}
@@ -0,0 +1,244 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v4"
+	"github.com/jackc/pgx/v4/pgxpool"
+	"github.com/zalhonan/remotejobs-site/internal/domain/entity"
+	"go.uber.org/zap"
+)
+
+// errRecorded ошибка, которую возвращает recordingQuerier вместо выполнения запроса
+var errRecorded = errors.New("запрос записан")
+
+// recordingQuerier записывает тексты запросов и не выполняет их
+type recordingQuerier struct {
+	querier
+	queries []string
+}
+
+func (q *recordingQuerier) Query(_ context.Context, sql string, _ ...interface{}) (pgx.Rows, error) {
+	q.queries = append(q.queries, sql)
+	return nil, errRecorded
+}
+
+func (q *recordingQuerier) QueryRow(_ context.Context, sql string, _ ...interface{}) pgx.Row {
+	q.queries = append(q.queries, sql)
+	return errorRow{}
+}
+
+type errorRow struct{}
+
+func (errorRow) Scan(...interface{}) error {
+	return errRecorded
+}
+
+func TestPublicQueriesUseVisibleJobCondition(t *testing.T) {
+	ctx := context.Background()
+	tests := []struct {
+		name string
+		call func(r *JobRepository) error
+	}{
+		{"GetByTechnology", func(r *JobRepository) error {
+			_, err := r.GetByTechnology(ctx, "go", entity.JobFilter{}, 10, 0)
+			return err
+		}},
+		{"GetTotalCountByTechnology", func(r *JobRepository) error {
+			_, err := r.GetTotalCountByTechnology(ctx, "go", entity.JobFilter{}, 0)
+			return err
+		}},
+		{"GetByCursor с технологиями", func(r *JobRepository) error {
+			_, err := r.GetByCursor(ctx, []string{"go", "php"}, entity.JobFilter{}, entity.JobCursor{}, entity.CursorOlder, 10)
+			return err
+		}},
+		{"GetByCursor без технологий", func(r *JobRepository) error {
+			_, err := r.GetByCursor(ctx, nil, entity.JobFilter{}, entity.JobCursor{}, entity.CursorOlder, 10)
+			return err
+		}},
+		{"GetDuplicates", func(r *JobRepository) error {
+			_, err := r.GetDuplicates(ctx, entity.JobRaw{ID: 1})
+			return err
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db := &recordingQuerier{}
+			repo := &JobRepository{db: db, logger: zap.NewNop()}
+
+			if err := tt.call(repo); !errors.Is(err, errRecorded) {
+				t.Fatalf("ожидалась ошибка %v, получено %v", errRecorded, err)
+			}
+			if len(db.queries) != 1 {
+				t.Fatalf("ожидался один запрос, выполнено %d", len(db.queries))
+			}
+			if !strings.Contains(db.queries[0], visibleJobCondition) {
+				t.Errorf("запрос не содержит условие показа вакансии:\n%s", db.queries[0])
+			}
+		})
+	}
+}
+
+func TestModerationQueueUsesListingFilter(t *testing.T) {
+	stopWords := []string{"курс", "стажировка"}
+
+	queue, _ := moderationQueueWhere(entity.ModerationFilter{StopWords: stopWords})
+	listing := newWhereClause()
+	listing.applyFilter(entity.JobFilter{StopWords: stopWords})
+
+	for _, condition := range listing.conditions {
+		if !slices.Contains(queue.conditions, condition) {
+			t.Errorf("в условиях очереди модерации нет условия списков: %s", condition)
+		}
+	}
+}
+
+func TestApplyFilterStatuses(t *testing.T) {
+	tests := []struct {
+		name   string
+		filter entity.JobFilter
+		want   string
+	}{
+		{"только активные", entity.JobFilter{}, "status = 'active'"},
+		{"с устаревшими", entity.JobFilter{IncludeExpired: true}, "status IN ('active', 'expired')"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			where := newWhereClause()
+			where.applyFilter(tt.filter)
+			if !slices.Contains(where.conditions, tt.want) {
+				t.Errorf("нет условия %q среди %v", tt.want, where.conditions)
+			}
+		})
+	}
+}
+
+func TestWordPatterns(t *testing.T) {
+	tests := []struct {
+		word string
+		want string
+	}{
+		{"java", `\mjava\M`},
+		{"курс", `\mкурс\M`},
+		{"c++", `\mc\+\+`},
+		{".net", `\.net\M`},
+		{"1с", `\m1с\M`},
+		{"node.js", `\mnode\.js\M`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.word, func(t *testing.T) {
+			if got := wordPatterns([]string{tt.word})[0]; got != tt.want {
+				t.Errorf("wordPatterns(%q) = %q, ожидалось %q", tt.word, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPrefixPattern(t *testing.T) {
+	tests := []struct {
+		prefix string
+		want   string
+	}{
+		{"https://t.me/", "https://t.me/%"},
+		{"https://jobs_board.example/100%/", `https://jobs\_board.example/100\%/%`},
+		{`c:\jobs`, `c:\\jobs%`},
+	}
+
+	for _, tt := range tests {
+		if got := prefixPattern(tt.prefix); got != tt.want {
+			t.Errorf("prefixPattern(%q) = %q, ожидалось %q", tt.prefix, got, tt.want)
+		}
+	}
+}
+
+// TestHiddenJobMissingFromTechnologyPage проверяет на настоящей базе, что скрытая вакансия и пост,
+// отмеченный модератором как не вакансия, не попадают на страницу технологии.
+// Нужна база с примененными миграциями в TEST_DATABASE_URL, без неё тест пропускается
+func TestHiddenJobMissingFromTechnologyPage(t *testing.T) {
+	dsn := os.Getenv("TEST_DATABASE_URL")
+	if dsn == "" {
+		t.Skip("TEST_DATABASE_URL не задан")
+	}
+
+	ctx := context.Background()
+	pool, err := pgxpool.Connect(ctx, dsn)
+	if err != nil {
+		t.Fatalf("не удалось подключиться к базе: %v", err)
+	}
+	defer pool.Close()
+
+	repo := NewJobRepository(pool, zap.NewNop())
+	suffix := time.Now().UnixNano()
+	technology := fmt.Sprintf("test-%d", suffix)
+
+	create := func(name string) int64 {
+		id, inserted, err := repo.Create(ctx, entity.JobRaw{
+			Content:        name,
+			Title:          name,
+			ContentPure:    name,
+			SourceLink:     fmt.Sprintf("https://example.com/%d/%s", suffix, name),
+			MainTechnology: technology,
+			Slug:           name,
+			DatePosted:     time.Now(),
+		})
+		if err != nil || !inserted {
+			t.Fatalf("не удалось сохранить вакансию %s: inserted=%v, err=%v", name, inserted, err)
+		}
+		t.Cleanup(func() { _, _ = pool.Exec(ctx, "DELETE FROM jobs_raw WHERE id = $1", id) })
+		return id
+	}
+
+	visibleID := create("visible")
+	hiddenID := create("hidden")
+	notVacancyID := create("not-vacancy")
+
+	if err := repo.SetHidden(ctx, hiddenID, true); err != nil {
+		t.Fatal(err)
+	}
+	if err := repo.UpdateModeration(ctx, notVacancyID, entity.ModerationNotVacancy, ""); err != nil {
+		t.Fatal(err)
+	}
+
+	jobs, err := repo.GetByTechnology(ctx, technology, entity.JobFilter{}, 10, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(jobs) != 1 || jobs[0].ID != visibleID {
+		t.Errorf("на странице технологии ожидалась только вакансия %d, получено %v", visibleID, jobIDs(jobs))
+	}
+
+	count, err := repo.GetTotalCountByTechnology(ctx, technology, entity.JobFilter{}, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if count != 1 {
+		t.Errorf("ожидалась 1 вакансия технологии, получено %d", count)
+	}
+
+	jobs, err = repo.GetByCursor(ctx, []string{technology}, entity.JobFilter{}, entity.JobCursor{}, entity.CursorOlder, 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(jobs) != 1 || jobs[0].ID != visibleID {
+		t.Errorf("в выборке по курсору ожидалась только вакансия %d, получено %v", visibleID, jobIDs(jobs))
+	}
+}
+
+// jobIDs возвращает ID вакансий
+func jobIDs(jobs []entity.JobRaw) []int64 {
+	ids := make([]int64, 0, len(jobs))
+	for _, job := range jobs {
+		ids = append(ids, job.ID)
+	}
+	return ids
+}
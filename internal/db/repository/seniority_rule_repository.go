@@ -0,0 +1,53 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/jackc/pgx/v4/pgxpool"
+	"github.com/zalhonan/remotejobs-site/internal/domain/entity"
+	"go.uber.org/zap"
+)
+
+type SeniorityRuleRepository struct {
+	db     *pgxpool.Pool
+	logger *zap.Logger
+}
+
+// NewSeniorityRuleRepository создает новый репозиторий для работы с правилами определения уровня позиции
+func NewSeniorityRuleRepository(db *pgxpool.Pool, logger *zap.Logger) *SeniorityRuleRepository {
+	return &SeniorityRuleRepository{
+		db:     db,
+		logger: logger,
+	}
+}
+
+// GetAll возвращает все правила определения уровня позиции
+func (r *SeniorityRuleRepository) GetAll(ctx context.Context) ([]entity.SeniorityRule, error) {
+	query := `
+		SELECT id, level, keywords
+		FROM seniority_rules
+		ORDER BY id ASC
+	`
+
+	rows, err := r.db.Query(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("не удалось получить правила определения уровня позиции: %w", err)
+	}
+	defer rows.Close()
+
+	rules := make([]entity.SeniorityRule, 0)
+	for rows.Next() {
+		var rule entity.SeniorityRule
+		if err := rows.Scan(&rule.ID, &rule.Level, &rule.Keywords); err != nil {
+			return nil, fmt.Errorf("не удалось обработать строку правила уровня позиции: %w", err)
+		}
+		rules = append(rules, rule)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("ошибка при обработке результатов запроса: %w", err)
+	}
+
+	return rules, nil
+}
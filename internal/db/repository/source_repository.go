@@ -0,0 +1,123 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/jackc/pgx/v4/pgxpool"
+	"github.com/zalhonan/remotejobs-site/internal/domain/entity"
+	"go.uber.org/zap"
+)
+
+// sourceColumns список колонок, читаемых в порядке sourceScanTargets
+const sourceColumns = "id, kind, name, url, state, enabled, date_source_added, items_parsed, date_last_parsed, coalesce(last_error, '')"
+
+type SourceRepository struct {
+	db     *pgxpool.Pool
+	logger *zap.Logger
+}
+
+// NewSourceRepository создает новый репозиторий для работы с источниками вакансий
+func NewSourceRepository(db *pgxpool.Pool, logger *zap.Logger) *SourceRepository {
+	return &SourceRepository{
+		db:     db,
+		logger: logger,
+	}
+}
+
+// sourceScanTargets возвращает указатели на поля источника в порядке колонок sourceColumns
+func sourceScanTargets(src *entity.Source) []interface{} {
+	return []interface{}{
+		&src.ID,
+		&src.Kind,
+		&src.Name,
+		&src.URL,
+		&src.State,
+		&src.Enabled,
+		&src.DateSourceAdded,
+		&src.ItemsParsed,
+		&src.DateLastParsed,
+		&src.LastError,
+	}
+}
+
+// GetEnabled возвращает включенные источники в порядке добавления
+func (r *SourceRepository) GetEnabled(ctx context.Context) ([]entity.Source, error) {
+	query := `
+		SELECT ` + sourceColumns + `
+		FROM sources
+		WHERE enabled
+		ORDER BY id ASC
+	`
+
+	rows, err := r.db.Query(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("не удалось получить список источников: %w", err)
+	}
+	defer rows.Close()
+
+	sources := make([]entity.Source, 0)
+	for rows.Next() {
+		var src entity.Source
+		if err := rows.Scan(sourceScanTargets(&src)...); err != nil {
+			return nil, fmt.Errorf("не удалось обработать строку источника: %w", err)
+		}
+		sources = append(sources, src)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("ошибка при обработке результатов запроса: %w", err)
+	}
+
+	return sources, nil
+}
+
+// GetByName возвращает источник по его имени, в том числе выключенный
+func (r *SourceRepository) GetByName(ctx context.Context, name string) (entity.Source, error) {
+	query := `
+		SELECT ` + sourceColumns + `
+		FROM sources
+		WHERE name = $1
+	`
+
+	var src entity.Source
+	err := r.db.QueryRow(ctx, query, name).Scan(sourceScanTargets(&src)...)
+	if err != nil {
+		return entity.Source{}, fmt.Errorf("не удалось получить источник %s: %w", name, err)
+	}
+
+	return src, nil
+}
+
+// UpdateProgress сохраняет состояние адаптера, увеличивает счетчик обработанных публикаций и сбрасывает ошибку
+func (r *SourceRepository) UpdateProgress(ctx context.Context, id int64, state string, itemsParsed int) error {
+	query := `
+		UPDATE sources
+		SET state = $2,
+			items_parsed = items_parsed + $3,
+			date_last_parsed = NOW(),
+			last_error = NULL
+		WHERE id = $1
+	`
+
+	if _, err := r.db.Exec(ctx, query, id, state, itemsParsed); err != nil {
+		return fmt.Errorf("не удалось обновить состояние источника с ID=%d: %w", id, err)
+	}
+
+	return nil
+}
+
+// UpdateError сохраняет ошибку последней загрузки источника
+func (r *SourceRepository) UpdateError(ctx context.Context, id int64, message string) error {
+	query := `
+		UPDATE sources
+		SET last_error = $2
+		WHERE id = $1
+	`
+
+	if _, err := r.db.Exec(ctx, query, id, message); err != nil {
+		return fmt.Errorf("не удалось сохранить ошибку источника с ID=%d: %w", id, err)
+	}
+
+	return nil
+}
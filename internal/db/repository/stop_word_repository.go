@@ -0,0 +1,118 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/jackc/pgx/v4"
+	"github.com/jackc/pgx/v4/pgxpool"
+	"github.com/zalhonan/remotejobs-site/internal/domain/entity"
+	"go.uber.org/zap"
+)
+
+// ErrStopWordNotFound стоп-слово не найдено
+var ErrStopWordNotFound = errors.New("стоп-слово не найдено")
+
+type StopWordRepository struct {
+	db     *pgxpool.Pool
+	logger *zap.Logger
+}
+
+// NewStopWordRepository создает новый репозиторий для работы со стоп-словами
+func NewStopWordRepository(db *pgxpool.Pool, logger *zap.Logger) *StopWordRepository {
+	return &StopWordRepository{
+		db:     db,
+		logger: logger,
+	}
+}
+
+// GetAll возвращает все стоп-слова, отсортированные по алфавиту
+func (r *StopWordRepository) GetAll(ctx context.Context) ([]entity.StopWord, error) {
+	query := `
+		SELECT id, word
+		FROM stop_words
+		ORDER BY word ASC
+	`
+
+	rows, err := r.db.Query(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("не удалось получить список стоп-слов: %w", err)
+	}
+	defer rows.Close()
+
+	stopWords := make([]entity.StopWord, 0)
+	for rows.Next() {
+		var stopWord entity.StopWord
+		if err := rows.Scan(&stopWord.ID, &stopWord.Word); err != nil {
+			return nil, fmt.Errorf("не удалось обработать строку стоп-слова: %w", err)
+		}
+		stopWords = append(stopWords, stopWord)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("ошибка при обработке результатов запроса: %w", err)
+	}
+
+	return stopWords, nil
+}
+
+// Add добавляет стоп-слово в справочник. Возвращает false, если такое слово уже есть
+func (r *StopWordRepository) Add(ctx context.Context, word string) (bool, error) {
+	query := `
+		INSERT INTO stop_words (word)
+		VALUES ($1)
+		ON CONFLICT (word) DO NOTHING
+	`
+
+	tag, err := r.db.Exec(ctx, query, word)
+	if err != nil {
+		return false, fmt.Errorf("не удалось добавить стоп-слово %s: %w", word, err)
+	}
+
+	return tag.RowsAffected() > 0, nil
+}
+
+// AddMany добавляет стоп-слова в справочник, пропуская уже существующие. Возвращает количество добавленных слов
+func (r *StopWordRepository) AddMany(ctx context.Context, words []string) (int64, error) {
+	query := `
+		INSERT INTO stop_words (word)
+		SELECT DISTINCT unnest($1::TEXT[])
+		ON CONFLICT (word) DO NOTHING
+	`
+
+	tag, err := r.db.Exec(ctx, query, words)
+	if err != nil {
+		return 0, fmt.Errorf("не удалось добавить стоп-слова: %w", err)
+	}
+
+	return tag.RowsAffected(), nil
+}
+
+// GetByID возвращает стоп-слово по его ID. Возвращает ErrStopWordNotFound, если слова нет
+func (r *StopWordRepository) GetByID(ctx context.Context, id int64) (entity.StopWord, error) {
+	var stopWord entity.StopWord
+	err := r.db.QueryRow(ctx, `SELECT id, word FROM stop_words WHERE id = $1`, id).Scan(&stopWord.ID, &stopWord.Word)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return entity.StopWord{}, ErrStopWordNotFound
+	}
+	if err != nil {
+		return entity.StopWord{}, fmt.Errorf("не удалось получить стоп-слово с ID=%d: %w", id, err)
+	}
+
+	return stopWord, nil
+}
+
+// Delete удаляет стоп-слово из справочника и возвращает его. Возвращает ErrStopWordNotFound, если слова нет
+func (r *StopWordRepository) Delete(ctx context.Context, id int64) (string, error) {
+	var word string
+	err := r.db.QueryRow(ctx, `DELETE FROM stop_words WHERE id = $1 RETURNING word`, id).Scan(&word)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return "", ErrStopWordNotFound
+	}
+	if err != nil {
+		return "", fmt.Errorf("не удалось удалить стоп-слово с ID=%d: %w", id, err)
+	}
+
+	return word, nil
+}
@@ -2,13 +2,26 @@ package repository
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"time"
 
+	"github.com/jackc/pgx/v4"
 	"github.com/jackc/pgx/v4/pgxpool"
 	"github.com/zalhonan/remotejobs-site/internal/domain/entity"
 	"go.uber.org/zap"
 )
 
+// technologyColumns список колонок, читаемых в порядке technologyScanTargets
+const technologyColumns = `id, technology, keywords, negative_keywords, sort_order, count, count_recent,
+	coalesce(expire_after_days, 0), coalesce(archive_after_days, 0), coalesce(remove_after_days, 0)`
+
+// ErrTechnologyExists технология с таким именем уже есть
+var ErrTechnologyExists = errors.New("технология с таким именем уже есть")
+
+// technologyNameConstraint ограничение уникальности имени технологии
+const technologyNameConstraint = "technologies_technology_key"
+
 type TechnologyRepository struct {
 	db     *pgxpool.Pool
 	logger *zap.Logger
@@ -22,31 +35,34 @@ func NewTechnologyRepository(db *pgxpool.Pool, logger *zap.Logger) *TechnologyRe
 	}
 }
 
-// GetAll возвращает все технологии, отсортированные по порядку сортировки
-func (r *TechnologyRepository) GetAll(ctx context.Context) ([]entity.Technology, error) {
-	query := `
-		SELECT id, technology, keywords, sort_order, count
-		FROM technologies
-		WHERE count > 0
-		ORDER BY sort_order DESC, technology ASC
-	`
+// technologyScanTargets возвращает указатели на поля технологии в порядке колонок technologyColumns
+func technologyScanTargets(tech *entity.Technology) []interface{} {
+	return []interface{}{
+		&tech.ID,
+		&tech.Technology,
+		&tech.Keywords,
+		&tech.NegativeKeywords,
+		&tech.SortOrder,
+		&tech.Count,
+		&tech.CountRecent,
+		&tech.Lifecycle.ExpireAfterDays,
+		&tech.Lifecycle.ArchiveAfterDays,
+		&tech.Lifecycle.RemoveAfterDays,
+	}
+}
 
-	rows, err := r.db.Query(ctx, query)
+// queryTechnologies выполняет выборку технологий
+func (r *TechnologyRepository) queryTechnologies(ctx context.Context, query string, args ...interface{}) ([]entity.Technology, error) {
+	rows, err := r.db.Query(ctx, query, args...)
 	if err != nil {
-		return nil, fmt.Errorf("не удалось получить список технологий: %w", err)
+		return nil, err
 	}
 	defer rows.Close()
 
 	technologies := make([]entity.Technology, 0)
 	for rows.Next() {
 		var tech entity.Technology
-		if err := rows.Scan(
-			&tech.ID,
-			&tech.Technology,
-			&tech.Keywords,
-			&tech.SortOrder,
-			&tech.Count,
-		); err != nil {
+		if err := rows.Scan(technologyScanTargets(&tech)...); err != nil {
 			return nil, fmt.Errorf("не удалось обработать строку технологии: %w", err)
 		}
 		technologies = append(technologies, tech)
@@ -59,22 +75,190 @@ func (r *TechnologyRepository) GetAll(ctx context.Context) ([]entity.Technology,
 	return technologies, nil
 }
 
+// GetAll возвращает все технологии, отсортированные по порядку сортировки
+func (r *TechnologyRepository) GetAll(ctx context.Context) ([]entity.Technology, error) {
+	query := `
+		SELECT ` + technologyColumns + `
+		FROM technologies
+		WHERE count > 0
+		ORDER BY sort_order DESC, technology ASC
+	`
+
+	technologies, err := r.queryTechnologies(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("не удалось получить список технологий: %w", err)
+	}
+
+	return technologies, nil
+}
+
+// GetForClassification возвращает все технологии с ключевыми словами, включая технологии без вакансий
+func (r *TechnologyRepository) GetForClassification(ctx context.Context) ([]entity.Technology, error) {
+	query := `
+		SELECT ` + technologyColumns + `
+		FROM technologies
+		WHERE cardinality(keywords) > 0
+		ORDER BY sort_order DESC, technology ASC
+	`
+
+	technologies, err := r.queryTechnologies(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("не удалось получить технологии для классификации: %w", err)
+	}
+
+	return technologies, nil
+}
+
+// GetAllForAdmin возвращает все технологии, включая технологии без вакансий и ключевых слов
+func (r *TechnologyRepository) GetAllForAdmin(ctx context.Context) ([]entity.Technology, error) {
+	query := `
+		SELECT ` + technologyColumns + `
+		FROM technologies
+		ORDER BY sort_order DESC, technology ASC
+	`
+
+	technologies, err := r.queryTechnologies(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("не удалось получить список технологий: %w", err)
+	}
+
+	return technologies, nil
+}
+
+// GetByID возвращает технологию по её ID
+func (r *TechnologyRepository) GetByID(ctx context.Context, id int64) (entity.Technology, error) {
+	query := `
+		SELECT ` + technologyColumns + `
+		FROM technologies
+		WHERE id = $1
+	`
+
+	var tech entity.Technology
+	err := r.db.QueryRow(ctx, query, id).Scan(technologyScanTargets(&tech)...)
+	if err != nil {
+		return entity.Technology{}, fmt.Errorf("не удалось получить технологию с ID=%d: %w", id, err)
+	}
+
+	return tech, nil
+}
+
+// Create сохраняет новую технологию и возвращает её ID.
+// Возвращает ErrTechnologyExists, если технология с таким именем уже есть
+func (r *TechnologyRepository) Create(ctx context.Context, tech entity.Technology) (int64, error) {
+	query := `
+		INSERT INTO technologies (
+			technology, keywords, negative_keywords, sort_order,
+			expire_after_days, archive_after_days, remove_after_days
+		)
+		VALUES ($1, $2, $3, $4, NULLIF($5::INT, 0), NULLIF($6::INT, 0), NULLIF($7::INT, 0))
+		RETURNING id
+	`
+
+	var id int64
+	err := r.db.QueryRow(ctx, query,
+		tech.Technology,
+		tech.Keywords,
+		tech.NegativeKeywords,
+		tech.SortOrder,
+		tech.Lifecycle.ExpireAfterDays,
+		tech.Lifecycle.ArchiveAfterDays,
+		tech.Lifecycle.RemoveAfterDays,
+	).Scan(&id)
+	if isUniqueViolation(err, technologyNameConstraint) {
+		return 0, ErrTechnologyExists
+	}
+	if err != nil {
+		return 0, fmt.Errorf("не удалось сохранить технологию %s: %w", tech.Technology, err)
+	}
+
+	return id, nil
+}
+
+// Update сохраняет технологию. При переименовании вакансии технологии переводятся на новое имя
+// в той же транзакции. Возвращает ErrTechnologyExists, если новое имя занято другой технологией
+func (r *TechnologyRepository) Update(ctx context.Context, tech entity.Technology) error {
+	return inTx(ctx, r.db, func(tx pgx.Tx) error {
+		var oldName string
+		err := tx.QueryRow(ctx, "SELECT technology FROM technologies WHERE id = $1 FOR UPDATE", tech.ID).Scan(&oldName)
+		if err != nil {
+			return fmt.Errorf("не удалось получить технологию с ID=%d: %w", tech.ID, err)
+		}
+
+		query := `
+			UPDATE technologies
+			SET technology = $2,
+				keywords = $3,
+				negative_keywords = $4,
+				sort_order = $5,
+				expire_after_days = NULLIF($6::INT, 0),
+				archive_after_days = NULLIF($7::INT, 0),
+				remove_after_days = NULLIF($8::INT, 0)
+			WHERE id = $1
+		`
+
+		_, err = tx.Exec(ctx, query,
+			tech.ID,
+			tech.Technology,
+			tech.Keywords,
+			tech.NegativeKeywords,
+			tech.SortOrder,
+			tech.Lifecycle.ExpireAfterDays,
+			tech.Lifecycle.ArchiveAfterDays,
+			tech.Lifecycle.RemoveAfterDays,
+		)
+		if isUniqueViolation(err, technologyNameConstraint) {
+			return ErrTechnologyExists
+		}
+		if err != nil {
+			return fmt.Errorf("не удалось обновить технологию с ID=%d: %w", tech.ID, err)
+		}
+
+		if oldName == tech.Technology {
+			return nil
+		}
+
+		_, err = tx.Exec(ctx, "UPDATE jobs_raw SET main_technology = $2 WHERE main_technology = $1", oldName, tech.Technology)
+		if err != nil {
+			return fmt.Errorf("не удалось перевести вакансии технологии %s на имя %s: %w", oldName, tech.Technology, err)
+		}
+
+		return nil
+	})
+}
+
+// Delete удаляет технологию. У её вакансий основная технология сбрасывается в той же транзакции,
+// и они ждут повторной классификации. Возвращает количество таких вакансий
+func (r *TechnologyRepository) Delete(ctx context.Context, id int64) (int64, error) {
+	var unassigned int64
+	err := inTx(ctx, r.db, func(tx pgx.Tx) error {
+		var name string
+		err := tx.QueryRow(ctx, "DELETE FROM technologies WHERE id = $1 RETURNING technology", id).Scan(&name)
+		if err != nil {
+			return fmt.Errorf("не удалось удалить технологию с ID=%d: %w", id, err)
+		}
+
+		tag, err := tx.Exec(ctx, "UPDATE jobs_raw SET main_technology = NULL WHERE main_technology = $1", name)
+		if err != nil {
+			return fmt.Errorf("не удалось сбросить технологию %s у вакансий: %w", name, err)
+		}
+		unassigned = tag.RowsAffected()
+
+		return nil
+	})
+
+	return unassigned, err
+}
+
 // GetByName возвращает технологию по её имени
 func (r *TechnologyRepository) GetByName(ctx context.Context, name string) (entity.Technology, error) {
 	query := `
-		SELECT id, technology, keywords, sort_order, count
+		SELECT ` + technologyColumns + `
 		FROM technologies
 		WHERE technology = $1
 	`
 
 	var tech entity.Technology
-	err := r.db.QueryRow(ctx, query, name).Scan(
-		&tech.ID,
-		&tech.Technology,
-		&tech.Keywords,
-		&tech.SortOrder,
-		&tech.Count,
-	)
+	err := r.db.QueryRow(ctx, query, name).Scan(technologyScanTargets(&tech)...)
 	if err != nil {
 		return entity.Technology{}, fmt.Errorf("не удалось получить технологию с именем=%s: %w", name, err)
 	}
@@ -94,3 +278,67 @@ func (r *TechnologyRepository) Exists(ctx context.Context, name string) (bool, e
 
 	return exists, nil
 }
+
+// FilterExisting возвращает те имена из списка, для которых существует технология
+func (r *TechnologyRepository) FilterExisting(ctx context.Context, names []string) ([]string, error) {
+	query := `
+		SELECT technology
+		FROM technologies
+		WHERE technology = ANY($1)
+		ORDER BY sort_order DESC, technology ASC
+	`
+
+	rows, err := r.db.Query(ctx, query, names)
+	if err != nil {
+		return nil, fmt.Errorf("не удалось проверить существование технологий %v: %w", names, err)
+	}
+	defer rows.Close()
+
+	existing := make([]string, 0, len(names))
+	for rows.Next() {
+		var name string
+		if err := rows.Scan(&name); err != nil {
+			return nil, fmt.Errorf("не удалось обработать строку технологии: %w", err)
+		}
+		existing = append(existing, name)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("ошибка при обработке результатов запроса: %w", err)
+	}
+
+	return existing, nil
+}
+
+// RecountJobs пересчитывает количество показываемых вакансий каждой технологии: всего и опубликованных
+// не раньше recentFrom. Вакансии, содержащие стоп-слова, не учитываются, как и в списках вакансий.
+// Возвращает количество обновленных технологий
+func (r *TechnologyRepository) RecountJobs(ctx context.Context, stopWords []string, recentFrom time.Time) (int64, error) {
+	where := newWhereClause(visibleJobCondition)
+	where.applyFilter(entity.JobFilter{StopWords: stopWords})
+
+	query := fmt.Sprintf(`
+		WITH counts AS (
+			SELECT main_technology,
+				COUNT(*) AS total,
+				COUNT(*) FILTER (WHERE date_posted >= %s) AS recent
+			FROM jobs_raw
+			WHERE %s
+			GROUP BY main_technology
+		)
+		UPDATE technologies AS t
+		SET count = coalesce(counts.total, 0),
+			count_recent = coalesce(counts.recent, 0),
+			date_counted = NOW()
+		FROM technologies AS tech
+		LEFT JOIN counts ON counts.main_technology = tech.technology
+		WHERE t.id = tech.id
+	`, where.arg(recentFrom), where)
+
+	tag, err := r.db.Exec(ctx, query, where.args...)
+	if err != nil {
+		return 0, fmt.Errorf("не удалось пересчитать количество вакансий по технологиям: %w", err)
+	}
+
+	return tag.RowsAffected(), nil
+}
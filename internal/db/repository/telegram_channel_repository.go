@@ -0,0 +1,271 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgx/v4"
+	"github.com/jackc/pgx/v4/pgxpool"
+	"github.com/zalhonan/remotejobs-site/internal/domain/entity"
+	"go.uber.org/zap"
+)
+
+// telegramChannelColumns список колонок, читаемых в порядке telegramChannelScanTargets
+const telegramChannelColumns = "id, tag, coalesce(last_post_id, 0), date_channel_added, posts_parsed, date_last_parsed, paused"
+
+// telegramChannelTagConstraint ограничение уникальности имени канала
+const telegramChannelTagConstraint = "telegram_channels_tag_key"
+
+var (
+	// ErrTelegramChannelExists канал с таким именем уже есть
+	ErrTelegramChannelExists = errors.New("канал с таким именем уже есть")
+
+	// ErrTelegramChannelNotFound канал не найден
+	ErrTelegramChannelNotFound = errors.New("канал не найден")
+)
+
+type TelegramChannelRepository struct {
+	db     *pgxpool.Pool
+	logger *zap.Logger
+}
+
+// NewTelegramChannelRepository создает новый репозиторий для работы с каналами Telegram
+func NewTelegramChannelRepository(db *pgxpool.Pool, logger *zap.Logger) *TelegramChannelRepository {
+	return &TelegramChannelRepository{
+		db:     db,
+		logger: logger,
+	}
+}
+
+// telegramChannelScanTargets возвращает указатели на поля канала в порядке колонок telegramChannelColumns
+func telegramChannelScanTargets(channel *entity.TelegramChannel) []interface{} {
+	return []interface{}{
+		&channel.ID,
+		&channel.Tag,
+		&channel.LastPostID,
+		&channel.DateChannelAdded,
+		&channel.PostsParsed,
+		&channel.DateLastParsed,
+		&channel.Paused,
+	}
+}
+
+// GetAll возвращает все каналы Telegram в порядке добавления
+func (r *TelegramChannelRepository) GetAll(ctx context.Context) ([]entity.TelegramChannel, error) {
+	query := `
+		SELECT ` + telegramChannelColumns + `
+		FROM telegram_channels
+		ORDER BY id ASC
+	`
+
+	rows, err := r.db.Query(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("не удалось получить список каналов Telegram: %w", err)
+	}
+	defer rows.Close()
+
+	channels := make([]entity.TelegramChannel, 0)
+	for rows.Next() {
+		var channel entity.TelegramChannel
+		if err := rows.Scan(telegramChannelScanTargets(&channel)...); err != nil {
+			return nil, fmt.Errorf("не удалось обработать строку канала Telegram: %w", err)
+		}
+		channels = append(channels, channel)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("ошибка при обработке результатов запроса: %w", err)
+	}
+
+	return channels, nil
+}
+
+// GetActive возвращает каналы Telegram, кроме приостановленных, в порядке добавления
+func (r *TelegramChannelRepository) GetActive(ctx context.Context) ([]entity.TelegramChannel, error) {
+	query := `
+		SELECT ` + telegramChannelColumns + `
+		FROM telegram_channels
+		WHERE NOT paused
+		ORDER BY id ASC
+	`
+
+	rows, err := r.db.Query(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("не удалось получить список активных каналов Telegram: %w", err)
+	}
+	defer rows.Close()
+
+	channels := make([]entity.TelegramChannel, 0)
+	for rows.Next() {
+		var channel entity.TelegramChannel
+		if err := rows.Scan(telegramChannelScanTargets(&channel)...); err != nil {
+			return nil, fmt.Errorf("не удалось обработать строку канала Telegram: %w", err)
+		}
+		channels = append(channels, channel)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("ошибка при обработке результатов запроса: %w", err)
+	}
+
+	return channels, nil
+}
+
+// GetAllWithStats возвращает все каналы Telegram в порядке добавления вместе с показателями их вакансий,
+// опубликованных не раньше since. Вакансия относится к каналу по ссылке на пост вида {baseURL}/<tag>/<id поста>,
+// ссылки других источников с тем же видом не учитываются. Вакансия считается скрытой стоп-словами,
+// если они записаны в колонку stop_words или слово из stopWords встречается в её тексте
+func (r *TelegramChannelRepository) GetAllWithStats(
+	ctx context.Context,
+	baseURL string,
+	since time.Time,
+	stopWords []string,
+) ([]entity.TelegramChannelStats, error) {
+	query := fmt.Sprintf(`
+		WITH channel_jobs AS (
+			SELECT lower(substring(source_link FROM '/([^/]+)/[0-9]+$')) AS channel_tag,
+				count(*) AS jobs,
+				count(*) FILTER (WHERE main_technology IS NOT NULL AND main_technology != '') AS classified,
+				count(*) FILTER (WHERE canonical_id IS NOT NULL) AS duplicates,
+				count(*) FILTER (
+					WHERE coalesce(cardinality(stop_words), 0) > 0 OR %s ~* ANY($1)
+				) AS stop_word_hits
+			FROM jobs_raw
+			WHERE source_link ILIKE $2 AND date_posted >= $3
+			GROUP BY 1
+		)
+		SELECT %s,
+			coalesce(cj.jobs, 0), coalesce(cj.classified, 0), coalesce(cj.duplicates, 0), coalesce(cj.stop_word_hits, 0)
+		FROM telegram_channels c
+		LEFT JOIN channel_jobs cj ON cj.channel_tag = lower(c.tag)
+		ORDER BY c.id ASC
+	`, jobTextExpression, telegramChannelColumns)
+
+	linkPattern := prefixPattern(strings.TrimRight(baseURL, "/") + "/")
+	rows, err := r.db.Query(ctx, query, wordPatterns(stopWords), linkPattern, since)
+	if err != nil {
+		return nil, fmt.Errorf("не удалось получить показатели каналов Telegram: %w", err)
+	}
+	defer rows.Close()
+
+	channels := make([]entity.TelegramChannelStats, 0)
+	for rows.Next() {
+		var channel entity.TelegramChannelStats
+		targets := append(telegramChannelScanTargets(&channel.TelegramChannel),
+			&channel.Jobs, &channel.Classified, &channel.Duplicates, &channel.StopWordHits)
+		if err := rows.Scan(targets...); err != nil {
+			return nil, fmt.Errorf("не удалось обработать строку канала Telegram: %w", err)
+		}
+		channels = append(channels, channel)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("ошибка при обработке результатов запроса: %w", err)
+	}
+
+	return channels, nil
+}
+
+// GetByTag возвращает канал Telegram по его имени
+func (r *TelegramChannelRepository) GetByTag(ctx context.Context, tag string) (entity.TelegramChannel, error) {
+	query := `
+		SELECT ` + telegramChannelColumns + `
+		FROM telegram_channels
+		WHERE tag = $1
+	`
+
+	var channel entity.TelegramChannel
+	err := r.db.QueryRow(ctx, query, tag).Scan(telegramChannelScanTargets(&channel)...)
+	if err != nil {
+		return entity.TelegramChannel{}, fmt.Errorf("не удалось получить канал Telegram %s: %w", tag, err)
+	}
+
+	return channel, nil
+}
+
+// UpdateProgress сохраняет ID последнего обработанного поста и увеличивает счетчик обработанных постов
+func (r *TelegramChannelRepository) UpdateProgress(ctx context.Context, id, lastPostID int64, postsParsed int) error {
+	query := `
+		UPDATE telegram_channels
+		SET last_post_id = $2,
+			posts_parsed = posts_parsed + $3,
+			date_last_parsed = NOW()
+		WHERE id = $1
+	`
+
+	if _, err := r.db.Exec(ctx, query, id, lastPostID, postsParsed); err != nil {
+		return fmt.Errorf("не удалось обновить состояние канала Telegram с ID=%d: %w", id, err)
+	}
+
+	return nil
+}
+
+// Create добавляет канал Telegram и возвращает его ID. Имена каналов в Telegram не различают регистр,
+// поэтому возвращает ErrTelegramChannelExists, если канал с таким именем в любом регистре уже есть
+func (r *TelegramChannelRepository) Create(ctx context.Context, tag string) (int64, error) {
+	query := `
+		INSERT INTO telegram_channels (tag)
+		SELECT $1::VARCHAR
+		WHERE NOT EXISTS (SELECT 1 FROM telegram_channels WHERE lower(tag) = lower($1))
+		RETURNING id
+	`
+
+	var id int64
+	err := r.db.QueryRow(ctx, query, tag).Scan(&id)
+	if errors.Is(err, pgx.ErrNoRows) || isUniqueViolation(err, telegramChannelTagConstraint) {
+		return 0, ErrTelegramChannelExists
+	}
+	if err != nil {
+		return 0, fmt.Errorf("не удалось добавить канал Telegram %s: %w", tag, err)
+	}
+
+	return id, nil
+}
+
+// Delete удаляет канал Telegram и возвращает его имя. Сохраненные вакансии канала остаются.
+// Возвращает ErrTelegramChannelNotFound, если канала нет
+func (r *TelegramChannelRepository) Delete(ctx context.Context, id int64) (string, error) {
+	var tag string
+	err := r.db.QueryRow(ctx, `DELETE FROM telegram_channels WHERE id = $1 RETURNING tag`, id).Scan(&tag)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return "", ErrTelegramChannelNotFound
+	}
+	if err != nil {
+		return "", fmt.Errorf("не удалось удалить канал Telegram с ID=%d: %w", id, err)
+	}
+
+	return tag, nil
+}
+
+// SetPaused приостанавливает или возобновляет загрузку канала и возвращает его имя.
+// Возвращает ErrTelegramChannelNotFound, если канала нет
+func (r *TelegramChannelRepository) SetPaused(ctx context.Context, id int64, paused bool) (string, error) {
+	var tag string
+	err := r.db.QueryRow(ctx, `UPDATE telegram_channels SET paused = $2 WHERE id = $1 RETURNING tag`, id, paused).Scan(&tag)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return "", ErrTelegramChannelNotFound
+	}
+	if err != nil {
+		return "", fmt.Errorf("не удалось изменить паузу канала Telegram с ID=%d: %w", id, err)
+	}
+
+	return tag, nil
+}
+
+// ResetLastPostID задает ID последнего обработанного поста, чтобы парсер заново загрузил посты после него,
+// и возвращает имя канала. Возвращает ErrTelegramChannelNotFound, если канала нет
+func (r *TelegramChannelRepository) ResetLastPostID(ctx context.Context, id, lastPostID int64) (string, error) {
+	var tag string
+	err := r.db.QueryRow(ctx, `UPDATE telegram_channels SET last_post_id = $2 WHERE id = $1 RETURNING tag`, id, lastPostID).Scan(&tag)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return "", ErrTelegramChannelNotFound
+	}
+	if err != nil {
+		return "", fmt.Errorf("не удалось изменить последний пост канала Telegram с ID=%d: %w", id, err)
+	}
+
+	return tag, nil
+}
@@ -0,0 +1,130 @@
+package repository
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/zalhonan/remotejobs-site/internal/domain/entity"
+)
+
+// visibleJobCondition условие, при котором вакансия показывается на сайте: технология определена,
+// модератор не отметил пост как не вакансию, а администратор не скрыл вакансию
+const visibleJobCondition = "main_technology IS NOT NULL AND main_technology != '' AND moderation IS DISTINCT FROM '" +
+	entity.ModerationNotVacancy + "' AND NOT hidden"
+
+// jobTextExpression текст вакансии, в котором ищутся стоп-слова
+const jobTextExpression = "coalesce(title, '') || ' ' || coalesce(content_pure, '')"
+
+// whereClause собирает условия WHERE и аргументы для параметризованного запроса
+type whereClause struct {
+	conditions []string
+	args       []interface{}
+}
+
+// newWhereClause создает набор условий с начальными условиями без аргументов
+func newWhereClause(conditions ...string) *whereClause {
+	return &whereClause{
+		conditions: conditions,
+	}
+}
+
+// arg добавляет аргумент запроса и возвращает его плейсхолдер вида $N
+func (w *whereClause) arg(value interface{}) string {
+	w.args = append(w.args, value)
+	return fmt.Sprintf("$%d", len(w.args))
+}
+
+// add добавляет условие в выборку
+func (w *whereClause) add(condition string) {
+	w.conditions = append(w.conditions, condition)
+}
+
+// applyFilter добавляет условия из фильтра вакансий
+func (w *whereClause) applyFilter(filter entity.JobFilter) {
+	// Повторы вакансии из других источников показываются только на странице канонической вакансии
+	w.add("canonical_id IS NULL")
+	// Устаревшие вакансии показываются только в поиске и за произвольный период, архивные - только по прямой ссылке
+	if filter.IncludeExpired {
+		w.add("status IN ('" + entity.JobStatusActive + "', '" + entity.JobStatusExpired + "')")
+	} else {
+		w.add("status = '" + entity.JobStatusActive + "'")
+	}
+	if !filter.PostedFrom.IsZero() {
+		w.add("date_posted >= " + w.arg(filter.PostedFrom))
+	}
+	if !filter.PostedTo.IsZero() {
+		w.add("date_posted < " + w.arg(filter.PostedTo))
+	}
+	if !filter.ShowHidden {
+		// Скрываем вакансии, помеченные стоп-словами при парсинге,
+		// и вакансии, текст которых содержит стоп-слова из справочника
+		w.add("coalesce(cardinality(stop_words), 0) = 0")
+		if len(filter.StopWords) > 0 {
+			w.add("NOT (" + jobTextExpression + " ~* ANY(" + w.arg(wordPatterns(filter.StopWords)) + "))")
+		}
+	}
+	if filter.SalaryCurrency != "" {
+		// Вакансия подходит, если её вилка пересекается с запрошенной
+		w.add("salary_currency = " + w.arg(filter.SalaryCurrency))
+		if filter.SalaryFrom > 0 {
+			w.add("coalesce(salary_month_max, salary_month_min) >= " + w.arg(filter.SalaryFrom))
+		}
+		if filter.SalaryTo > 0 {
+			w.add("coalesce(salary_month_min, salary_month_max) <= " + w.arg(filter.SalaryTo))
+		}
+	}
+	if len(filter.Seniority) > 0 {
+		w.add("seniority && " + w.arg(filter.Seniority))
+	}
+	if len(filter.Location) > 0 {
+		w.add("location_constraints && " + w.arg(filter.Location))
+	}
+	if filter.Country != "" {
+		// Подходят вакансии без ограничения по стране и вакансии, где страна указана среди допустимых
+		w.add(fmt.Sprintf("(NOT coalesce(location_constraints, '{}') @> ARRAY['%s'] OR %s = ANY(location_countries))",
+			entity.LocationCountry, w.arg(filter.Country)))
+	}
+}
+
+// wordPatterns преобразует слова в регулярные выражения PostgreSQL (оператор ~*) для поиска отдельного
+// слова так же, как extract.ContainsWord: граница слова (\m, \M) проверяется с той стороны, где слово
+// начинается или заканчивается буквой или цифрой. Поэтому "java" не находится в "javascript", а "c++" - находится в "c++20"
+func wordPatterns(words []string) []string {
+	patterns := make([]string, 0, len(words))
+	for _, word := range words {
+		first, _ := utf8.DecodeRuneInString(word)
+		last, _ := utf8.DecodeLastRuneInString(word)
+
+		pattern := regexp.QuoteMeta(word)
+		if isWordRune(first) {
+			pattern = `\m` + pattern
+		}
+		if isWordRune(last) {
+			pattern += `\M`
+		}
+		patterns = append(patterns, pattern)
+	}
+	return patterns
+}
+
+// prefixPattern преобразует строку в шаблон LIKE для поиска строк, начинающихся с неё
+func prefixPattern(prefix string) string {
+	escaper := strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`)
+	return escaper.Replace(prefix) + "%"
+}
+
+// isWordRune сообщает, является ли символ частью слова
+func isWordRune(r rune) bool {
+	return unicode.IsLetter(r) || unicode.IsDigit(r)
+}
+
+// String возвращает условия, объединенные через AND
+func (w *whereClause) String() string {
+	if len(w.conditions) == 0 {
+		return "TRUE"
+	}
+	return strings.Join(w.conditions, " AND ")
+}
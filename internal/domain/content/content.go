@@ -0,0 +1,142 @@
+// Package content готовит текст вакансии к показу: очищает HTML, формирует превью и мета-описание.
+// Результат сохраняется в базе при загрузке вакансии, поэтому страницы не обрабатывают текст при каждом показе
+package content
+
+import (
+	"html/template"
+	"regexp"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/microcosm-cc/bluemonday"
+)
+
+const (
+	// PreviewLines количество непустых строк текста в превью вакансии
+	PreviewLines = 5
+
+	// PreviewMaxRunes максимальная длина превью в символах
+	PreviewMaxRunes = 700
+
+	// DescriptionMaxRunes максимальная длина мета-описания в символах без названия технологии
+	DescriptionMaxRunes = 160
+)
+
+var (
+	// policy политика очистки HTML. Политика bluemonday безопасна для одновременного использования
+	policy = newPolicy()
+
+	// leadingSpace пробелы в начале строк
+	leadingSpace = regexp.MustCompile(`(?m)^[\t ]+`)
+
+	// extraNewlines последовательности из трех и более переносов строк
+	extraNewlines = regexp.MustCompile(`\n{3,}`)
+)
+
+// newPolicy создает политику очистки HTML вакансий от опасных элементов и атрибутов (JavaScript)
+func newPolicy() *bluemonday.Policy {
+	// Используем UGCPolicy из bluemonday - политику для пользовательского контента
+	p := bluemonday.UGCPolicy()
+
+	// Разрешаем базовые элементы форматирования текста и таблицы
+	p.AllowElements("p", "br", "strong", "em", "u", "s", "ul", "ol", "li",
+		"blockquote", "code", "pre", "h1", "h2", "h3", "h4", "h5", "h6",
+		"table", "thead", "tbody", "tr", "td", "th")
+
+	// Разрешаем ссылки, но только для безопасных протоколов
+	p.AllowAttrs("href").OnElements("a")
+	p.AllowURLSchemes("http", "https", "mailto")
+
+	return p
+}
+
+// Sanitize очищает HTML вакансии от опасных элементов и лишних пробелов
+func Sanitize(html string) string {
+	sanitized := policy.Sanitize(html)
+
+	// Удаляем только лишние пробелы в начале каждой строки, но сохраняем пустые строки
+	sanitized = leadingSpace.ReplaceAllString(sanitized, "")
+
+	// Удаляем пробелы в начале и конце всего текста
+	sanitized = strings.TrimSpace(sanitized)
+
+	// Заменяем последовательности из более чем 3-х переносов строк на 2 переноса
+	return extraNewlines.ReplaceAllString(sanitized, "\n\n")
+}
+
+// Preview формирует HTML превью из текста вакансии: первые lines непустых строк, соединенные <br>,
+// но не длиннее maxRunes символов. Текст обрезается до экранирования, поэтому в превью
+// не бывает разрезанных символов и HTML-сущностей
+func Preview(text string, lines, maxRunes int) string {
+	var nonEmpty []string
+	for _, line := range strings.Split(text, "\n") {
+		if line = strings.TrimSpace(line); line != "" {
+			nonEmpty = append(nonEmpty, line)
+		}
+	}
+
+	if len(nonEmpty) == 0 {
+		return ""
+	}
+
+	more := len(nonEmpty) > lines
+	if more {
+		nonEmpty = nonEmpty[:lines]
+	}
+
+	var b strings.Builder
+	runes := 0
+	for i, line := range nonEmpty {
+		// Перенос строки в превью считается одним символом
+		if i > 0 {
+			b.WriteString("<br>")
+			runes++
+		}
+
+		lineRunes := utf8.RuneCountInString(line)
+		if runes+lineRunes > maxRunes {
+			b.WriteString(template.HTMLEscapeString(truncateRunes(line, maxRunes-runes)))
+			b.WriteString("...")
+			return b.String()
+		}
+
+		runes += lineRunes
+		b.WriteString(template.HTMLEscapeString(line))
+	}
+
+	if more {
+		b.WriteString("...")
+	}
+
+	return b.String()
+}
+
+// Description формирует мета-описание из текста вакансии: текст в одну строку,
+// обрезанный по границе слова до maxRunes символов
+func Description(text string, maxRunes int) string {
+	description := strings.Join(strings.Fields(text), " ")
+	if utf8.RuneCountInString(description) <= maxRunes {
+		return description
+	}
+
+	return truncateRunes(description, maxRunes) + "..."
+}
+
+// truncateRunes обрезает строку до maxRunes символов по последнему пробелу, если он есть
+func truncateRunes(s string, maxRunes int) string {
+	if maxRunes <= 0 {
+		return ""
+	}
+
+	runes := []rune(s)
+	if len(runes) <= maxRunes {
+		return s
+	}
+
+	truncated := string(runes[:maxRunes])
+	if lastSpace := strings.LastIndex(truncated, " "); lastSpace > 0 {
+		truncated = truncated[:lastSpace]
+	}
+
+	return strings.TrimRight(truncated, " ")
+}
@@ -0,0 +1,76 @@
+package content
+
+import (
+	"strings"
+
+	"golang.org/x/net/html"
+	"golang.org/x/net/html/atom"
+)
+
+// PlainText возвращает текст HTML без разметки. Блочные элементы и <br> разделяются переводом строки
+func PlainText(fragment string) string {
+	nodes, err := html.ParseFragment(strings.NewReader(fragment), &html.Node{
+		Type:     html.ElementNode,
+		Data:     "div",
+		DataAtom: atom.Div,
+	})
+	if err != nil {
+		return strings.TrimSpace(fragment)
+	}
+
+	var builder strings.Builder
+
+	var walk func(n *html.Node)
+	walk = func(n *html.Node) {
+		switch {
+		case n.Type == html.TextNode:
+			builder.WriteString(n.Data)
+		case n.Type == html.ElementNode && n.DataAtom == atom.Br:
+			builder.WriteString("\n")
+		case n.Type == html.ElementNode && (n.DataAtom == atom.Script || n.DataAtom == atom.Style):
+			return
+		}
+		for child := n.FirstChild; child != nil; child = child.NextSibling {
+			walk(child)
+		}
+		if n.Type == html.ElementNode && isBlock(n.DataAtom) {
+			builder.WriteString("\n")
+		}
+	}
+	for _, node := range nodes {
+		walk(node)
+	}
+
+	return collapseLines(builder.String())
+}
+
+// isBlock сообщает, начинается ли после элемента новая строка текста
+func isBlock(a atom.Atom) bool {
+	switch a {
+	case atom.P, atom.Div, atom.Li, atom.Ul, atom.Ol, atom.Tr, atom.Table, atom.Blockquote, atom.Pre,
+		atom.H1, atom.H2, atom.H3, atom.H4, atom.H5, atom.H6:
+		return true
+	}
+	return false
+}
+
+// collapseLines убирает пробелы по краям строк и лишние пустые строки
+func collapseLines(text string) string {
+	lines := strings.Split(text, "\n")
+	result := make([]string, 0, len(lines))
+	blank := false
+	for _, line := range lines {
+		line = strings.Join(strings.Fields(line), " ")
+		if line == "" {
+			if !blank && len(result) > 0 {
+				result = append(result, "")
+			}
+			blank = true
+			continue
+		}
+		result = append(result, line)
+		blank = false
+	}
+
+	return strings.TrimSpace(strings.Join(result, "\n"))
+}
@@ -0,0 +1,79 @@
+// Package dedup находит почти одинаковые вакансии, опубликованные в разных источниках
+package dedup
+
+import (
+	"hash/fnv"
+	"math/bits"
+	"regexp"
+	"strings"
+	"unicode"
+)
+
+const (
+	// MaxDistance максимальное количество различающихся бит SimHash у почти одинаковых текстов.
+	// Подпись канала или измененная сумма в тексте вакансии меняют 3-5 бит, другая вакансия - больше 10.
+	// Кандидаты ищутся в базе по MaxDistance+1 полосам SimHash (функция simhash_bands в миграциях),
+	// поэтому при изменении порога нужно изменить и количество полос
+	MaxDistance = 6
+
+	// BandCount количество полос SimHash в функции simhash_bands. Если тексты отличаются не больше чем
+	// MaxDistance битами, то хотя бы одна из MaxDistance+1 полос у них совпадает
+	BandCount = MaxDistance + 1
+
+	// BandBits количество бит SimHash в одной полосе функции simhash_bands
+	BandBits = 9
+
+	// shingleSize количество слов в одном признаке текста
+	shingleSize = 3
+
+	// minWords минимальное количество слов, при котором SimHash надежно отличает тексты
+	minWords = 20
+)
+
+// noisePattern ссылки, упоминания и хэштеги. Каналы добавляют их к перепостам, поэтому они не учитываются
+var noisePattern = regexp.MustCompile(`(?i)https?://\S+|t\.me/\S+|[@#][\p{L}\p{N}_]+`)
+
+// SimHash считает 64-битный SimHash текста по шинглам из трех слов. Возвращает false,
+// если в тексте слишком мало слов, чтобы сравнение было надежным
+func SimHash(text string) (uint64, bool) {
+	words := strings.FieldsFunc(strings.ToLower(noisePattern.ReplaceAllString(text, " ")), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+	if len(words) < minWords {
+		return 0, false
+	}
+
+	var weights [64]int
+	for i := 0; i+shingleSize <= len(words); i++ {
+		h := fnv.New64a()
+		h.Write([]byte(strings.Join(words[i:i+shingleSize], " ")))
+		sum := h.Sum64()
+
+		for bit := 0; bit < 64; bit++ {
+			if sum&(1<<bit) != 0 {
+				weights[bit]++
+			} else {
+				weights[bit]--
+			}
+		}
+	}
+
+	var hash uint64
+	for bit, weight := range weights {
+		if weight > 0 {
+			hash |= 1 << bit
+		}
+	}
+
+	return hash, true
+}
+
+// Distance возвращает количество различающихся бит двух SimHash
+func Distance(a, b uint64) int {
+	return bits.OnesCount64(a ^ b)
+}
+
+// IsNearDuplicate сообщает, что тексты с такими SimHash почти одинаковы
+func IsNearDuplicate(a, b uint64) bool {
+	return Distance(a, b) <= MaxDistance
+}
@@ -0,0 +1,141 @@
+package dedup
+
+import (
+	"fmt"
+	"math/rand"
+	"os"
+	"slices"
+	"strings"
+	"testing"
+)
+
+// bandsMigration миграция с функцией simhash_bands
+const bandsMigration = "../../../migrations/20250630120000_add_jobs_duplicates.sql"
+
+// vacancy текст вакансии, которую каналы публикуют повторно
+const vacancy = `Ищем Golang разработчика в команду платежного сервиса. Полная удаленка, оформление по ТК РФ или как ИП.
+Задачи: разработка микросервисов на Go, проектирование API, оптимизация запросов к PostgreSQL, участие в код-ревью.
+Требования: опыт коммерческой разработки на Go от трех лет, знание PostgreSQL и Kafka, понимание принципов
+построения распределенных систем. Будет плюсом опыт с Kubernetes и gRPC. Зарплата от 350 000 до 450 000 рублей на руки.`
+
+// otherVacancy текст другой вакансии того же стека
+const otherVacancy = `В финтех-стартап нужен Senior Go Developer. Работа из любой точки мира, оплата в долларах.
+Вы будете развивать биллинг и антифрод, писать сервисы на Go, настраивать мониторинг в Grafana и Prometheus.
+Мы ждем уверенного знания Go, опыта работы с Redis и ClickHouse, умения писать тесты. Английский не ниже B2.
+Предлагаем гибкий график, оплачиваемое обучение и опцион. Вилка 5000-7000 USD в месяц.`
+
+// bands делит SimHash на полосы так же, как функция simhash_bands в миграциях
+func bands(hash uint64) []int32 {
+	result := make([]int32, 0, BandCount)
+	for band := 0; band < BandCount; band++ {
+		result = append(result, int32(band<<BandBits)|int32((hash>>(band*BandBits))&(1<<BandBits-1)))
+	}
+	return result
+}
+
+// sharesBand сообщает, что у двух SimHash совпадает хотя бы одна полоса (условие simhash_bands && simhash_bands)
+func sharesBand(a, b uint64) bool {
+	bandsB := bands(b)
+	for _, band := range bands(a) {
+		if slices.Contains(bandsB, band) {
+			return true
+		}
+	}
+	return false
+}
+
+func TestBandsMatchMigration(t *testing.T) {
+	if BandCount*BandBits > 64 {
+		t.Fatalf("%d полос по %d бит не помещаются в 64-битный SimHash", BandCount, BandBits)
+	}
+
+	migration, err := os.ReadFile(bandsMigration)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, fragment := range []string{
+		fmt.Sprintf("(band << %d)", BandBits),
+		fmt.Sprintf("(hash >> (band * %d)) & %d", BandBits, 1<<BandBits-1),
+		fmt.Sprintf("generate_series(0, %d)", BandCount-1),
+	} {
+		if !strings.Contains(string(migration), fragment) {
+			t.Errorf("simhash_bands в %s не совпадает с BandCount=%d и BandBits=%d: нет %q",
+				bandsMigration, BandCount, BandBits, fragment)
+		}
+	}
+}
+
+func TestBandsFindNearDuplicates(t *testing.T) {
+	random := rand.New(rand.NewSource(1))
+
+	// Почти одинаковые SimHash всегда находятся по полосам, как бы ни распределились различающиеся биты
+	for i := 0; i < 10000; i++ {
+		hash := random.Uint64()
+		changed := hash
+		for _, bit := range random.Perm(64)[:1+random.Intn(MaxDistance)] {
+			changed ^= 1 << bit
+		}
+
+		if !IsNearDuplicate(hash, changed) {
+			t.Fatalf("SimHash %x и %x отличаются на %d бит и должны считаться повтором", hash, changed, Distance(hash, changed))
+		}
+		if !sharesBand(hash, changed) {
+			t.Fatalf("у SimHash %x и %x, отличающихся на %d бит, нет общей полосы", hash, changed, Distance(hash, changed))
+		}
+	}
+
+	// При пороге MaxDistance+1 полос не хватает: по одному различающемуся биту в каждой полосе
+	// дают повтор, который не находится по индексу
+	hash := random.Uint64()
+	changed := hash
+	for band := 0; band < BandCount; band++ {
+		changed ^= 1 << (band * BandBits)
+	}
+	if Distance(hash, changed) != MaxDistance+1 {
+		t.Fatalf("ожидалось различие в %d бит, получено %d", MaxDistance+1, Distance(hash, changed))
+	}
+	if sharesBand(hash, changed) {
+		t.Error("SimHash с различающимся битом в каждой полосе не должны иметь общих полос")
+	}
+}
+
+func TestSimHash(t *testing.T) {
+	original, ok := SimHash(vacancy)
+	if !ok {
+		t.Fatal("SimHash не посчитан для текста вакансии")
+	}
+
+	tests := []struct {
+		name      string
+		text      string
+		duplicate bool
+	}{
+		{"тот же текст", vacancy, true},
+		{"репост с подписью канала", vacancy + "\n\n@golang_jobs - вакансии для гоферов https://t.me/golang_jobs #go #вакансия", true},
+		{"репост со ссылкой на отклик", "Откликаться: @hr_anna\n" + vacancy, true},
+		{"другой регистр и знаки", strings.ToUpper(strings.ReplaceAll(vacancy, ",", ";")), true},
+		{"другая вакансия", otherVacancy, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			hash, ok := SimHash(tt.text)
+			if !ok {
+				t.Fatal("SimHash не посчитан")
+			}
+			if got := IsNearDuplicate(original, hash); got != tt.duplicate {
+				t.Errorf("IsNearDuplicate = %v (различие %d бит), ожидалось %v", got, Distance(original, hash), tt.duplicate)
+			}
+			if tt.duplicate && !sharesBand(original, hash) {
+				t.Error("повтор не находится по полосам SimHash")
+			}
+		})
+	}
+}
+
+func TestSimHashShortText(t *testing.T) {
+	if _, ok := SimHash("Go developer, удаленно, https://t.me/golang_jobs @hr #go"); ok {
+		t.Error("SimHash короткого текста не должен считаться")
+	}
+}
@@ -0,0 +1,14 @@
+package entity
+
+import "time"
+
+// IngestClient внешний парсер, который отправляет вакансии через API
+type IngestClient struct {
+	ID              int64
+	Name            string     // Уникальное имя клиента, передается в заголовке запроса
+	Secret          string     // Секрет для подписи запросов HMAC-SHA256
+	Enabled         bool       // Клиенту разрешено отправлять вакансии
+	DateClientAdded time.Time  // Дата добавления клиента
+	ItemsReceived   int64      // Количество полученных от клиента вакансий
+	DateLastRequest *time.Time // Дата последнего запроса (nil - запросов еще не было)
+}
@@ -0,0 +1,48 @@
+package entity
+
+import "time"
+
+// Действия с вакансией в журнале изменений админки
+const (
+	JobAuditUpdate   = "update"   // Изменены заголовок, основная технология или текст
+	JobAuditHide     = "hide"     // Вакансия скрыта с сайта
+	JobAuditUnhide   = "unhide"   // Вакансия снова показывается на сайте
+	JobAuditDelete   = "delete"   // Вакансия удалена
+	JobAuditModerate = "moderate" // Модератор назначил технологию или отметил пост как не вакансию
+	JobAuditRestore  = "restore"  // Снята отметка "не вакансия"
+)
+
+// Поля вакансии в журнале изменений
+const (
+	JobFieldTitle          = "title"
+	JobFieldMainTechnology = "main_technology"
+	JobFieldContent        = "content"
+	JobFieldHidden         = "hidden"
+	JobFieldStatus         = "status"
+	JobFieldModeration     = "moderation"
+)
+
+// JobFieldChange изменение поля вакансии: значение до и после изменения
+type JobFieldChange struct {
+	Field  string `json:"field"`
+	Before string `json:"before"`
+	After  string `json:"after"`
+}
+
+// JobAuditEntry запись журнала изменений вакансии
+type JobAuditEntry struct {
+	ID          int64
+	JobID       int64
+	Action      string           // Действие (JobAuditUpdate и т.д.)
+	Actor       string           // Кто выполнил действие
+	Comment     string           // Причина изменения, пусто - не указана
+	Changes     []JobFieldChange // Измененные поля
+	DateCreated time.Time
+}
+
+// JobEdit изменяемые в админке поля вакансии
+type JobEdit struct {
+	Title          string
+	MainTechnology string // Пусто - технология не определена, вакансия не показывается на сайте
+	Content        string // HTML текста вакансии
+}
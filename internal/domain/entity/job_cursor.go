@@ -0,0 +1,72 @@
+package entity
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// CursorDirection направление перехода при курсорной пагинации
+type CursorDirection int
+
+const (
+	// CursorOlder переход к более старым вакансиям
+	CursorOlder CursorDirection = iota
+	// CursorNewer переход к более новым вакансиям
+	CursorNewer
+)
+
+// JobCursor позиция вакансии в списке, отсортированном по (date_posted, id)
+type JobCursor struct {
+	DatePosted time.Time
+	ID         int64
+}
+
+// NewJobCursor возвращает курсор, указывающий на вакансию
+func NewJobCursor(job JobRaw) JobCursor {
+	return JobCursor{
+		DatePosted: job.DatePosted,
+		ID:         job.ID,
+	}
+}
+
+// IsZero сообщает, что курсор не задан (первая страница списка)
+func (c JobCursor) IsZero() bool {
+	return c.ID == 0 && c.DatePosted.IsZero()
+}
+
+// String кодирует курсор для передачи в URL
+func (c JobCursor) String() string {
+	return fmt.Sprintf("%d_%d", c.DatePosted.UnixMicro(), c.ID)
+}
+
+// ParseJobCursor разбирает курсор, полученный из URL
+func ParseJobCursor(s string) (JobCursor, error) {
+	microStr, idStr, ok := strings.Cut(s, "_")
+	if !ok {
+		return JobCursor{}, fmt.Errorf("некорректный курсор %q", s)
+	}
+
+	micro, err := strconv.ParseInt(microStr, 10, 64)
+	if err != nil {
+		return JobCursor{}, fmt.Errorf("некорректная дата в курсоре %q: %w", s, err)
+	}
+
+	id, err := strconv.ParseInt(idStr, 10, 64)
+	if err != nil || id < 1 {
+		return JobCursor{}, fmt.Errorf("некорректный ID в курсоре %q", s)
+	}
+
+	return JobCursor{
+		DatePosted: time.UnixMicro(micro),
+		ID:         id,
+	}, nil
+}
+
+// JobCursorPage страница списка вакансий при курсорной пагинации
+type JobCursorPage struct {
+	Jobs  []JobRaw
+	Older *JobCursor // Курсор для перехода к более старым вакансиям, nil - их нет
+	Newer *JobCursor // Курсор для перехода к более новым вакансиям, nil - их нет
+}
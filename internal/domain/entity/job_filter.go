@@ -0,0 +1,19 @@
+package entity
+
+import "time"
+
+// JobFilter дополнительные условия отбора вакансий в списках.
+// Нулевые значения полей означают отсутствие ограничения
+type JobFilter struct {
+	PostedFrom     time.Time // Нижняя граница даты публикации (включительно)
+	PostedTo       time.Time // Верхняя граница даты публикации (не включительно)
+	ShowHidden     bool      // Показывать вакансии со стоп-словами
+	StopWords      []string  // Стоп-слова, вакансии с которыми скрываются из списка
+	SalaryCurrency string    // Валюта зарплаты (пусто - зарплата не учитывается)
+	SalaryFrom     int64     // Зарплата в месяц не меньше (в валюте SalaryCurrency)
+	SalaryTo       int64     // Зарплата в месяц не больше (в валюте SalaryCurrency)
+	Seniority      []string  // Уровни позиции, хотя бы один из которых должен быть у вакансии
+	Location       []string  // Ограничения по месту работы, хотя бы одно из которых должно быть у вакансии
+	Country        string    // Код страны, из которой можно работать по вакансии
+	IncludeExpired bool      // Показывать вместе с активными устаревшие вакансии (поиск и произвольный период)
+}
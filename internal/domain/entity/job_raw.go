@@ -3,13 +3,59 @@ package entity
 import "time"
 
 type JobRaw struct {
-	ID             int64
-	Content        string
-	Title          string
-	SourceLink     string
-	MainTechnology string
-	ContentPure    string
-	Slug           string
-	DatePosted     time.Time
-	DateParsed     time.Time
+	ID               int64
+	Content          string
+	ContentSanitized string // Очищенный HTML для страницы вакансии, пусто - еще не подготовлен
+	ContentPreview   string // HTML превью для списков, пусто - еще не подготовлено
+	MetaDescription  string // Текст мета-описания, пусто - еще не подготовлен
+	Title            string
+	SourceLink       string
+	MainTechnology   string
+	ContentPure      string
+	Slug             string
+	StopWords        []string
+	Salary           Salary
+	Seniority        []string
+	Location         Location
+	SimHash          int64  // SimHash текста, 0 - не посчитан
+	CanonicalID      int64  // ID канонической вакансии, 0 - вакансия сама каноническая
+	Status           string // Статус жизненного цикла (JobStatusActive и т.д.)
+	Hidden           bool   // Скрыта администратором и не показывается на сайте
+	Moderation       string // Решение модератора (ModerationApproved и т.д.), пусто - не проверялась
+	DatePosted       time.Time
+	DateParsed       time.Time
+
+	// TechnologyScores оценки технологий классификатором. Заполняется при классификации
+	// и читается только для очереди модерации
+	TechnologyScores []TechnologyScore
+}
+
+// Статусы жизненного цикла вакансии. Статус меняется только вперед по мере старения вакансии
+const (
+	JobStatusActive   = "active"   // Показывается в списках
+	JobStatusExpired  = "expired"  // Вероятно закрыта: страница доступна с предупреждением
+	JobStatusArchived = "archived" // В архиве: страница доступна с предупреждением и не индексируется
+	JobStatusRemoved  = "removed"  // Удалена: страница отвечает 410 Gone
+)
+
+// JobLifecycle возраст вакансии в днях, после которого она переходит в следующий статус
+type JobLifecycle struct {
+	ExpireAfterDays  int // active -> expired
+	ArchiveAfterDays int // expired -> archived
+	RemoveAfterDays  int // archived -> removed
+}
+
+// JobFingerprint отпечаток текста вакансии для поиска почти одинаковых вакансий
+type JobFingerprint struct {
+	ID          int64
+	SimHash     int64
+	CanonicalID int64
+}
+
+// RootID возвращает ID канонической вакансии группы повторов, в которую входит вакансия
+func (f JobFingerprint) RootID() int64 {
+	if f.CanonicalID != 0 {
+		return f.CanonicalID
+	}
+	return f.ID
 }
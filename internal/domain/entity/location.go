@@ -0,0 +1,35 @@
+package entity
+
+// Ограничения по месту работы, найденные в вакансии
+const (
+	LocationWorldwide  = "worldwide"  // Работа из любой страны
+	LocationCountry    = "country"    // Нужно находиться в определенных странах
+	LocationTimezone   = "timezone"   // Нужно пересечение по часовому поясу
+	LocationRelocation = "relocation" // Нужен переезд
+	LocationHybrid     = "hybrid"     // Частично работа в офисе
+)
+
+// LocationConstraints все ограничения по месту работы
+var LocationConstraints = []string{
+	LocationWorldwide,
+	LocationCountry,
+	LocationTimezone,
+	LocationRelocation,
+	LocationHybrid,
+}
+
+// Location ограничения по месту работы. Пустое значение означает, что в вакансии они не указаны
+type Location struct {
+	Constraints []string // Ограничения из LocationConstraints
+	Countries   []string // Коды стран (ISO 3166-1 alpha-2, EU - Евросоюз), где нужно находиться
+}
+
+// HasConstraint сообщает, указано ли в вакансии ограничение
+func (l Location) HasConstraint(constraint string) bool {
+	for _, value := range l.Constraints {
+		if value == constraint {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,33 @@
+package entity
+
+// Решения модератора по вакансии (колонка jobs_raw.moderation)
+const (
+	ModerationApproved   = "approved"    // Технология подтверждена или назначена вручную
+	ModerationNotVacancy = "not_vacancy" // Пост не является вакансией и не показывается на сайте
+)
+
+// Причины попадания вакансии в очередь модерации
+const (
+	ModerationReasonUnclassified  = "unclassified"   // Классификатор не определил технологию
+	ModerationReasonLowConfidence = "low_confidence" // Технология определена неуверенно
+)
+
+// ModerationFilter выборка очереди модерации: непроверенные активные вакансии без технологии
+// или с неуверенно определенной технологией
+type ModerationFilter struct {
+	Reason     string   // Причина попадания в очередь, пусто - любая
+	LowScore   float64  // Оценка лучшей технологии, ниже которой классификация неуверенная
+	CloseRatio float64  // Отношение второй оценки к лучшей, начиная с которого классификация неуверенная
+	StopWords  []string // Стоп-слова справочника: вакансии с ними скрыты с сайта и в очередь не попадают
+}
+
+// ModerationCounts количество вакансий в очереди модерации по причинам
+type ModerationCounts struct {
+	Unclassified  int
+	LowConfidence int
+}
+
+// Total возвращает общее количество вакансий в очереди
+func (c ModerationCounts) Total() int {
+	return c.Unclassified + c.LowConfidence
+}
@@ -0,0 +1,36 @@
+package entity
+
+// Валюты зарплаты (коды ISO 4217)
+const (
+	CurrencyRUB = "RUB"
+	CurrencyUSD = "USD"
+	CurrencyEUR = "EUR"
+	CurrencyGBP = "GBP"
+)
+
+// Периоды, за который указана зарплата
+const (
+	SalaryPeriodHour  = "hour"
+	SalaryPeriodMonth = "month"
+	SalaryPeriodYear  = "year"
+)
+
+// Указание налогов в зарплате
+const (
+	SalaryTaxGross = "gross" // До вычета налогов
+	SalaryTaxNet   = "net"   // На руки
+)
+
+// Salary зарплата, указанная в тексте вакансии
+type Salary struct {
+	Min      int64  // Нижняя граница (0 - не указана)
+	Max      int64  // Верхняя граница (0 - не указана)
+	Currency string // Код валюты (пусто - зарплата не найдена)
+	Period   string // Период: час, месяц или год
+	Tax      string // gross, net или пусто, если не указано
+}
+
+// IsZero сообщает, что зарплата не указана
+func (s Salary) IsZero() bool {
+	return s.Currency == "" || (s.Min == 0 && s.Max == 0)
+}
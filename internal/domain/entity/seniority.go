@@ -0,0 +1,28 @@
+package entity
+
+// Уровни позиции в порядке возрастания
+const (
+	SeniorityIntern    = "intern"
+	SeniorityJunior    = "junior"
+	SeniorityMiddle    = "middle"
+	SenioritySenior    = "senior"
+	SeniorityLead      = "lead"
+	SeniorityPrincipal = "principal"
+)
+
+// SeniorityLevels все уровни позиции в порядке возрастания
+var SeniorityLevels = []string{
+	SeniorityIntern,
+	SeniorityJunior,
+	SeniorityMiddle,
+	SenioritySenior,
+	SeniorityLead,
+	SeniorityPrincipal,
+}
+
+// SeniorityRule ключевые слова, по которым вакансия относится к уровню позиции
+type SeniorityRule struct {
+	ID       int64
+	Level    string
+	Keywords []string
+}
@@ -0,0 +1,26 @@
+package entity
+
+import "time"
+
+// Source источник вакансий, загружаемый адаптером своего типа, и состояние его обработки
+type Source struct {
+	ID              int64
+	Kind            string     // Тип источника, по которому выбирается адаптер (например, "rss")
+	Name            string     // Уникальное имя источника
+	URL             string     // Адрес источника
+	State           string     // Состояние адаптера после последней загрузки, формат зависит от типа источника
+	Enabled         bool       // Источник загружается парсером
+	DateSourceAdded time.Time  // Дата добавления источника
+	ItemsParsed     int64      // Количество обработанных публикаций
+	DateLastParsed  *time.Time // Дата последней успешной загрузки (nil - источник еще не загружался)
+	LastError       string     // Ошибка последней загрузки, пусто - загрузка прошла успешно
+}
+
+// SourceItem публикация вакансии, полученная из источника
+type SourceItem struct {
+	Title      string    // Заголовок, пусто - формируется из текста
+	HTML       string    // HTML текста публикации
+	Text       string    // Текст публикации без разметки
+	Link       string    // Ссылка на публикацию в источнике
+	DatePosted time.Time // Дата публикации, нулевое значение - неизвестна
+}
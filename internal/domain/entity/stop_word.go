@@ -0,0 +1,13 @@
+package entity
+
+type StopWord struct {
+	ID   int64
+	Word string
+}
+
+// StopWordImpact сколько вакансий затронет стоп-слово
+type StopWordImpact struct {
+	Word    string
+	Matched int64 // Вакансий, в заголовке или тексте которых встречается слово
+	Visible int64 // Из них сейчас показываются на сайте и будут скрыты
+}
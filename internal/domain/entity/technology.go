@@ -1,9 +1,28 @@
 package entity
 
+import "time"
+
 type Technology struct {
-	ID         int64
-	Technology string
-	Keywords   []string
-	SortOrder  int
-	Count      int64
+	ID               int64
+	Technology       string
+	Keywords         []string
+	NegativeKeywords []string
+	SortOrder        int
+	Count            int64        // Количество показываемых вакансий
+	CountRecent      int64        // Количество показываемых вакансий за последние 30 дней
+	Lifecycle        JobLifecycle // Пороги жизненного цикла вакансий технологии, 0 - порог по умолчанию
+}
+
+// TechnologyScore оценка технологии классификатором для вакансии
+type TechnologyScore struct {
+	Technology string  `json:"technology"`
+	Score      float64 `json:"score"`
+}
+
+// ClassifyFilter выборка вакансий для классификации по технологиям
+type ClassifyFilter struct {
+	Technologies []string  // Текущие основные технологии, пустой список - любые
+	Unclassified bool      // Только вакансии без основной технологии
+	PostedFrom   time.Time // Опубликованы не раньше (нулевое значение - без ограничения)
+	PostedTo     time.Time // Опубликованы раньше (нулевое значение - без ограничения)
 }
@@ -0,0 +1,33 @@
+package entity
+
+import "time"
+
+// TelegramChannel канал Telegram, из которого загружаются вакансии, и состояние его обработки
+type TelegramChannel struct {
+	ID               int64
+	Tag              string     // Имя канала в ссылке t.me/<tag>
+	LastPostID       int64      // ID последнего обработанного поста
+	DateChannelAdded time.Time  // Дата добавления канала
+	PostsParsed      int64      // Количество обработанных постов
+	DateLastParsed   *time.Time // Дата последней обработки (nil - канал еще не обрабатывался)
+	Paused           bool       // Канал не загружается при обходе всех каналов
+}
+
+// TelegramChannelStats канал Telegram и показатели сохраненных из него вакансий
+type TelegramChannelStats struct {
+	TelegramChannel
+	Jobs         int64 // Сохранено вакансий из постов канала
+	Classified   int64 // Из них с определенной основной технологией
+	Duplicates   int64 // Из них повторов вакансий из других постов и источников
+	StopWordHits int64 // Из них скрытых стоп-словами
+}
+
+// TelegramPost пост канала Telegram из веб-превью t.me/s/<tag>
+type TelegramPost struct {
+	ID         int64     // ID поста в канале
+	Channel    string    // Имя канала
+	HTML       string    // HTML текста поста
+	Text       string    // Текст поста без разметки
+	Link       string    // Ссылка на пост
+	DatePosted time.Time // Дата публикации
+}
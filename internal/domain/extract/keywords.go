@@ -0,0 +1,56 @@
+package extract
+
+import (
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// ContainsWord сообщает, встречается ли ключевое слово в тексте как отдельное слово, без учета регистра.
+// Границы проверяются только у букв и цифр, поэтому "c++" и ".net" тоже находятся
+func ContainsWord(text, keyword string) bool {
+	return CountWord(strings.ToLower(text), strings.ToLower(keyword), 1) > 0
+}
+
+// CountWord считает вхождения ключевого слова в тексте как отдельного слова, но не больше limit
+// (0 - без ограничения). Текст и ключевое слово должны быть приведены к нижнему регистру
+func CountWord(text, keyword string, limit int) int {
+	if keyword == "" {
+		return 0
+	}
+
+	first, _ := utf8.DecodeRuneInString(keyword)
+	last, _ := utf8.DecodeLastRuneInString(keyword)
+
+	count := 0
+	for offset := 0; offset < len(text); {
+		index := strings.Index(text[offset:], keyword)
+		if index < 0 {
+			break
+		}
+
+		start := offset + index
+		end := start + len(keyword)
+
+		before, _ := utf8.DecodeLastRuneInString(text[:start])
+		after, _ := utf8.DecodeRuneInString(text[end:])
+
+		if (!isWordRune(first) || start == 0 || !isWordRune(before)) &&
+			(!isWordRune(last) || end == len(text) || !isWordRune(after)) {
+			count++
+			if limit > 0 && count >= limit {
+				break
+			}
+		}
+
+		_, size := utf8.DecodeRuneInString(text[start:])
+		offset = start + size
+	}
+
+	return count
+}
+
+// isWordRune сообщает, является ли символ частью слова
+func isWordRune(r rune) bool {
+	return unicode.IsLetter(r) || unicode.IsDigit(r)
+}
@@ -0,0 +1,170 @@
+package extract
+
+import (
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/zalhonan/remotejobs-site/internal/domain/entity"
+)
+
+const (
+	// countryWindow количество символов после упоминания ограничения, в которых ищутся страны
+	countryWindow = 80
+
+	// countryOfficeContext количество байт перед упоминанием ограничения, в которых ищется слово "офис"
+	countryOfficeContext = 40
+)
+
+// countryKeywords названия стран в текстах вакансий по кодам стран
+var countryKeywords = map[string][]string{
+	"RU": {"рф", "россия", "россии", "российской федерации", "russia", "russian federation"},
+	"BY": {"рб", "беларусь", "беларуси", "белоруссия", "белоруссии", "belarus"},
+	"KZ": {"казахстан", "казахстане", "казахстана", "kazakhstan"},
+	"UZ": {"узбекистан", "узбекистане", "узбекистана", "uzbekistan"},
+	"AM": {"армения", "армении", "armenia"},
+	"GE": {"грузия", "грузии", "georgia"},
+	"RS": {"сербия", "сербии", "serbia"},
+	"UA": {"украина", "украине", "украины", "ukraine"},
+	"US": {"сша", "usa", "united states"},
+	"GB": {"великобритания", "великобритании", "united kingdom"},
+	"EU": {"ес", "евросоюз", "евросоюза", "европа", "европе", "европы", "eu", "europe", "european union"},
+}
+
+var (
+	// locationWorldwide работа из любой страны
+	locationWorldwide = regexp.MustCompile(`(?i)(worldwide|from anywhere|work anywhere|anywhere in the world|` +
+		`любой точк[еи] мира|любой страны|любая страна|без привязки к (локации|стране|местоположению)|` +
+		`без ограничений по (локации|стране|местоположению))`)
+
+	// locationCountryStrict ограничение по стране, даже если сама страна не названа
+	locationCountryStrict = regexp.MustCompile(`(?i)(резидент|гражданств|гражданин|must (be )?(reside|based|located)|` +
+		`residents? of|citizens? of|based in|located in|находиться (в|на территории)|проживание в|проживающ)`)
+
+	// locationCountryWeak ограничение по стране, только если рядом названа страна
+	locationCountryWeak = regexp.MustCompile(`(?i)(только|only|within|exclusively)`)
+
+	// locationCountryWaived оборот сразу после указателя на ограничение, снимающий его:
+	// "гражданство не важно", "проживающих в любой точке мира", "residents of any country"
+	locationCountryWaived = regexp.MustCompile(`(?i)^\S*\s+((в|на|из|in|of|from)\s+)?` +
+		`(не важн|не имеет значени|не требуется|не обязательн|любо|any\b)`)
+
+	// locationCountryOffice слово перед указателем, относящее его к офису компании, а не к кандидату:
+	// "office located in Berlin", "office based in London"
+	locationCountryOffice = regexp.MustCompile(`(?i)(office|офис|headquarters|штаб-квартира)\S*\s+(is\s+)?$`)
+
+	// locationCountryExcluded оборот, исключающий страну: "из РФ не рассматриваем", "кроме РФ"
+	locationCountryExcluded = regexp.MustCompile(`(?i)(не рассматрива|кроме|except|excluding|не подходит|not eligible)`)
+
+	// locationTimezone требование пересечения по часовому поясу
+	locationTimezone = regexp.MustCompile(`(?i)(time\s?zone|часов(ой|ом|ого|ым) пояс|(gmt|utc|мск|msk)\s?[+\-±−]{1,2}\s?\d{1,2}|` +
+		`±\s?\d{1,2}\s?(h|ч)|overlap|пересечени[ея] (по времени|с мск|с москвой)|по московскому времени|по мск)`)
+
+	// locationRelocation требование переезда
+	locationRelocation = regexp.MustCompile(`(?i)(relocation (is )?required|requires? relocation|willing to relocate|` +
+		`must relocate|необходим (переезд|релокация)|нужен переезд|требуется (переезд|релокация)|` +
+		`переезд обязателен|релокация обязательна|готовность к (переезду|релокации))`)
+
+	// locationHybrid частичная работа в офисе
+	locationHybrid = regexp.MustCompile(`(?i)(hybrid|гибрид|частично удал[её]н|частичная удал[её]нка|` +
+		`\d\s?(дн[яей]|days?) в (офисе|неделю в офисе)|\d days? (a|per) week in (the )?office)`)
+)
+
+// Location определяет ограничения по месту работы по заголовку и тексту вакансии
+func Location(title, content string) entity.Location {
+	text := strings.ToLower(title + "\n" + content)
+
+	found := make(map[string]bool)
+	countries := findRestrictedCountries(text)
+	worldwide := locationWorldwide.MatchString(text)
+
+	// Названные страны важнее общих слов "из любой точки мира", а указатель на ограничение без страны
+	// ("нужно быть резидентом") - нет
+	if len(countries) > 0 || (len(strictCountryTriggers(text)) > 0 && !worldwide) {
+		found[entity.LocationCountry] = true
+	}
+	if !found[entity.LocationCountry] && worldwide {
+		found[entity.LocationWorldwide] = true
+	}
+	if locationTimezone.MatchString(text) {
+		found[entity.LocationTimezone] = true
+	}
+	if locationRelocation.MatchString(text) {
+		found[entity.LocationRelocation] = true
+	}
+	if locationHybrid.MatchString(text) {
+		found[entity.LocationHybrid] = true
+	}
+
+	var location entity.Location
+	for _, constraint := range entity.LocationConstraints {
+		if found[constraint] {
+			location.Constraints = append(location.Constraints, constraint)
+		}
+	}
+	location.Countries = countries
+
+	return location
+}
+
+// findRestrictedCountries находит страны, названные рядом с указанием на ограничение по стране:
+// "только РФ/РБ", "резидент РФ", "based in the EU". Текст должен быть в нижнем регистре
+func findRestrictedCountries(text string) []string {
+	triggers := strictCountryTriggers(text)
+	triggers = append(triggers, locationCountryWeak.FindAllStringIndex(text, -1)...)
+
+	found := make(map[string]bool)
+	for _, trigger := range triggers {
+		// Страна обычно идет сразу после слова-указателя: "только РФ", "резидент Казахстана"
+		window := runePrefix(text[trigger[1]:], countryWindow)
+		if cut := strings.IndexAny(window, ".;!?\n"); cut >= 0 {
+			window = window[:cut]
+		}
+		if locationCountryExcluded.MatchString(window) {
+			continue
+		}
+
+		for code, keywords := range countryKeywords {
+			for _, keyword := range keywords {
+				if CountWord(window, keyword, 1) > 0 {
+					found[code] = true
+					break
+				}
+			}
+		}
+	}
+
+	countries := make([]string, 0, len(found))
+	for code := range found {
+		countries = append(countries, code)
+	}
+	sort.Strings(countries)
+
+	if len(countries) == 0 {
+		return nil
+	}
+	return countries
+}
+
+// strictCountryTriggers находит указатели на ограничение по стране, кроме относящихся к офису компании
+// и снятых следующими словами ("гражданство не важно"). Текст должен быть в нижнем регистре
+func strictCountryTriggers(text string) [][]int {
+	var triggers [][]int
+	for _, trigger := range locationCountryStrict.FindAllStringIndex(text, -1) {
+		before := text[max(0, trigger[0]-countryOfficeContext):trigger[0]]
+		if locationCountryOffice.MatchString(before) || locationCountryWaived.MatchString(text[trigger[1]:]) {
+			continue
+		}
+		triggers = append(triggers, trigger)
+	}
+	return triggers
+}
+
+// runePrefix возвращает первые n символов строки, не разрезая многобайтовые символы
+func runePrefix(text string, n int) string {
+	runes := []rune(text)
+	if len(runes) > n {
+		runes = runes[:n]
+	}
+	return string(runes)
+}
@@ -0,0 +1,61 @@
+package extract
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/zalhonan/remotejobs-site/internal/domain/entity"
+)
+
+func TestLocationCountries(t *testing.T) {
+	tests := []struct {
+		text      string
+		countries []string
+	}{
+		{"Работа только из РФ/РБ", []string{"BY", "RU"}},
+		// Страна дальше 80 байт, но ближе 80 символов от слова-указателя
+		{"Рассматриваем только специалистов, которые сейчас живут и работают в Казахстане", []string{"KZ"}},
+		{"Из РФ не рассматриваем, только удаленно", nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.text, func(t *testing.T) {
+			location := Location("", tt.text)
+			if !slices.Equal(location.Countries, tt.countries) {
+				t.Errorf("получены страны %v, ожидались %v", location.Countries, tt.countries)
+			}
+		})
+	}
+}
+
+func TestLocationCountryConstraint(t *testing.T) {
+	tests := []struct {
+		text      string
+		country   bool
+		worldwide bool
+	}{
+		{"Только для резидентов РФ", true, false},
+		{"Candidates must be based in the EU", true, false},
+		{"Нужно гражданство РФ", true, false},
+		{"Ищем разработчиков, проживающих в России", true, false},
+		{"Обязательно быть налоговым резидентом", true, false},
+		{"Работа из любой точки мира, но нужно быть резидентом РФ", true, false},
+		{"Ищем разработчиков, проживающих в любой точке мира", false, true},
+		{"Residents of any country are welcome", false, false},
+		{"Гражданство не важно, работа удаленная", false, false},
+		{"Our office located in Berlin, but the team works fully remote", false, false},
+		{"Office based in London, work from anywhere", false, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.text, func(t *testing.T) {
+			location := Location("", tt.text)
+			if got := slices.Contains(location.Constraints, entity.LocationCountry); got != tt.country {
+				t.Errorf("ограничение по стране: %v, ожидалось %v (%v)", got, tt.country, location.Constraints)
+			}
+			if got := slices.Contains(location.Constraints, entity.LocationWorldwide); got != tt.worldwide {
+				t.Errorf("работа из любой страны: %v, ожидалось %v (%v)", got, tt.worldwide, location.Constraints)
+			}
+		})
+	}
+}
@@ -0,0 +1,273 @@
+// Package extract содержит разбор структурированных данных из текста вакансий
+package extract
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/zalhonan/remotejobs-site/internal/domain/entity"
+)
+
+const (
+	// salaryNumber число с пробелами между разрядами ("250 000") или с дробной частью ("4.5")
+	salaryNumber = `(\d{1,3}(?:[ \x{00A0}\x{202F}]\d{3})+|\d+(?:[.,]\d+)?)`
+
+	// salaryMultiplier сокращение тысяч или миллионов после числа
+	salaryMultiplier = `(?:\s?(k|к|тыс\.?|тысяч|млн))?`
+
+	// salaryCurrency обозначение валюты до или после числа
+	salaryCurrency = `(₽|руб[\pL.]*|р\.|rub|rur|\$|usd|долл[\pL.]*|€|eur|евро|£|gbp)`
+
+	// salaryTailLength количество символов после суммы, в которых ищутся период и налоги
+	salaryTailLength = 40
+)
+
+// salaryPattern находит сумму или диапазон: "от 250 000 ₽", "$4-6k", "3000-4000 EUR", "от 3000$ до 5000$"
+var salaryPattern = regexp.MustCompile(`(?i)(от|from|до|up to)?\s*` + salaryCurrency + `?\s*` +
+	salaryNumber + salaryMultiplier +
+	`(?:\s*` + salaryCurrency + `?\s*(?:-|–|—|до|to)\s*` + salaryCurrency + `?\s*` + salaryNumber + salaryMultiplier + `)?` +
+	`\s*` + salaryCurrency + `?`)
+
+// Индексы групп salaryPattern
+const (
+	groupBound = iota + 1
+	groupCurrencyBefore
+	groupFirstNumber
+	groupFirstMultiplier
+	groupFirstCurrencyAfter
+	groupSecondCurrency
+	groupSecondNumber
+	groupSecondMultiplier
+	groupCurrencyAfter
+)
+
+var (
+	salaryPeriodHour  = regexp.MustCompile(`(?i)(/\s*h\b|/\s*hr|/\s*час|в час|per hour|hourly|an hour|почасов)`)
+	salaryPeriodYear  = regexp.MustCompile(`(?i)(/\s*y(ea)?r|/\s*год|в год|per year|per annum|a year|annual|yearly|годов)`)
+	salaryPeriodMonth = regexp.MustCompile(`(?i)(/\s*mo|/\s*мес|в мес|per month|a month|monthly|ежемесяч)`)
+	salaryTaxGross    = regexp.MustCompile(`(?i)(gross|гросс|брутто|до вычета|до налог)`)
+	salaryTaxNet      = regexp.MustCompile(`(?i)(\bnet\b|нетто|на руки|после вычета|после налог|чистыми)`)
+)
+
+// Salary находит первое упоминание зарплаты в тексте и приводит его к структурированному виду.
+// Суммы без указания валюты не учитываются, чтобы не принять за зарплату годы и номера телефонов
+func Salary(text string) (entity.Salary, bool) {
+	for _, match := range salaryPattern.FindAllStringSubmatchIndex(text, -1) {
+		salary, ok := parseSalaryMatch(text, match)
+		if ok {
+			return salary, true
+		}
+	}
+	return entity.Salary{}, false
+}
+
+// parseSalaryMatch разбирает одно совпадение salaryPattern
+func parseSalaryMatch(text string, match []int) (entity.Salary, bool) {
+	group := func(index int) string {
+		if match[2*index] < 0 {
+			return ""
+		}
+		return text[match[2*index]:match[2*index+1]]
+	}
+
+	// "5 kafka" - не сокращение тысяч: после множителя не должно идти буквы
+	for _, index := range []int{groupFirstMultiplier, groupSecondMultiplier} {
+		if end := match[2*index+1]; end >= 0 && end < len(text) {
+			if next, _ := utf8.DecodeRuneInString(text[end:]); unicode.IsLetter(next) {
+				return entity.Salary{}, false
+			}
+		}
+	}
+
+	currency := ""
+	for _, index := range []int{groupCurrencyBefore, groupFirstCurrencyAfter, groupSecondCurrency, groupCurrencyAfter} {
+		if currency = normalizeCurrency(group(index)); currency != "" {
+			break
+		}
+	}
+	if currency == "" {
+		return entity.Salary{}, false
+	}
+
+	first, ok := parseSalaryNumber(group(groupFirstNumber), group(groupFirstMultiplier) != "")
+	if !ok {
+		return entity.Salary{}, false
+	}
+	first *= multiplierValue(group(groupFirstMultiplier))
+
+	var second float64
+	if group(groupSecondNumber) != "" {
+		second, ok = parseSalaryNumber(group(groupSecondNumber), group(groupSecondMultiplier) != "")
+		if !ok {
+			return entity.Salary{}, false
+		}
+		multiplier := multiplierValue(group(groupSecondMultiplier))
+		second *= multiplier
+
+		// "4-6k": множитель второго числа относится и к первому
+		if group(groupFirstMultiplier) == "" && multiplier > 1 && first*multiplier <= second {
+			first *= multiplier
+		}
+	}
+
+	salary := entity.Salary{Currency: currency}
+
+	bound := strings.ToLower(group(groupBound))
+	switch {
+	case second > 0:
+		salary.Min, salary.Max = int64(first), int64(second)
+		if salary.Min > salary.Max {
+			salary.Min, salary.Max = salary.Max, salary.Min
+		}
+	case bound == "до" || bound == "up to":
+		salary.Max = int64(first)
+	default:
+		salary.Min = int64(first)
+	}
+
+	tail := salaryTail(text, match[1])
+	salary.Period = detectSalaryPeriod(tail, salary)
+	salary.Tax = detectSalaryTax(tail)
+
+	if !isPlausibleSalary(salary) {
+		return entity.Salary{}, false
+	}
+
+	return salary, true
+}
+
+// normalizeCurrency приводит обозначение валюты к коду ISO 4217
+func normalizeCurrency(value string) string {
+	value = strings.ToLower(value)
+	switch {
+	case value == "":
+		return ""
+	case value == "₽" || value == "р." || value == "rub" || value == "rur" || strings.HasPrefix(value, "руб"):
+		return entity.CurrencyRUB
+	case value == "$" || value == "usd" || strings.HasPrefix(value, "долл"):
+		return entity.CurrencyUSD
+	case value == "€" || value == "eur" || value == "евро":
+		return entity.CurrencyEUR
+	case value == "£" || value == "gbp":
+		return entity.CurrencyGBP
+	}
+	return ""
+}
+
+// parseSalaryNumber разбирает число, записанное с пробелами между разрядами или с дробной частью.
+// "3,000" без множителя означает три тысячи, "3,5k" - три с половиной тысячи
+func parseSalaryNumber(value string, hasMultiplier bool) (float64, bool) {
+	value = strings.Map(func(r rune) rune {
+		if unicode.IsSpace(r) {
+			return -1
+		}
+		return r
+	}, value)
+
+	if separator := strings.IndexAny(value, ".,"); separator >= 0 {
+		if !hasMultiplier && len(value)-separator-1 == 3 {
+			value = value[:separator] + value[separator+1:]
+		} else {
+			value = value[:separator] + "." + value[separator+1:]
+		}
+	}
+
+	number, err := strconv.ParseFloat(value, 64)
+	if err != nil || number <= 0 {
+		return 0, false
+	}
+
+	return number, true
+}
+
+// multiplierValue возвращает множитель для сокращений "k", "тыс", "млн"
+func multiplierValue(value string) float64 {
+	value = strings.ToLower(value)
+	switch {
+	case value == "":
+		return 1
+	case value == "млн":
+		return 1_000_000
+	default:
+		return 1000
+	}
+}
+
+// salaryTail возвращает текст сразу после суммы, где обычно указывают период и налоги
+func salaryTail(text string, start int) string {
+	tail := []rune(text[start:])
+	if len(tail) > salaryTailLength {
+		tail = tail[:salaryTailLength]
+	}
+	return string(tail)
+}
+
+// detectSalaryPeriod определяет период зарплаты. Если он не указан, крупные суммы
+// в долларах, евро и фунтах считаются годовыми, маленькие - почасовыми
+func detectSalaryPeriod(tail string, salary entity.Salary) string {
+	switch {
+	case salaryPeriodHour.MatchString(tail):
+		return entity.SalaryPeriodHour
+	case salaryPeriodYear.MatchString(tail):
+		return entity.SalaryPeriodYear
+	case salaryPeriodMonth.MatchString(tail):
+		return entity.SalaryPeriodMonth
+	}
+
+	if salary.Currency == entity.CurrencyRUB {
+		return entity.SalaryPeriodMonth
+	}
+
+	amount := salary.Max
+	if amount == 0 {
+		amount = salary.Min
+	}
+
+	switch {
+	case amount >= 30_000:
+		return entity.SalaryPeriodYear
+	case amount < 300:
+		return entity.SalaryPeriodHour
+	}
+	return entity.SalaryPeriodMonth
+}
+
+// detectSalaryTax определяет, указана зарплата до или после вычета налогов
+func detectSalaryTax(tail string) string {
+	switch {
+	case salaryTaxGross.MatchString(tail):
+		return entity.SalaryTaxGross
+	case salaryTaxNet.MatchString(tail):
+		return entity.SalaryTaxNet
+	}
+	return ""
+}
+
+// isPlausibleSalary отбрасывает суммы, которые не могут быть зарплатой за указанный период
+func isPlausibleSalary(salary entity.Salary) bool {
+	monthly := MonthlyAmount(salary.Max, salary.Period)
+	if monthly == 0 {
+		monthly = MonthlyAmount(salary.Min, salary.Period)
+	}
+
+	minMonthly := int64(300)
+	if salary.Currency == entity.CurrencyRUB {
+		minMonthly = 10_000
+	}
+
+	return monthly >= minMonthly && monthly <= 100_000_000
+}
+
+// MonthlyAmount приводит сумму за период к сумме в месяц (168 рабочих часов в месяце)
+func MonthlyAmount(amount int64, period string) int64 {
+	switch period {
+	case entity.SalaryPeriodHour:
+		return amount * 168
+	case entity.SalaryPeriodYear:
+		return amount / 12
+	}
+	return amount
+}
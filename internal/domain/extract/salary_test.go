@@ -0,0 +1,34 @@
+package extract
+
+import "testing"
+
+func TestSalary(t *testing.T) {
+	tests := []struct {
+		text     string
+		min, max int64
+		currency string
+	}{
+		{"от 3000$ до 5000$", 3000, 5000, "USD"},
+		{"$4-6k", 4000, 6000, "USD"},
+		{"от 250 000 ₽", 250000, 0, "RUB"},
+		{"3000-4000 EUR", 3000, 4000, "EUR"},
+		{"до 300 000 руб.", 0, 300000, "RUB"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.text, func(t *testing.T) {
+			salary, ok := Salary(tt.text)
+			if !ok {
+				t.Fatalf("зарплата не найдена")
+			}
+			if salary.Min != tt.min || salary.Max != tt.max || salary.Currency != tt.currency {
+				t.Errorf("получено %d-%d %s, ожидалось %d-%d %s",
+					salary.Min, salary.Max, salary.Currency, tt.min, tt.max, tt.currency)
+			}
+		})
+	}
+
+	if _, ok := Salary("опыт от 3 лет, 2024 год"); ok {
+		t.Errorf("числа без валюты не должны считаться зарплатой")
+	}
+}
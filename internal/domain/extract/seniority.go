@@ -0,0 +1,40 @@
+package extract
+
+import (
+	"github.com/zalhonan/remotejobs-site/internal/domain/entity"
+)
+
+// Seniority определяет уровни позиции по ключевым словам правил. Заголовок важнее текста:
+// текст просматривается, только если в заголовке уровень не указан. Вилка вида "Middle/Senior"
+// дает несколько уровней. Результат упорядочен по возрастанию уровня
+func Seniority(title, content string, rules []entity.SeniorityRule) []string {
+	if levels := matchSeniority(title, rules); len(levels) > 0 {
+		return levels
+	}
+	return matchSeniority(content, rules)
+}
+
+// matchSeniority возвращает уровни, ключевые слова которых встречаются в тексте
+func matchSeniority(text string, rules []entity.SeniorityRule) []string {
+	if text == "" {
+		return nil
+	}
+
+	found := make(map[string]bool)
+	for _, rule := range rules {
+		for _, keyword := range rule.Keywords {
+			if ContainsWord(text, keyword) {
+				found[rule.Level] = true
+				break
+			}
+		}
+	}
+
+	var levels []string
+	for _, level := range entity.SeniorityLevels {
+		if found[level] {
+			levels = append(levels, level)
+		}
+	}
+	return levels
+}
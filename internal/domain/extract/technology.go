@@ -0,0 +1,148 @@
+package extract
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/zalhonan/remotejobs-site/internal/domain/entity"
+)
+
+const (
+	// titleKeywordWeight во сколько раз совпадение в заголовке весомее совпадения в тексте
+	titleKeywordWeight = 3
+
+	// maxContentKeywordHits сколько вхождений одного ключевого слова в тексте учитывается.
+	// Ограничение не дает длинному тексту с частыми упоминаниями перевесить заголовок
+	maxContentKeywordHits = 3
+
+	// maxTechnologyScores сколько лучших оценок сохраняется для отладки классификации
+	maxTechnologyScores = 5
+)
+
+// weightedKeyword ключевое слово технологии в нижнем регистре с весом
+type weightedKeyword struct {
+	word   string
+	weight float64
+}
+
+// technologyRule подготовленные ключевые слова одной технологии
+type technologyRule struct {
+	technology string
+	sortOrder  int
+	keywords   []weightedKeyword
+	negative   []weightedKeyword
+}
+
+// TechnologyClassifier определяет основную технологию вакансии по ключевым словам справочника технологий
+type TechnologyClassifier struct {
+	rules []technologyRule
+}
+
+// NewTechnologyClassifier подготавливает ключевые слова технологий для классификации.
+// Ключевое слово задается как "слово" или "слово:вес", вес по умолчанию равен 1
+func NewTechnologyClassifier(technologies []entity.Technology) *TechnologyClassifier {
+	rules := make([]technologyRule, 0, len(technologies))
+	for _, tech := range technologies {
+		rule := technologyRule{
+			technology: tech.Technology,
+			sortOrder:  tech.SortOrder,
+			keywords:   parseWeightedKeywords(tech.Keywords),
+			negative:   parseWeightedKeywords(tech.NegativeKeywords),
+		}
+		if len(rule.keywords) > 0 {
+			rules = append(rules, rule)
+		}
+	}
+
+	return &TechnologyClassifier{rules: rules}
+}
+
+// parseWeightedKeywords разбирает ключевые слова вида "слово:вес"
+func parseWeightedKeywords(keywords []string) []weightedKeyword {
+	parsed := make([]weightedKeyword, 0, len(keywords))
+	for _, keyword := range keywords {
+		word, weight := keyword, 1.0
+		if i := strings.LastIndex(keyword, ":"); i > 0 {
+			if w, err := strconv.ParseFloat(strings.TrimSpace(keyword[i+1:]), 64); err == nil {
+				word, weight = keyword[:i], w
+			}
+		}
+
+		word = strings.ToLower(strings.TrimSpace(word))
+		if word == "" || weight <= 0 {
+			continue
+		}
+		parsed = append(parsed, weightedKeyword{word: word, weight: weight})
+	}
+	return parsed
+}
+
+// Classify оценивает технологии по заголовку и тексту вакансии. Возвращает технологию с наибольшей
+// положительной оценкой (пустую строку, если подходящей нет) и лучшие оценки в порядке убывания.
+// При равных оценках выигрывает технология с большим порядком сортировки
+func (c *TechnologyClassifier) Classify(title, content string) (string, []entity.TechnologyScore) {
+	title = strings.ToLower(title)
+	content = strings.ToLower(content)
+
+	type candidate struct {
+		score     entity.TechnologyScore
+		sortOrder int
+	}
+
+	var candidates []candidate
+	for _, rule := range c.rules {
+		score := scoreKeywords(title, content, rule.keywords)
+		if score == 0 {
+			continue
+		}
+
+		score -= scoreKeywords(title, content, rule.negative)
+		if score <= 0 {
+			continue
+		}
+
+		candidates = append(candidates, candidate{
+			score:     entity.TechnologyScore{Technology: rule.technology, Score: score},
+			sortOrder: rule.sortOrder,
+		})
+	}
+
+	if len(candidates) == 0 {
+		return "", nil
+	}
+
+	sort.Slice(candidates, func(i, j int) bool {
+		a, b := candidates[i], candidates[j]
+		if a.score.Score != b.score.Score {
+			return a.score.Score > b.score.Score
+		}
+		if a.sortOrder != b.sortOrder {
+			return a.sortOrder > b.sortOrder
+		}
+		return a.score.Technology < b.score.Technology
+	})
+
+	if len(candidates) > maxTechnologyScores {
+		candidates = candidates[:maxTechnologyScores]
+	}
+
+	scores := make([]entity.TechnologyScore, 0, len(candidates))
+	for _, c := range candidates {
+		scores = append(scores, c.score)
+	}
+
+	return scores[0].Technology, scores
+}
+
+// scoreKeywords считает суммарный вес ключевых слов, найденных в заголовке и тексте.
+// Заголовок и текст должны быть приведены к нижнему регистру
+func scoreKeywords(title, content string, keywords []weightedKeyword) float64 {
+	var score float64
+	for _, keyword := range keywords {
+		hits := titleKeywordWeight*CountWord(title, keyword.word, 1) +
+			CountWord(content, keyword.word, maxContentKeywordHits)
+		score += keyword.weight * float64(hits)
+	}
+	return score
+}
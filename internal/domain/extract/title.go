@@ -0,0 +1,30 @@
+package extract
+
+import (
+	"strings"
+	"unicode/utf8"
+)
+
+// Title формирует заголовок вакансии из первой непустой строки текста,
+// обрезая его по границе слова до maxRunes символов
+func Title(text string, maxRunes int) string {
+	for _, line := range strings.Split(text, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" {
+			continue
+		}
+
+		if utf8.RuneCountInString(line) <= maxRunes {
+			return line
+		}
+
+		runes := []rune(line)[:maxRunes]
+		truncated := string(runes)
+		if lastSpace := strings.LastIndex(truncated, " "); lastSpace > 0 {
+			truncated = truncated[:lastSpace]
+		}
+		return strings.TrimRight(truncated, " ,.;:-–—") + "…"
+	}
+
+	return ""
+}
@@ -0,0 +1,330 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/zalhonan/remotejobs-site/internal/db/repository"
+	"github.com/zalhonan/remotejobs-site/internal/domain/entity"
+	"github.com/zalhonan/remotejobs-site/internal/domain/extract"
+	"go.uber.org/zap"
+)
+
+// DefaultClassifyBatchSize количество вакансий, классифицируемых за один запрос к базе
+const DefaultClassifyBatchSize = 500
+
+// ClassifyStats итоги классификации вакансий по технологиям
+type ClassifyStats struct {
+	Processed  int // Обработано вакансий
+	Classified int // Вакансий, для которых определена основная технология
+}
+
+// TechnologyChange смена основной технологии вакансии при повторной классификации
+type TechnologyChange struct {
+	JobID int64
+	Slug  string
+	Title string
+	From  string // Прежняя технология, пустая строка - не была определена
+	To    string // Новая технология, пустая строка - не определена
+}
+
+// ReclassifyReport итоги повторной классификации вакансий
+type ReclassifyReport struct {
+	Processed int                // Обработано вакансий
+	Changes   []TechnologyChange // Вакансии, у которых сменилась основная технология, по возрастанию ID
+	Applied   bool               // Изменения сохранены (false при пробном запуске или ошибке)
+}
+
+// Moves возвращает количество вакансий, перешедших между технологиями, по парам "прежняя -> новая"
+func (r ReclassifyReport) Moves() map[[2]string]int {
+	moves := make(map[[2]string]int)
+	for _, change := range r.Changes {
+		moves[[2]string{change.From, change.To}]++
+	}
+	return moves
+}
+
+// TechnologyPreview итоги пробной классификации недавних вакансий с измененными ключевыми словами технологии
+type TechnologyPreview struct {
+	Processed int                // Проверено вакансий
+	Matched   int                // Вакансий, которые получат эту технологию
+	Gained    int                // Вакансий, которые перейдут к технологии от другой или без технологии
+	Lost      int                // Вакансий технологии, которые перейдут к другой или останутся без технологии
+	Changes   []TechnologyChange // Примеры переходов, не больше maxPreviewChanges
+	Truncated bool               // Вакансий за период больше maxPreviewJobs, проверены только самые новые
+}
+
+const (
+	// maxPreviewJobs сколько самых новых вакансий проверяется при пробной классификации
+	maxPreviewJobs = 2000
+
+	// maxPreviewChanges сколько примеров переходов показывается при пробной классификации
+	maxPreviewChanges = 30
+)
+
+type ClassifierService struct {
+	jobRepo  *repository.JobRepository
+	techRepo *repository.TechnologyRepository
+	logger   *zap.Logger
+}
+
+// NewClassifierService создает новый сервис определения основной технологии вакансий
+func NewClassifierService(
+	jobRepo *repository.JobRepository,
+	techRepo *repository.TechnologyRepository,
+	logger *zap.Logger,
+) *ClassifierService {
+	return &ClassifierService{
+		jobRepo:  jobRepo,
+		techRepo: techRepo,
+		logger:   logger,
+	}
+}
+
+// LoadClassifier строит классификатор по текущему справочнику технологий.
+// Справочник читается один раз на пакет работы, чтобы не обращаться к базе для каждой вакансии
+func (s *ClassifierService) LoadClassifier(ctx context.Context) (*extract.TechnologyClassifier, error) {
+	technologies, err := s.techRepo.GetForClassification(ctx)
+	if err != nil {
+		s.logger.Error("Не удалось получить технологии для классификации", zap.Error(err))
+		return nil, err
+	}
+
+	return extract.NewTechnologyClassifier(technologies), nil
+}
+
+// Classify заполняет основную технологию вакансии и оценки классификатора
+func (s *ClassifierService) Classify(job *entity.JobRaw, classifier *extract.TechnologyClassifier) {
+	job.MainTechnology, job.TechnologyScores = classifier.Classify(job.Title, job.ContentPure)
+}
+
+// ClassifyMissing определяет основную технологию для вакансий, у которых она не заполнена, пачками по batchSize
+func (s *ClassifierService) ClassifyMissing(ctx context.Context, batchSize int) (ClassifyStats, error) {
+	if batchSize <= 0 {
+		batchSize = DefaultClassifyBatchSize
+	}
+
+	var stats ClassifyStats
+	var lastID int64
+
+	classifier, err := s.LoadClassifier(ctx)
+	if err != nil {
+		return stats, err
+	}
+
+	for {
+		jobs, err := s.jobRepo.GetClassifyBatchAfterID(ctx, entity.ClassifyFilter{Unclassified: true}, lastID, batchSize)
+		if err != nil {
+			s.logger.Error("Не удалось получить вакансии для классификации",
+				zap.Error(err),
+				zap.Int64("afterId", lastID),
+			)
+			return stats, err
+		}
+
+		if len(jobs) == 0 {
+			return stats, nil
+		}
+
+		for i := range jobs {
+			s.Classify(&jobs[i], classifier)
+			if jobs[i].MainTechnology != "" {
+				stats.Classified++
+			}
+		}
+
+		if err := s.jobRepo.UpdateClassification(ctx, jobs); err != nil {
+			s.logger.Error("Не удалось сохранить технологии вакансий",
+				zap.Error(err),
+				zap.Int64("afterId", lastID),
+			)
+			return stats, err
+		}
+
+		stats.Processed += len(jobs)
+		lastID = jobs[len(jobs)-1].ID
+
+		s.logger.Info("Пакет вакансий классифицирован",
+			zap.Int64("lastId", lastID),
+			zap.Int("processed", stats.Processed),
+			zap.Int("classified", stats.Classified),
+		)
+	}
+}
+
+// Reclassify заново определяет основную технологию для вакансий из выборки пачками по batchSize.
+// Все пачки сохраняются в одной транзакции: при ошибке изменения не применяются ни к одной вакансии.
+// При пробном запуске (dryRun) изменения только попадают в отчет
+func (s *ClassifierService) Reclassify(
+	ctx context.Context,
+	filter entity.ClassifyFilter,
+	batchSize int,
+	dryRun bool,
+) (ReclassifyReport, error) {
+	if batchSize <= 0 {
+		batchSize = DefaultClassifyBatchSize
+	}
+
+	classifier, err := s.LoadClassifier(ctx)
+	if err != nil {
+		return ReclassifyReport{}, err
+	}
+
+	var report ReclassifyReport
+	reclassify := func(jobRepo *repository.JobRepository) error {
+		report = ReclassifyReport{}
+		var lastID int64
+
+		for {
+			jobs, err := jobRepo.GetClassifyBatchAfterID(ctx, filter, lastID, batchSize)
+			if err != nil {
+				s.logger.Error("Не удалось получить вакансии для повторной классификации",
+					zap.Error(err),
+					zap.Int64("afterId", lastID),
+				)
+				return err
+			}
+
+			if len(jobs) == 0 {
+				return nil
+			}
+
+			for i := range jobs {
+				previous := jobs[i].MainTechnology
+				s.Classify(&jobs[i], classifier)
+				if jobs[i].MainTechnology != previous {
+					report.Changes = append(report.Changes, TechnologyChange{
+						JobID: jobs[i].ID,
+						Slug:  jobs[i].Slug,
+						Title: jobs[i].Title,
+						From:  previous,
+						To:    jobs[i].MainTechnology,
+					})
+				}
+			}
+
+			if !dryRun {
+				if err := jobRepo.UpdateClassification(ctx, jobs); err != nil {
+					s.logger.Error("Не удалось сохранить технологии вакансий",
+						zap.Error(err),
+						zap.Int64("afterId", lastID),
+					)
+					return err
+				}
+			}
+
+			report.Processed += len(jobs)
+			lastID = jobs[len(jobs)-1].ID
+
+			s.logger.Info("Пакет вакансий классифицирован повторно",
+				zap.Int64("lastId", lastID),
+				zap.Int("processed", report.Processed),
+				zap.Int("changed", len(report.Changes)),
+			)
+		}
+	}
+
+	if dryRun {
+		return report, reclassify(s.jobRepo)
+	}
+
+	if err := s.jobRepo.InTx(ctx, reclassify); err != nil {
+		return report, err
+	}
+
+	report.Applied = true
+	return report, nil
+}
+
+// PreviewTechnology классифицирует вакансии, опубликованные не раньше since, справочником, в котором
+// технология tech заменена измененной версией (новая технология - ID = 0), и сравнивает результат
+// с текущими основными технологиями. Вакансии проверяются от новых к старым, не больше maxPreviewJobs.
+// Ничего не сохраняет
+func (s *ClassifierService) PreviewTechnology(ctx context.Context, tech entity.Technology, since time.Time) (TechnologyPreview, error) {
+	technologies, err := s.techRepo.GetForClassification(ctx)
+	if err != nil {
+		s.logger.Error("Не удалось получить технологии для классификации", zap.Error(err))
+		return TechnologyPreview{}, err
+	}
+
+	// Имя технологии до изменения: вакансии с ним считаются вакансиями технологии
+	var oldName string
+	candidates := make([]entity.Technology, 0, len(technologies)+1)
+	for _, existing := range technologies {
+		if tech.ID != 0 && existing.ID == tech.ID {
+			oldName = existing.Technology
+			continue
+		}
+		candidates = append(candidates, existing)
+	}
+	if tech.ID != 0 && oldName == "" {
+		// У технологии сейчас нет ключевых слов, поэтому её нет среди технологий для классификации
+		current, err := s.techRepo.GetByID(ctx, tech.ID)
+		if err != nil {
+			s.logger.Error("Не удалось получить технологию по ID", zap.Error(err), zap.Int64("id", tech.ID))
+			return TechnologyPreview{}, err
+		}
+		oldName = current.Technology
+	}
+	candidates = append(candidates, tech)
+
+	classifier := extract.NewTechnologyClassifier(candidates)
+	filter := entity.ClassifyFilter{PostedFrom: since}
+
+	var preview TechnologyPreview
+	var beforeID int64
+	for !preview.Truncated {
+		// Лишняя вакансия сверх maxPreviewJobs показывает, что проверены не все вакансии за период
+		limit := min(DefaultClassifyBatchSize, maxPreviewJobs-preview.Processed+1)
+		jobs, err := s.jobRepo.GetClassifyBatchBeforeID(ctx, filter, beforeID, limit)
+		if err != nil {
+			s.logger.Error("Не удалось получить вакансии для пробной классификации", zap.Error(err))
+			return preview, err
+		}
+		if len(jobs) == 0 {
+			break
+		}
+		beforeID = jobs[len(jobs)-1].ID
+		if preview.Processed+len(jobs) > maxPreviewJobs {
+			preview.Truncated = true
+			jobs = jobs[:maxPreviewJobs-preview.Processed]
+		}
+
+		for _, job := range jobs {
+			to, _ := classifier.Classify(job.Title, job.ContentPure)
+
+			from := job.MainTechnology
+			if oldName != "" && from == oldName {
+				// Переименование не считается переходом
+				from = tech.Technology
+			}
+
+			if to == tech.Technology {
+				preview.Matched++
+			}
+
+			switch {
+			case to == tech.Technology && from != tech.Technology:
+				preview.Gained++
+			case from == tech.Technology && to != tech.Technology:
+				preview.Lost++
+			default:
+				continue
+			}
+
+			if len(preview.Changes) < maxPreviewChanges {
+				preview.Changes = append(preview.Changes, TechnologyChange{
+					JobID: job.ID,
+					Slug:  job.Slug,
+					Title: job.Title,
+					From:  job.MainTechnology,
+					To:    to,
+				})
+			}
+		}
+
+		preview.Processed += len(jobs)
+	}
+
+	return preview, nil
+}
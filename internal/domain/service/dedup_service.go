@@ -0,0 +1,135 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/zalhonan/remotejobs-site/internal/db/repository"
+	"github.com/zalhonan/remotejobs-site/internal/domain/dedup"
+	"github.com/zalhonan/remotejobs-site/internal/domain/entity"
+	"go.uber.org/zap"
+)
+
+const (
+	// DefaultDedupBatchSize количество вакансий, обрабатываемых за один запрос к базе
+	DefaultDedupBatchSize = 500
+
+	// duplicateWindow период до и после публикации вакансии, в котором ищутся её повторы
+	duplicateWindow = 14 * 24 * time.Hour
+
+	// maxDuplicateCandidates количество кандидатов в повторы, проверяемых для одной вакансии
+	maxDuplicateCandidates = 50
+)
+
+// DedupStats итоги поиска повторов вакансий
+type DedupStats struct {
+	Processed  int // Обработано вакансий
+	Duplicates int // Вакансий, оказавшихся повторами
+}
+
+type DedupService struct {
+	jobRepo *repository.JobRepository
+	logger  *zap.Logger
+}
+
+// NewDedupService создает новый сервис поиска вакансий, опубликованных в нескольких источниках
+func NewDedupService(jobRepo *repository.JobRepository, logger *zap.Logger) *DedupService {
+	return &DedupService{
+		jobRepo: jobRepo,
+		logger:  logger,
+	}
+}
+
+// Deduplicate считает SimHash вакансии и, если среди более ранних вакансий есть почти такая же,
+// связывает вакансию с канонической вакансией их группы. Каноническая вакансия группы - самая ранняя
+func (s *DedupService) Deduplicate(ctx context.Context, job *entity.JobRaw) error {
+	job.SimHash, job.CanonicalID = 0, 0
+
+	hash, ok := dedup.SimHash(job.ContentPure)
+	if !ok {
+		return nil
+	}
+	job.SimHash = int64(hash)
+
+	candidates, err := s.jobRepo.FindNearDuplicateCandidates(ctx,
+		job.SimHash,
+		job.ID,
+		job.DatePosted.Add(-duplicateWindow),
+		job.DatePosted.Add(duplicateWindow),
+		maxDuplicateCandidates,
+	)
+	if err != nil {
+		s.logger.Error("Не удалось найти повторы вакансии",
+			zap.Error(err),
+			zap.Int64("id", job.ID),
+			zap.String("sourceLink", job.SourceLink),
+		)
+		return err
+	}
+
+	for _, candidate := range candidates {
+		if !dedup.IsNearDuplicate(hash, uint64(candidate.SimHash)) {
+			continue
+		}
+		if rootID := candidate.RootID(); job.CanonicalID == 0 || rootID < job.CanonicalID {
+			job.CanonicalID = rootID
+		}
+	}
+
+	return nil
+}
+
+// Backfill ищет повторы для всех вакансий пачками по batchSize. Вакансии обрабатываются по возрастанию ID,
+// и каждая сравнивается только с более ранними, поэтому канонической остается первая публикация
+func (s *DedupService) Backfill(ctx context.Context, batchSize int) (DedupStats, error) {
+	if batchSize <= 0 {
+		batchSize = DefaultDedupBatchSize
+	}
+
+	var stats DedupStats
+	var lastID int64
+
+	for {
+		jobs, err := s.jobRepo.GetBatchAfterID(ctx, lastID, batchSize)
+		if err != nil {
+			s.logger.Error("Не удалось получить вакансии для поиска повторов",
+				zap.Error(err),
+				zap.Int64("afterId", lastID),
+			)
+			return stats, err
+		}
+
+		if len(jobs) == 0 {
+			return stats, nil
+		}
+
+		// Вакансии сохраняются по одной: следующая вакансия пачки сравнивается с уже обработанными
+		for i := range jobs {
+			job := &jobs[i]
+			if err := s.Deduplicate(ctx, job); err != nil {
+				return stats, err
+			}
+
+			if err := s.jobRepo.UpdateDeduplication(ctx, job.ID, job.SimHash, job.CanonicalID); err != nil {
+				s.logger.Error("Не удалось сохранить повторы вакансии",
+					zap.Error(err),
+					zap.Int64("id", job.ID),
+				)
+				return stats, err
+			}
+
+			if job.CanonicalID != 0 {
+				stats.Duplicates++
+			}
+		}
+
+		stats.Processed += len(jobs)
+		lastID = jobs[len(jobs)-1].ID
+
+		s.logger.Info("Пакет вакансий проверен на повторы",
+			zap.Int64("lastId", lastID),
+			zap.Int("processed", stats.Processed),
+			zap.Int("duplicates", stats.Duplicates),
+		)
+	}
+}
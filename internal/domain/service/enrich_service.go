@@ -0,0 +1,131 @@
+package service
+
+import (
+	"context"
+
+	"github.com/zalhonan/remotejobs-site/internal/db/repository"
+	"github.com/zalhonan/remotejobs-site/internal/domain/content"
+	"github.com/zalhonan/remotejobs-site/internal/domain/entity"
+	"github.com/zalhonan/remotejobs-site/internal/domain/extract"
+	"go.uber.org/zap"
+)
+
+// DefaultEnrichBatchSize количество вакансий, обрабатываемых за один запрос к базе
+const DefaultEnrichBatchSize = 500
+
+// EnrichRules настраиваемые правила извлечения данных из текста вакансий
+type EnrichRules struct {
+	Seniority []entity.SeniorityRule // Ключевые слова уровней позиции
+}
+
+// EnrichStats итоги пересчета данных, извлекаемых из текста вакансий
+type EnrichStats struct {
+	Processed     int // Обработано вакансий
+	WithSalary    int // Вакансий с найденной зарплатой
+	WithSeniority int // Вакансий с найденным уровнем позиции
+	WithLocation  int // Вакансий с найденными ограничениями по месту работы
+}
+
+type EnrichService struct {
+	jobRepo           *repository.JobRepository
+	seniorityRuleRepo *repository.SeniorityRuleRepository
+	logger            *zap.Logger
+}
+
+// NewEnrichService создает новый сервис для извлечения структурированных данных из текста вакансий
+func NewEnrichService(
+	jobRepo *repository.JobRepository,
+	seniorityRuleRepo *repository.SeniorityRuleRepository,
+	logger *zap.Logger,
+) *EnrichService {
+	return &EnrichService{
+		jobRepo:           jobRepo,
+		seniorityRuleRepo: seniorityRuleRepo,
+		logger:            logger,
+	}
+}
+
+// LoadRules загружает правила извлечения данных. Правила читаются один раз на пакет работы,
+// чтобы не обращаться к базе для каждой вакансии
+func (s *EnrichService) LoadRules(ctx context.Context) (EnrichRules, error) {
+	seniorityRules, err := s.seniorityRuleRepo.GetAll(ctx)
+	if err != nil {
+		s.logger.Error("Не удалось получить правила определения уровня позиции", zap.Error(err))
+		return EnrichRules{}, err
+	}
+
+	return EnrichRules{Seniority: seniorityRules}, nil
+}
+
+// Enrich заполняет поля вакансии, извлекаемые из её заголовка и текста,
+// и подготавливает текст к показу: очищенный HTML, превью и мета-описание
+func (s *EnrichService) Enrich(job *entity.JobRaw, rules EnrichRules) {
+	text := job.Title + "\n" + job.ContentPure
+
+	job.Salary, _ = extract.Salary(text)
+	job.Seniority = extract.Seniority(job.Title, job.ContentPure, rules.Seniority)
+	job.Location = extract.Location(job.Title, job.ContentPure)
+
+	job.ContentSanitized = content.Sanitize(job.Content)
+	job.ContentPreview = content.Preview(job.ContentPure, content.PreviewLines, content.PreviewMaxRunes)
+	job.MetaDescription = content.Description(job.ContentPure, content.DescriptionMaxRunes)
+}
+
+// Backfill пересчитывает извлекаемые данные для всех вакансий пачками по batchSize
+func (s *EnrichService) Backfill(ctx context.Context, batchSize int) (EnrichStats, error) {
+	if batchSize <= 0 {
+		batchSize = DefaultEnrichBatchSize
+	}
+
+	var stats EnrichStats
+	var lastID int64
+
+	rules, err := s.LoadRules(ctx)
+	if err != nil {
+		return stats, err
+	}
+
+	for {
+		jobs, err := s.jobRepo.GetBatchAfterID(ctx, lastID, batchSize)
+		if err != nil {
+			s.logger.Error("Не удалось получить вакансии для пересчета",
+				zap.Error(err),
+				zap.Int64("afterId", lastID),
+			)
+			return stats, err
+		}
+
+		if len(jobs) == 0 {
+			return stats, nil
+		}
+
+		for i := range jobs {
+			s.Enrich(&jobs[i], rules)
+			if !jobs[i].Salary.IsZero() {
+				stats.WithSalary++
+			}
+			if len(jobs[i].Seniority) > 0 {
+				stats.WithSeniority++
+			}
+			if len(jobs[i].Location.Constraints) > 0 {
+				stats.WithLocation++
+			}
+		}
+
+		if err := s.jobRepo.UpdateEnrichment(ctx, jobs); err != nil {
+			s.logger.Error("Не удалось сохранить пересчитанные данные вакансий",
+				zap.Error(err),
+				zap.Int64("afterId", lastID),
+			)
+			return stats, err
+		}
+
+		stats.Processed += len(jobs)
+		lastID = jobs[len(jobs)-1].ID
+
+		s.logger.Info("Пакет вакансий обработан",
+			zap.Int64("lastId", lastID),
+			zap.Int("processed", stats.Processed),
+		)
+	}
+}
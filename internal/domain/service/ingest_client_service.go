@@ -0,0 +1,121 @@
+package service
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/zalhonan/remotejobs-site/internal/db/repository"
+	"github.com/zalhonan/remotejobs-site/internal/domain/entity"
+	"go.uber.org/zap"
+)
+
+const (
+	// IngestSignaturePrefix префикс подписи запроса в заголовке
+	IngestSignaturePrefix = "sha256="
+
+	// IngestMaxClockSkew максимальное расхождение времени подписи запроса с временем сервера.
+	// Запросы со старой подписью отклоняются, чтобы перехваченный запрос нельзя было повторить позже
+	IngestMaxClockSkew = 5 * time.Minute
+)
+
+// ErrIngestUnauthorized запрос к API загрузки вакансий не прошел проверку подписи
+var ErrIngestUnauthorized = errors.New("запрос не прошел проверку подписи")
+
+// Причины отклонения запроса. Клиенту возвращается только ErrIngestUnauthorized, причина пишется в лог
+var (
+	errIngestMalformed      = errors.New("нет времени подписи или подпись без префикса " + IngestSignaturePrefix)
+	errIngestStale          = errors.New("время подписи отличается от времени сервера больше допустимого")
+	errIngestClientDisabled = errors.New("клиент выключен")
+	errIngestBadSignature   = errors.New("подпись не совпала")
+)
+
+type IngestClientService struct {
+	clientRepo *repository.IngestClientRepository
+	logger     *zap.Logger
+}
+
+// NewIngestClientService создает новый сервис проверки клиентов API загрузки вакансий
+func NewIngestClientService(clientRepo *repository.IngestClientRepository, logger *zap.Logger) *IngestClientService {
+	return &IngestClientService{
+		clientRepo: clientRepo,
+		logger:     logger,
+	}
+}
+
+// IngestSignature возвращает подпись запроса: HMAC-SHA256 строки "{timestamp}.{тело запроса}"
+// с секретом клиента в шестнадцатеричном виде с префиксом IngestSignaturePrefix
+func IngestSignature(secret, timestamp string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(timestamp))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return IngestSignaturePrefix + hex.EncodeToString(mac.Sum(nil))
+}
+
+// Authenticate проверяет подпись запроса клиента name. timestamp - время подписи в секундах Unix.
+// Возвращает ErrIngestUnauthorized, если клиент не найден, выключен или подпись не совпала
+func (s *IngestClientService) Authenticate(
+	ctx context.Context,
+	name, timestamp, signature string,
+	body []byte,
+	now time.Time,
+) (entity.IngestClient, error) {
+	if name == "" {
+		return entity.IngestClient{}, ErrIngestUnauthorized
+	}
+
+	client, err := s.clientRepo.GetByName(ctx, name)
+	if err != nil {
+		s.logger.Warn("Клиент API загрузки не найден", zap.Error(err), zap.String("client", name))
+		return entity.IngestClient{}, ErrIngestUnauthorized
+	}
+
+	if err := verifyIngestRequest(client, timestamp, signature, body, now); err != nil {
+		s.logger.Warn("Запрос к API загрузки отклонен", zap.Error(err), zap.String("client", name))
+		return entity.IngestClient{}, ErrIngestUnauthorized
+	}
+
+	return client, nil
+}
+
+// verifyIngestRequest проверяет, что клиент включен, время подписи отличается от now не больше
+// IngestMaxClockSkew и подпись совпадает с подписью тела запроса секретом клиента
+func verifyIngestRequest(client entity.IngestClient, timestamp, signature string, body []byte, now time.Time) error {
+	if !client.Enabled {
+		return errIngestClientDisabled
+	}
+	if timestamp == "" || !strings.HasPrefix(strings.ToLower(signature), IngestSignaturePrefix) {
+		return errIngestMalformed
+	}
+
+	seconds, err := strconv.ParseInt(timestamp, 10, 64)
+	if err != nil {
+		return errIngestMalformed
+	}
+	if skew := now.Sub(time.Unix(seconds, 0)); skew > IngestMaxClockSkew || skew < -IngestMaxClockSkew {
+		return errIngestStale
+	}
+
+	expected := IngestSignature(client.Secret, timestamp, body)
+	if !hmac.Equal([]byte(expected), []byte(strings.ToLower(signature))) {
+		return errIngestBadSignature
+	}
+
+	return nil
+}
+
+// RecordRequest сохраняет статистику запроса клиента. Ошибка только логируется: вакансии уже сохранены
+func (s *IngestClientService) RecordRequest(ctx context.Context, client entity.IngestClient, itemsReceived int) {
+	if err := s.clientRepo.RecordRequest(ctx, client.ID, itemsReceived); err != nil {
+		s.logger.Error("Не удалось сохранить статистику клиента API загрузки",
+			zap.Error(err),
+			zap.String("client", client.Name),
+		)
+	}
+}
@@ -0,0 +1,70 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/zalhonan/remotejobs-site/internal/domain/entity"
+	"go.uber.org/zap"
+)
+
+func TestVerifyIngestRequest(t *testing.T) {
+	now := time.Unix(1750000000, 0)
+	body := []byte(`{"items":[{"source_link":"https://board.example/jobs/1","text":"Go developer"}]}`)
+	client := entity.IngestClient{Name: "boards", Secret: "secret", Enabled: true}
+
+	stamp := func(offset time.Duration) string {
+		return strconv.FormatInt(now.Add(offset).Unix(), 10)
+	}
+	sign := func(timestamp string) string {
+		return IngestSignature(client.Secret, timestamp, body)
+	}
+
+	tests := []struct {
+		name      string
+		client    entity.IngestClient
+		timestamp string
+		signature string
+		body      []byte
+		want      error
+	}{
+		{"верная подпись", client, stamp(0), sign(stamp(0)), body, nil},
+		{"подпись в верхнем регистре", client, stamp(0), strings.ToUpper(sign(stamp(0))), body, nil},
+		{"часы клиента отстают в пределах допуска", client, stamp(-4 * time.Minute), sign(stamp(-4 * time.Minute)), body, nil},
+		{"часы клиента спешат в пределах допуска", client, stamp(4 * time.Minute), sign(stamp(4 * time.Minute)), body, nil},
+		{"устаревшая подпись", client, stamp(-6 * time.Minute), sign(stamp(-6 * time.Minute)), body, errIngestStale},
+		{"подпись из будущего", client, stamp(6 * time.Minute), sign(stamp(6 * time.Minute)), body, errIngestStale},
+		{"нет времени подписи", client, "", sign(""), body, errIngestMalformed},
+		{"время подписи не число", client, "вчера", sign("вчера"), body, errIngestMalformed},
+		{"нет подписи", client, stamp(0), "", body, errIngestMalformed},
+		{"подпись без префикса", client, stamp(0), strings.TrimPrefix(sign(stamp(0)), IngestSignaturePrefix), body, errIngestMalformed},
+		{"другой алгоритм", client, stamp(0), "sha1=" + strings.TrimPrefix(sign(stamp(0)), IngestSignaturePrefix), body, errIngestMalformed},
+		{"клиент выключен", entity.IngestClient{Name: "boards", Secret: "secret"}, stamp(0), sign(stamp(0)), body, errIngestClientDisabled},
+		{"тело изменено", client, stamp(0), sign(stamp(0)), []byte(`{"items":[]}`), errIngestBadSignature},
+		{"пустое тело", client, stamp(0), sign(stamp(0)), nil, errIngestBadSignature},
+		{"подпись другим секретом", client, stamp(0), IngestSignature("other", stamp(0), body), body, errIngestBadSignature},
+		{"подпись с другим временем", client, stamp(0), sign(stamp(-time.Minute)), body, errIngestBadSignature},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := verifyIngestRequest(tt.client, tt.timestamp, tt.signature, tt.body, now)
+			if !errors.Is(err, tt.want) {
+				t.Errorf("получена ошибка %v, ожидалась %v", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestAuthenticateWithoutClientName(t *testing.T) {
+	service := NewIngestClientService(nil, zap.NewNop())
+
+	_, err := service.Authenticate(context.Background(), "", "1750000000", "sha256=00", nil, time.Unix(1750000000, 0))
+	if !errors.Is(err, ErrIngestUnauthorized) {
+		t.Errorf("получена ошибка %v, ожидалась %v", err, ErrIngestUnauthorized)
+	}
+}
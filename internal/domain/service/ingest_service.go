@@ -0,0 +1,462 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/zalhonan/remotejobs-site/internal/db/repository"
+	"github.com/zalhonan/remotejobs-site/internal/domain/entity"
+	"github.com/zalhonan/remotejobs-site/internal/domain/extract"
+	"github.com/zalhonan/remotejobs-site/internal/source"
+	"github.com/zalhonan/remotejobs-site/internal/source/telegram"
+	"github.com/zalhonan/remotejobs-site/internal/util/slug"
+	"go.uber.org/zap"
+)
+
+const (
+	// DefaultIngestMaxPages количество страниц постов, загружаемых из канала за один проход
+	DefaultIngestMaxPages = 10
+
+	// maxTitleLength максимальная длина заголовка, формируемого из текста поста
+	maxTitleLength = 120
+
+	// importLogInterval количество импортированных постов между сообщениями о ходе импорта
+	importLogInterval = 1000
+
+	// maxSourceErrorLength максимальная длина сохраняемой ошибки загрузки источника
+	maxSourceErrorLength = 1000
+)
+
+// IngestStats итоги загрузки публикаций из каналов Telegram и других источников
+type IngestStats struct {
+	Channels int // Обработано каналов Telegram
+	Sources  int // Обработано других источников
+	Posts    int // Загружено публикаций
+	Inserted int // Сохранено новых вакансий
+	Failed   int // Каналов и источников, обработка которых завершилась ошибкой
+}
+
+// add суммирует итоги двух загрузок
+func (s *IngestStats) add(other IngestStats) {
+	s.Channels += other.Channels
+	s.Sources += other.Sources
+	s.Posts += other.Posts
+	s.Inserted += other.Inserted
+	s.Failed += other.Failed
+}
+
+type IngestService struct {
+	jobRepo           *repository.JobRepository
+	channelRepo       *repository.TelegramChannelRepository
+	sourceRepo        *repository.SourceRepository
+	enrichService     *EnrichService
+	classifierService *ClassifierService
+	dedupService      *DedupService
+	client            *telegram.Client
+	sources           *source.Registry
+	logger            *zap.Logger
+}
+
+// ingestRules правила обработки публикаций, загружаемые один раз на проход по каналам и источникам
+type ingestRules struct {
+	enrich     EnrichRules
+	classifier *extract.TechnologyClassifier
+}
+
+// NewIngestService создает новый сервис загрузки вакансий из каналов Telegram и источников,
+// адаптеры которых зарегистрированы в sources
+func NewIngestService(
+	jobRepo *repository.JobRepository,
+	channelRepo *repository.TelegramChannelRepository,
+	sourceRepo *repository.SourceRepository,
+	enrichService *EnrichService,
+	classifierService *ClassifierService,
+	dedupService *DedupService,
+	client *telegram.Client,
+	sources *source.Registry,
+	logger *zap.Logger,
+) *IngestService {
+	return &IngestService{
+		jobRepo:           jobRepo,
+		channelRepo:       channelRepo,
+		sourceRepo:        sourceRepo,
+		enrichService:     enrichService,
+		classifierService: classifierService,
+		dedupService:      dedupService,
+		client:            client,
+		sources:           sources,
+		logger:            logger,
+	}
+}
+
+// IngestAll загружает новые публикации из всех каналов Telegram, кроме приостановленных, и включенных источников.
+// Ошибка одного канала или источника не останавливает обработку остальных
+func (s *IngestService) IngestAll(ctx context.Context, maxPages int) (IngestStats, error) {
+	channels, err := s.channelRepo.GetActive(ctx)
+	if err != nil {
+		s.logger.Error("Не удалось получить список каналов Telegram", zap.Error(err))
+		return IngestStats{}, err
+	}
+
+	sources, err := s.sourceRepo.GetEnabled(ctx)
+	if err != nil {
+		s.logger.Error("Не удалось получить список источников", zap.Error(err))
+		return IngestStats{}, err
+	}
+
+	rules, err := s.loadRules(ctx)
+	if err != nil {
+		return IngestStats{}, err
+	}
+
+	stats, err := s.ingestChannels(ctx, channels, rules, maxPages)
+	if err != nil {
+		return stats, err
+	}
+
+	sourceStats, err := s.ingestSources(ctx, sources, rules)
+	stats.add(sourceStats)
+
+	return stats, err
+}
+
+// IngestTag загружает новые посты из одного канала, в том числе приостановленного
+func (s *IngestService) IngestTag(ctx context.Context, tag string, maxPages int) (IngestStats, error) {
+	channel, err := s.channelRepo.GetByTag(ctx, tag)
+	if err != nil {
+		s.logger.Error("Не удалось получить канал Telegram", zap.Error(err), zap.String("channel", tag))
+		return IngestStats{}, err
+	}
+
+	rules, err := s.loadRules(ctx)
+	if err != nil {
+		return IngestStats{}, err
+	}
+
+	return s.ingestChannels(ctx, []entity.TelegramChannel{channel}, rules, maxPages)
+}
+
+// IngestSource загружает новые публикации из одного источника, в том числе выключенного
+func (s *IngestService) IngestSource(ctx context.Context, name string) (IngestStats, error) {
+	src, err := s.sourceRepo.GetByName(ctx, name)
+	if err != nil {
+		s.logger.Error("Не удалось получить источник", zap.Error(err), zap.String("source", name))
+		return IngestStats{}, err
+	}
+
+	rules, err := s.loadRules(ctx)
+	if err != nil {
+		return IngestStats{}, err
+	}
+
+	return s.ingestSources(ctx, []entity.Source{src}, rules)
+}
+
+// ImportPosts сохраняет посты канала, загруженные не из веб-превью (например, из экспорта Telegram Desktop).
+// Уже сохраненные посты пропускаются по ссылке, поэтому импорт можно повторять
+func (s *IngestService) ImportPosts(ctx context.Context, posts []entity.TelegramPost) (IngestStats, error) {
+	rules, err := s.loadRules(ctx)
+	if err != nil {
+		return IngestStats{}, err
+	}
+
+	var stats IngestStats
+	for _, post := range posts {
+		if err := ctx.Err(); err != nil {
+			return stats, err
+		}
+
+		_, inserted, err := s.saveItem(ctx, telegramItem(post), rules)
+		if err != nil {
+			return stats, err
+		}
+
+		stats.Posts++
+		if inserted {
+			stats.Inserted++
+		}
+
+		if stats.Posts%importLogInterval == 0 {
+			s.logger.Info("Импорт постов продолжается",
+				zap.Int("posts", stats.Posts),
+				zap.Int("inserted", stats.Inserted),
+			)
+		}
+	}
+
+	return stats, nil
+}
+
+// IngestItemResult итог сохранения публикации, полученной от внешнего парсера
+type IngestItemResult struct {
+	Job      entity.JobRaw // Вакансия после обработки, ID заполнен, если она сохранена
+	Inserted bool          // Вакансия сохранена. false без ошибки - публикация с той же ссылкой уже есть или в ней нет текста
+	Err      error         // Ошибка сохранения
+}
+
+// IngestItems сохраняет публикации, полученные от внешних парсеров, так же, как посты каналов.
+// Ошибка сохранения одной публикации не мешает сохранить остальные
+func (s *IngestService) IngestItems(ctx context.Context, items []entity.SourceItem) ([]IngestItemResult, error) {
+	rules, err := s.loadRules(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	results := make([]IngestItemResult, 0, len(items))
+	for _, item := range items {
+		if err := ctx.Err(); err != nil {
+			return results, err
+		}
+
+		job, inserted, err := s.saveItem(ctx, item, rules)
+		if err != nil {
+			s.logger.Error("Не удалось сохранить вакансию внешнего парсера",
+				zap.Error(err),
+				zap.String("sourceLink", item.Link),
+			)
+		}
+		results = append(results, IngestItemResult{Job: job, Inserted: inserted, Err: err})
+	}
+
+	return results, nil
+}
+
+// loadRules загружает правила обработки публикаций
+func (s *IngestService) loadRules(ctx context.Context) (ingestRules, error) {
+	enrichRules, err := s.enrichService.LoadRules(ctx)
+	if err != nil {
+		return ingestRules{}, err
+	}
+
+	classifier, err := s.classifierService.LoadClassifier(ctx)
+	if err != nil {
+		return ingestRules{}, err
+	}
+
+	return ingestRules{enrich: enrichRules, classifier: classifier}, nil
+}
+
+// ingestChannels загружает новые посты из перечисленных каналов
+func (s *IngestService) ingestChannels(
+	ctx context.Context,
+	channels []entity.TelegramChannel,
+	rules ingestRules,
+	maxPages int,
+) (IngestStats, error) {
+	var stats IngestStats
+	for _, channel := range channels {
+		if err := ctx.Err(); err != nil {
+			return stats, err
+		}
+
+		posts, inserted, err := s.ingestChannel(ctx, channel, rules, maxPages)
+		stats.Channels++
+		stats.Posts += posts
+		stats.Inserted += inserted
+
+		if err != nil {
+			stats.Failed++
+			s.logger.Error("Ошибка при загрузке постов канала",
+				zap.Error(err),
+				zap.String("channel", channel.Tag),
+			)
+		}
+	}
+
+	return stats, nil
+}
+
+// ingestChannel загружает посты канала после last_post_id, не больше maxPages страниц.
+// Состояние канала сохраняется после каждой страницы, поэтому прерванная загрузка продолжается с того же места
+func (s *IngestService) ingestChannel(
+	ctx context.Context,
+	channel entity.TelegramChannel,
+	rules ingestRules,
+	maxPages int,
+) (int, int, error) {
+	if maxPages <= 0 {
+		maxPages = DefaultIngestMaxPages
+	}
+
+	lastPostID := channel.LastPostID
+	totalPosts, totalInserted := 0, 0
+
+	for page := 0; page < maxPages; page++ {
+		posts, err := s.client.FetchAfter(ctx, channel.Tag, lastPostID)
+		if err != nil {
+			return totalPosts, totalInserted, err
+		}
+
+		if len(posts) == 0 {
+			break
+		}
+
+		for _, post := range posts {
+			_, inserted, err := s.saveItem(ctx, telegramItem(post), rules)
+			if err != nil {
+				return totalPosts, totalInserted, err
+			}
+			if inserted {
+				totalInserted++
+			}
+		}
+
+		lastPostID = posts[len(posts)-1].ID
+		totalPosts += len(posts)
+
+		if err := s.channelRepo.UpdateProgress(ctx, channel.ID, lastPostID, len(posts)); err != nil {
+			return totalPosts, totalInserted, err
+		}
+	}
+
+	s.logger.Info("Канал обработан",
+		zap.String("channel", channel.Tag),
+		zap.Int64("lastPostId", lastPostID),
+		zap.Int("posts", totalPosts),
+		zap.Int("inserted", totalInserted),
+	)
+
+	return totalPosts, totalInserted, nil
+}
+
+// ingestSources загружает новые публикации из перечисленных источников
+func (s *IngestService) ingestSources(ctx context.Context, sources []entity.Source, rules ingestRules) (IngestStats, error) {
+	var stats IngestStats
+	for _, src := range sources {
+		if err := ctx.Err(); err != nil {
+			return stats, err
+		}
+
+		items, inserted, err := s.ingestSource(ctx, src, rules)
+		stats.Sources++
+		stats.Posts += items
+		stats.Inserted += inserted
+
+		if err != nil {
+			stats.Failed++
+			s.logger.Error("Ошибка при загрузке публикаций источника",
+				zap.Error(err),
+				zap.String("source", src.Name),
+			)
+
+			if ctx.Err() == nil {
+				if updateErr := s.sourceRepo.UpdateError(ctx, src.ID, truncateError(err, maxSourceErrorLength)); updateErr != nil {
+					s.logger.Error("Не удалось сохранить ошибку источника", zap.Error(updateErr), zap.String("source", src.Name))
+				}
+			}
+		}
+	}
+
+	return stats, nil
+}
+
+// ingestSource загружает публикации источника после сохраненного состояния адаптера.
+// Новое состояние сохраняется только после сохранения всех публикаций, поэтому прерванная загрузка повторяется целиком
+func (s *IngestService) ingestSource(ctx context.Context, src entity.Source, rules ingestRules) (int, int, error) {
+	adapter, err := s.sources.New(src)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	items, state, err := adapter.Fetch(ctx, src.State)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	totalInserted := 0
+	for _, item := range items {
+		_, inserted, err := s.saveItem(ctx, item, rules)
+		if err != nil {
+			return len(items), totalInserted, err
+		}
+		if inserted {
+			totalInserted++
+		}
+	}
+
+	if err := s.sourceRepo.UpdateProgress(ctx, src.ID, state, len(items)); err != nil {
+		return len(items), totalInserted, err
+	}
+
+	s.logger.Info("Источник обработан",
+		zap.String("source", src.Name),
+		zap.String("kind", src.Kind),
+		zap.String("state", state),
+		zap.Int("items", len(items)),
+		zap.Int("inserted", totalInserted),
+	)
+
+	return len(items), totalInserted, nil
+}
+
+// telegramItem представляет пост канала Telegram как публикацию источника. Заголовок формируется из текста
+func telegramItem(post entity.TelegramPost) entity.SourceItem {
+	return entity.SourceItem{
+		HTML:       post.HTML,
+		Text:       post.Text,
+		Link:       post.Link,
+		DatePosted: post.DatePosted,
+	}
+}
+
+// truncateError возвращает текст ошибки, обрезанный до maxRunes символов
+func truncateError(err error, maxRunes int) string {
+	message := []rune(err.Error())
+	if len(message) <= maxRunes {
+		return string(message)
+	}
+	return string(message[:maxRunes])
+}
+
+// saveItem сохраняет публикацию как вакансию и возвращает её с ID и слагом из базы, если она сохранена.
+// Публикации без текста (только медиа) пропускаются. Если у публикации нет заголовка, он формируется из текста
+func (s *IngestService) saveItem(ctx context.Context, item entity.SourceItem, rules ingestRules) (entity.JobRaw, bool, error) {
+	if strings.TrimSpace(item.Text) == "" {
+		return entity.JobRaw{}, false, nil
+	}
+
+	datePosted := item.DatePosted
+	if datePosted.IsZero() {
+		datePosted = time.Now()
+	}
+
+	title := item.Title
+	if strings.TrimSpace(title) == "" {
+		title = item.Text
+	}
+
+	job := entity.JobRaw{
+		Content:     item.HTML,
+		Title:       extract.Title(title, maxTitleLength),
+		ContentPure: item.Text,
+		SourceLink:  item.Link,
+		DatePosted:  datePosted,
+	}
+
+	s.enrichService.Enrich(&job, rules.enrich)
+	s.classifierService.Classify(&job, rules.classifier)
+
+	// Ошибка поиска повторов не мешает сохранить вакансию: она останется канонической
+	_ = s.dedupService.Deduplicate(ctx, &job)
+
+	// Слаг сохраняется вместе с ID новой вакансии, поэтому коллизия возможна только со старыми слагами
+	base := jobSlugBase(job)
+	for attempt := 1; attempt <= maxSlugAttempts; attempt++ {
+		job.Slug = slug.WithSuffix(base, attempt)
+
+		id, inserted, err := s.jobRepo.Create(ctx, job)
+		if errors.Is(err, repository.ErrSlugTaken) {
+			continue
+		}
+		if inserted {
+			job.ID = id
+			job.Slug = slug.WithID(id, job.Slug)
+		}
+		return job, inserted, err
+	}
+
+	return job, false, fmt.Errorf("не удалось подобрать свободный слаг для вакансии %s: %w", job.SourceLink, repository.ErrSlugTaken)
+}
@@ -0,0 +1,163 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v4/pgxpool"
+	"github.com/zalhonan/remotejobs-site/internal/db/repository"
+	"github.com/zalhonan/remotejobs-site/internal/source"
+	"github.com/zalhonan/remotejobs-site/internal/source/telegram"
+	"go.uber.org/zap"
+)
+
+// channelPages страницы веб-превью канала: после поста с ID из ключа Telegram показывает следующие посты
+var channelPages = map[int64][]int64{
+	0: {1, 2},
+	2: {3, 4},
+	4: {5},
+	5: {},
+}
+
+// newChannelServer запускает заглушку веб-превью t.me/s/<tag>, отдающую страницы channelPages,
+// и возвращает её и функцию, возвращающую значения параметра after полученных запросов
+func newChannelServer(t *testing.T, tag string) (*httptest.Server, func() []string) {
+	var (
+		mu     sync.Mutex
+		afters []string
+	)
+
+	var server *httptest.Server
+	server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		after := r.URL.Query().Get("after")
+		mu.Lock()
+		afters = append(afters, after)
+		mu.Unlock()
+
+		afterID, _ := strconv.ParseInt(after, 10, 64)
+		ids, ok := channelPages[afterID]
+		if r.URL.Path != "/s/"+tag || !ok {
+			http.NotFound(w, r)
+			return
+		}
+
+		var page strings.Builder
+		page.WriteString("<html><body>")
+		for _, id := range ids {
+			fmt.Fprintf(&page, `<div class="tgme_widget_message" data-post="%[1]s/%[2]d">
+				<div class="tgme_widget_message_text">Go developer, вакансия %[2]d канала %[1]s</div>
+				<a class="tgme_widget_message_date" href="%[3]s/%[1]s/%[2]d"><time datetime="2025-06-11T08:00:00+00:00"></time></a>
+			</div>`, tag, id, server.URL)
+		}
+		page.WriteString("</body></html>")
+		_, _ = w.Write([]byte(page.String()))
+	}))
+	t.Cleanup(server.Close)
+
+	return server, func() []string {
+		mu.Lock()
+		defer mu.Unlock()
+		return slices.Clone(afters)
+	}
+}
+
+// TestIngestChannelPaging проверяет на настоящей базе, что канал загружается постранично с последнего
+// сохраненного поста, а состояние канала сохраняется после каждой страницы.
+// Нужна база с примененными миграциями в TEST_DATABASE_URL, без неё тест пропускается
+func TestIngestChannelPaging(t *testing.T) {
+	dsn := os.Getenv("TEST_DATABASE_URL")
+	if dsn == "" {
+		t.Skip("TEST_DATABASE_URL не задан")
+	}
+
+	ctx := context.Background()
+	pool, err := pgxpool.Connect(ctx, dsn)
+	if err != nil {
+		t.Fatalf("не удалось подключиться к базе: %v", err)
+	}
+	defer pool.Close()
+
+	logger := zap.NewNop()
+	jobRepo := repository.NewJobRepository(pool, logger)
+	channelRepo := repository.NewTelegramChannelRepository(pool, logger)
+
+	tag := fmt.Sprintf("test%d", time.Now().UnixNano())
+	server, requests := newChannelServer(t, tag)
+
+	channelID, err := channelRepo.Create(ctx, tag)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() {
+		_, _ = pool.Exec(ctx, "DELETE FROM jobs_raw WHERE source_link LIKE $1", server.URL+"/%")
+		_, _ = channelRepo.Delete(ctx, channelID)
+	})
+
+	ingestService := NewIngestService(
+		jobRepo,
+		channelRepo,
+		repository.NewSourceRepository(pool, logger),
+		NewEnrichService(jobRepo, repository.NewSeniorityRuleRepository(pool, logger), logger),
+		NewClassifierService(jobRepo, repository.NewTechnologyRepository(pool, logger), logger),
+		NewDedupService(jobRepo, logger),
+		telegram.NewClient(server.URL, 0, logger),
+		source.NewRegistry(),
+		logger,
+	)
+
+	rules, err := ingestService.loadRules(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name         string
+		maxPages     int
+		wantAfters   []string
+		wantPosts    int
+		wantLastPost int64
+		wantParsed   int64
+	}{
+		{"загрузка прерывается по числу страниц", 2, []string{"", "2"}, 4, 4, 4},
+		{"следующий проход продолжает с сохраненного поста", 10, []string{"", "2", "4", "5"}, 1, 5, 5},
+		{"новых постов нет", 10, []string{"", "2", "4", "5", "5"}, 0, 5, 5},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			channel, err := channelRepo.GetByTag(ctx, tag)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			posts, inserted, err := ingestService.ingestChannel(ctx, channel, rules, tt.maxPages)
+			if err != nil {
+				t.Fatalf("ingestChannel: %v", err)
+			}
+			if posts != tt.wantPosts || inserted != tt.wantPosts {
+				t.Errorf("загружено %d постов, сохранено %d, ожидалось %d", posts, inserted, tt.wantPosts)
+			}
+			if got := requests(); !slices.Equal(got, tt.wantAfters) {
+				t.Errorf("параметры after запросов %q, ожидались %q", got, tt.wantAfters)
+			}
+
+			channel, err = channelRepo.GetByTag(ctx, tag)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if channel.LastPostID != tt.wantLastPost || channel.PostsParsed != tt.wantParsed {
+				t.Errorf("состояние канала: last_post_id=%d, posts_parsed=%d, ожидалось %d и %d",
+					channel.LastPostID, channel.PostsParsed, tt.wantLastPost, tt.wantParsed)
+			}
+		})
+	}
+}
@@ -0,0 +1,251 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/zalhonan/remotejobs-site/internal/db/repository"
+	"github.com/zalhonan/remotejobs-site/internal/domain/content"
+	"github.com/zalhonan/remotejobs-site/internal/domain/entity"
+	"go.uber.org/zap"
+)
+
+const (
+	// MaxJobTitleLength максимальная длина заголовка вакансии, задаваемого в админке
+	MaxJobTitleLength = 300
+
+	// JobAuditLogLimit количество записей журнала изменений, показываемых в админке
+	JobAuditLogLimit = 50
+)
+
+var (
+	// ErrInvalidJobEdit заголовок или текст вакансии пустые либо заголовок слишком длинный
+	ErrInvalidJobEdit = errors.New("заголовок и текст вакансии не должны быть пустыми, заголовок - не длиннее 300 символов")
+
+	// ErrNoJobChanges изменений нет: значения совпадают с текущими
+	ErrNoJobChanges = errors.New("изменений нет")
+)
+
+type JobAdminService struct {
+	jobRepo       *repository.JobRepository
+	techRepo      *repository.TechnologyRepository
+	enrichService *EnrichService
+	logger        *zap.Logger
+}
+
+// NewJobAdminService создает новый сервис изменения вакансий в админке
+func NewJobAdminService(
+	jobRepo *repository.JobRepository,
+	techRepo *repository.TechnologyRepository,
+	enrichService *EnrichService,
+	logger *zap.Logger,
+) *JobAdminService {
+	return &JobAdminService{
+		jobRepo:       jobRepo,
+		techRepo:      techRepo,
+		enrichService: enrichService,
+		logger:        logger,
+	}
+}
+
+// GetByID возвращает вакансию, в том числе скрытую или не показываемую на сайте, и её журнал изменений
+func (s *JobAdminService) GetByID(ctx context.Context, id int64) (entity.JobRaw, []entity.JobAuditEntry, error) {
+	job, err := s.jobRepo.GetByIDForAdmin(ctx, id)
+	if err != nil {
+		if !errors.Is(err, ErrJobNotFound) {
+			s.logger.Error("Не удалось получить вакансию", zap.Error(err), zap.Int64("jobId", id))
+		}
+		return entity.JobRaw{}, nil, err
+	}
+
+	entries, err := s.jobRepo.GetAuditLog(ctx, id, JobAuditLogLimit)
+	if err != nil {
+		s.logger.Error("Не удалось получить журнал изменений вакансии", zap.Error(err), zap.Int64("jobId", id))
+		return entity.JobRaw{}, nil, err
+	}
+
+	return job, entries, nil
+}
+
+// GetAuditLog возвращает последние записи журнала изменений всех вакансий
+func (s *JobAdminService) GetAuditLog(ctx context.Context) ([]entity.JobAuditEntry, error) {
+	entries, err := s.jobRepo.GetAuditLog(ctx, 0, JobAuditLogLimit)
+	if err != nil {
+		s.logger.Error("Не удалось получить журнал изменений вакансий", zap.Error(err))
+		return nil, err
+	}
+
+	return entries, nil
+}
+
+// Update сохраняет заголовок, основную технологию и HTML текста вакансии. Текст без разметки, зарплата,
+// уровень, ограничения по месту работы и превью пересчитываются из нового текста. Изменение записывается
+// в журнал от имени actor в той же транзакции. Возвращает ErrNoJobChanges, если значения не изменились
+func (s *JobAdminService) Update(ctx context.Context, id int64, edit entity.JobEdit, actor, comment string) error {
+	edit.Title = strings.TrimSpace(edit.Title)
+	edit.MainTechnology = strings.TrimSpace(edit.MainTechnology)
+	// Браузер отправляет переводы строк из textarea как \r\n
+	edit.Content = strings.TrimSpace(strings.ReplaceAll(edit.Content, "\r\n", "\n"))
+
+	contentPure := content.PlainText(edit.Content)
+	if edit.Title == "" || contentPure == "" || utf8.RuneCountInString(edit.Title) > MaxJobTitleLength {
+		return ErrInvalidJobEdit
+	}
+
+	if edit.MainTechnology != "" {
+		exists, err := s.techRepo.Exists(ctx, edit.MainTechnology)
+		if err != nil {
+			s.logger.Error("Не удалось проверить технологию", zap.Error(err), zap.String("technology", edit.MainTechnology))
+			return err
+		}
+		if !exists {
+			return ErrUnknownTechnology
+		}
+	}
+
+	rules, err := s.enrichService.LoadRules(ctx)
+	if err != nil {
+		return err
+	}
+
+	err = s.jobRepo.InTx(ctx, func(repo *repository.JobRepository) error {
+		job, err := repo.GetByIDForAdmin(ctx, id)
+		if err != nil {
+			return err
+		}
+
+		changes := make([]entity.JobFieldChange, 0, 3)
+		changes = appendChange(changes, entity.JobFieldTitle, job.Title, edit.Title)
+		changes = appendChange(changes, entity.JobFieldMainTechnology, job.MainTechnology, edit.MainTechnology)
+		changes = appendChange(changes, entity.JobFieldContent, strings.TrimSpace(job.Content), edit.Content)
+		if len(changes) == 0 {
+			return ErrNoJobChanges
+		}
+
+		job.Title = edit.Title
+		job.MainTechnology = edit.MainTechnology
+		job.Content = edit.Content
+		job.ContentPure = contentPure
+		s.enrichService.Enrich(&job, rules)
+
+		if err := repo.UpdateByAdmin(ctx, job); err != nil {
+			return err
+		}
+
+		return repo.AddAuditEntry(ctx, entity.JobAuditEntry{
+			JobID:   id,
+			Action:  entity.JobAuditUpdate,
+			Actor:   actor,
+			Comment: comment,
+			Changes: changes,
+		})
+	})
+	if err != nil {
+		if !errors.Is(err, ErrJobNotFound) && !errors.Is(err, ErrNoJobChanges) {
+			s.logger.Error("Не удалось сохранить вакансию", zap.Error(err), zap.Int64("jobId", id))
+		}
+		return err
+	}
+
+	s.logger.Info("Вакансия изменена в админке", zap.Int64("jobId", id), zap.String("actor", actor))
+	return nil
+}
+
+// SetHidden скрывает вакансию с сайта или снова показывает её, не удаляя из базы.
+// Изменение записывается в журнал от имени actor. Возвращает ErrNoJobChanges, если видимость уже такая
+func (s *JobAdminService) SetHidden(ctx context.Context, id int64, hidden bool, actor, comment string) error {
+	err := s.jobRepo.InTx(ctx, func(repo *repository.JobRepository) error {
+		job, err := repo.GetByIDForAdmin(ctx, id)
+		if err != nil {
+			return err
+		}
+		if job.Hidden == hidden {
+			return ErrNoJobChanges
+		}
+
+		if err := repo.SetHidden(ctx, id, hidden); err != nil {
+			return err
+		}
+
+		action := entity.JobAuditUnhide
+		if hidden {
+			action = entity.JobAuditHide
+		}
+		return repo.AddAuditEntry(ctx, entity.JobAuditEntry{
+			JobID:   id,
+			Action:  action,
+			Actor:   actor,
+			Comment: comment,
+			Changes: []entity.JobFieldChange{{
+				Field:  entity.JobFieldHidden,
+				Before: strconv.FormatBool(job.Hidden),
+				After:  strconv.FormatBool(hidden),
+			}},
+		})
+	})
+	if err != nil {
+		if !errors.Is(err, ErrJobNotFound) && !errors.Is(err, ErrNoJobChanges) {
+			s.logger.Error("Не удалось изменить видимость вакансии", zap.Error(err), zap.Int64("jobId", id))
+		}
+		return err
+	}
+
+	s.logger.Info("Изменена видимость вакансии в админке",
+		zap.Int64("jobId", id),
+		zap.Bool("hidden", hidden),
+		zap.String("actor", actor),
+	)
+	return nil
+}
+
+// Delete удаляет вакансию с сайта: она переводится в статус removed и её страница отвечает 410. Строка
+// остается в базе, поэтому повторы вакансии не становятся каноническими, а пост не загружается снова
+// из канала, источника или через API. Изменение записывается в журнал от имени actor.
+// Возвращает ErrNoJobChanges, если вакансия уже удалена
+func (s *JobAdminService) Delete(ctx context.Context, id int64, actor, comment string) error {
+	err := s.jobRepo.InTx(ctx, func(repo *repository.JobRepository) error {
+		job, err := repo.GetByIDForAdmin(ctx, id)
+		if err != nil {
+			return err
+		}
+		if job.Status == entity.JobStatusRemoved {
+			return ErrNoJobChanges
+		}
+
+		if err := repo.Remove(ctx, id); err != nil {
+			return err
+		}
+
+		return repo.AddAuditEntry(ctx, entity.JobAuditEntry{
+			JobID:   id,
+			Action:  entity.JobAuditDelete,
+			Actor:   actor,
+			Comment: comment,
+			Changes: []entity.JobFieldChange{{
+				Field:  entity.JobFieldStatus,
+				Before: job.Status,
+				After:  entity.JobStatusRemoved,
+			}},
+		})
+	})
+	if err != nil {
+		if !errors.Is(err, ErrJobNotFound) && !errors.Is(err, ErrNoJobChanges) {
+			s.logger.Error("Не удалось удалить вакансию", zap.Error(err), zap.Int64("jobId", id))
+		}
+		return err
+	}
+
+	s.logger.Info("Вакансия удалена в админке", zap.Int64("jobId", id), zap.String("actor", actor))
+	return nil
+}
+
+// appendChange добавляет изменение поля, если значение изменилось
+func appendChange(changes []entity.JobFieldChange, field, before, after string) []entity.JobFieldChange {
+	if before == after {
+		return changes
+	}
+	return append(changes, entity.JobFieldChange{Field: field, Before: before, After: after})
+}
@@ -2,6 +2,8 @@ package service
 
 import (
 	"context"
+	"strings"
+	"time"
 
 	"github.com/zalhonan/remotejobs-site/internal/db/repository"
 	"github.com/zalhonan/remotejobs-site/internal/domain/entity"
@@ -10,36 +12,98 @@ import (
 
 const (
 	DefaultPageSize = 10
+
+	// MaxNumberedPages количество страниц с номерами, доступных в списках.
+	// Более старые вакансии доступны через курсорную навигацию "новее/старее"
+	MaxNumberedPages = 50
+
+	// maxNumberedCount количество вакансий, достаточное для подсчета страниц с номерами
+	maxNumberedCount = MaxNumberedPages * DefaultPageSize
+
+	// MaxSearchQueryLength максимальная длина поискового запроса в символах
+	MaxSearchQueryLength = 200
+
+	// RelatedJobsLimit количество похожих вакансий на странице вакансии
+	RelatedJobsLimit = 5
+
+	// relatedJobsPeriod период, за который подбираются похожие вакансии
+	relatedJobsPeriod = 60 * 24 * time.Hour
 )
 
 type JobService struct {
-	jobRepo  *repository.JobRepository
-	techRepo *repository.TechnologyRepository
-	logger   *zap.Logger
+	jobRepo      *repository.JobRepository
+	techRepo     *repository.TechnologyRepository
+	stopWordRepo *repository.StopWordRepository
+	logger       *zap.Logger
 }
 
 // NewJobService создает новый сервис для работы с вакансиями
 func NewJobService(
 	jobRepo *repository.JobRepository,
 	techRepo *repository.TechnologyRepository,
+	stopWordRepo *repository.StopWordRepository,
 	logger *zap.Logger,
 ) *JobService {
 	return &JobService{
-		jobRepo:  jobRepo,
-		techRepo: techRepo,
-		logger:   logger,
+		jobRepo:      jobRepo,
+		techRepo:     techRepo,
+		stopWordRepo: stopWordRepo,
+		logger:       logger,
+	}
+}
+
+// getStopWords возвращает слова из справочника стоп-слов
+func (s *JobService) getStopWords(ctx context.Context) ([]string, error) {
+	stopWords, err := s.stopWordRepo.GetAll(ctx)
+	if err != nil {
+		s.logger.Error("Не удалось получить список стоп-слов", zap.Error(err))
+		return nil, err
+	}
+
+	words := make([]string, 0, len(stopWords))
+	for _, stopWord := range stopWords {
+		words = append(words, stopWord.Word)
+	}
+
+	return words, nil
+}
+
+// applyStopWords подставляет стоп-слова в фильтр списка вакансий.
+// Возвращает слова, чтобы пометить ими вакансии, если скрытые вакансии показываются
+func (s *JobService) applyStopWords(ctx context.Context, filter *entity.JobFilter) ([]string, error) {
+	words, err := s.getStopWords(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if !filter.ShowHidden {
+		filter.StopWords = words
+	}
+
+	return words, nil
+}
+
+// markHiddenJobs помечает стоп-словами вакансии, показанные вместе со скрытыми
+func markHiddenJobs(jobs []entity.JobRaw, filter entity.JobFilter, stopWords []string) {
+	if filter.ShowHidden {
+		markStopWords(jobs, stopWords)
 	}
 }
 
 // GetLatest возвращает последние вакансии с пагинацией
-func (s *JobService) GetLatest(ctx context.Context, page int) ([]entity.JobRaw, int, error) {
+func (s *JobService) GetLatest(ctx context.Context, filter entity.JobFilter, page int) ([]entity.JobRaw, int, error) {
 	if page < 1 {
 		page = 1
 	}
 
 	offset := (page - 1) * DefaultPageSize
 
-	jobs, err := s.jobRepo.GetLatest(ctx, DefaultPageSize, offset)
+	stopWords, err := s.applyStopWords(ctx, &filter)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	jobs, err := s.jobRepo.GetLatest(ctx, filter, DefaultPageSize, offset)
 	if err != nil {
 		s.logger.Error("Не удалось получить последние вакансии",
 			zap.Error(err),
@@ -50,8 +114,10 @@ func (s *JobService) GetLatest(ctx context.Context, page int) ([]entity.JobRaw,
 		return nil, 0, err
 	}
 
+	markHiddenJobs(jobs, filter, stopWords)
+
 	// Получаем общее количество страниц
-	totalCount, err := s.jobRepo.GetTotalCount(ctx)
+	totalCount, err := s.jobRepo.GetTotalCount(ctx, filter, maxNumberedCount)
 	if err != nil {
 		s.logger.Error("Не удалось получить общее количество вакансий", zap.Error(err))
 		return jobs, 0, nil
@@ -63,7 +129,7 @@ func (s *JobService) GetLatest(ctx context.Context, page int) ([]entity.JobRaw,
 }
 
 // GetByTechnology возвращает вакансии по конкретной технологии с пагинацией
-func (s *JobService) GetByTechnology(ctx context.Context, technology string, page int) ([]entity.JobRaw, int, error) {
+func (s *JobService) GetByTechnology(ctx context.Context, technology string, filter entity.JobFilter, page int) ([]entity.JobRaw, int, error) {
 	// Проверяем существование технологии
 	exists, err := s.techRepo.Exists(ctx, technology)
 	if err != nil {
@@ -85,7 +151,12 @@ func (s *JobService) GetByTechnology(ctx context.Context, technology string, pag
 
 	offset := (page - 1) * DefaultPageSize
 
-	jobs, err := s.jobRepo.GetByTechnology(ctx, technology, DefaultPageSize, offset)
+	stopWords, err := s.applyStopWords(ctx, &filter)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	jobs, err := s.jobRepo.GetByTechnology(ctx, technology, filter, DefaultPageSize, offset)
 	if err != nil {
 		s.logger.Error("Не удалось получить вакансии по технологии",
 			zap.Error(err),
@@ -97,8 +168,10 @@ func (s *JobService) GetByTechnology(ctx context.Context, technology string, pag
 		return nil, 0, err
 	}
 
+	markHiddenJobs(jobs, filter, stopWords)
+
 	// Получаем общее количество страниц для этой технологии
-	totalCount, err := s.jobRepo.GetTotalCountByTechnology(ctx, technology)
+	totalCount, err := s.jobRepo.GetTotalCountByTechnology(ctx, technology, filter, maxNumberedCount)
 	if err != nil {
 		s.logger.Error("Не удалось получить общее количество вакансий по технологии",
 			zap.Error(err),
@@ -112,6 +185,92 @@ func (s *JobService) GetByTechnology(ctx context.Context, technology string, pag
 	return jobs, totalPages, nil
 }
 
+// GetByCursor возвращает страницу вакансий при курсорной пагинации без подсчета общего количества.
+// Пустой список технологий означает все технологии, нулевой курсор - первую страницу
+func (s *JobService) GetByCursor(
+	ctx context.Context,
+	technologies []string,
+	filter entity.JobFilter,
+	cursor entity.JobCursor,
+	direction entity.CursorDirection,
+) (entity.JobCursorPage, error) {
+	stopWords, err := s.applyStopWords(ctx, &filter)
+	if err != nil {
+		return entity.JobCursorPage{}, err
+	}
+
+	// Запрашиваем на одну вакансию больше, чтобы узнать, есть ли следующая страница
+	jobs, err := s.jobRepo.GetByCursor(ctx, technologies, filter, cursor, direction, DefaultPageSize+1)
+	if err != nil {
+		s.logger.Error("Не удалось получить вакансии по курсору",
+			zap.Error(err),
+			zap.Strings("technologies", technologies),
+			zap.String("cursor", cursor.String()),
+		)
+		return entity.JobCursorPage{}, err
+	}
+
+	hasMore := len(jobs) > DefaultPageSize
+
+	// Если более новых вакансий меньше страницы, показываем начало списка целиком
+	if direction == entity.CursorNewer && !cursor.IsZero() && !hasMore {
+		return s.GetByCursor(ctx, technologies, filter, entity.JobCursor{}, entity.CursorOlder)
+	}
+
+	if hasMore {
+		if direction == entity.CursorNewer && !cursor.IsZero() {
+			// Лишняя вакансия при движении к новым - самая новая, она в начале списка
+			jobs = jobs[1:]
+		} else {
+			jobs = jobs[:DefaultPageSize]
+		}
+	}
+
+	markHiddenJobs(jobs, filter, stopWords)
+
+	page := entity.JobCursorPage{Jobs: jobs}
+	if len(jobs) == 0 {
+		return page, nil
+	}
+
+	newest := entity.NewJobCursor(jobs[0])
+	oldest := entity.NewJobCursor(jobs[len(jobs)-1])
+
+	switch {
+	case cursor.IsZero():
+		if hasMore {
+			page.Older = &oldest
+		}
+	case direction == entity.CursorNewer:
+		page.Older = &oldest
+		if hasMore {
+			page.Newer = &newest
+		}
+	default:
+		page.Newer = &newest
+		if hasMore {
+			page.Older = &oldest
+		}
+	}
+
+	return page, nil
+}
+
+// GetRedirect возвращает ID вакансии, на которую перенаправляется адрес удаленного повтора вакансии.
+// При ошибке перенаправления нет
+func (s *JobService) GetRedirect(ctx context.Context, oldID int64) (int64, bool) {
+	jobID, ok, err := s.jobRepo.GetRedirect(ctx, oldID)
+	if err != nil {
+		s.logger.Error("Не удалось получить перенаправление вакансии",
+			zap.Error(err),
+			zap.Int64("id", oldID),
+		)
+		return 0, false
+	}
+
+	return jobID, ok
+}
+
 // GetByID возвращает вакансию по её ID
 func (s *JobService) GetByID(ctx context.Context, id int64) (entity.JobRaw, error) {
 	job, err := s.jobRepo.GetByID(ctx, id)
@@ -123,5 +282,102 @@ func (s *JobService) GetByID(ctx context.Context, id int64) (entity.JobRaw, erro
 		return entity.JobRaw{}, err
 	}
 
+	// Детальная страница доступна всегда, стоп-слова показываются только как пометка.
+	// Ошибка справочника стоп-слов не мешает показать вакансию
+	if stopWords, err := s.getStopWords(ctx); err == nil {
+		job.StopWords = mergeWords(job.StopWords, matchStopWords(job, stopWords))
+	}
+
 	return job, nil
 }
+
+// GetRelated возвращает свежие вакансии, похожие на указанную, без скрытых по стоп-словам
+func (s *JobService) GetRelated(ctx context.Context, job entity.JobRaw) ([]entity.JobRaw, error) {
+	filter := entity.JobFilter{
+		PostedFrom: time.Now().Add(-relatedJobsPeriod),
+	}
+
+	if _, err := s.applyStopWords(ctx, &filter); err != nil {
+		return nil, err
+	}
+
+	jobs, err := s.jobRepo.GetRelated(ctx, job, filter, RelatedJobsLimit)
+	if err != nil {
+		s.logger.Error("Не удалось получить похожие вакансии",
+			zap.Error(err),
+			zap.Int64("id", job.ID),
+		)
+		return nil, err
+	}
+
+	return jobs, nil
+}
+
+// GetDuplicates возвращает публикации той же вакансии в других источниках
+func (s *JobService) GetDuplicates(ctx context.Context, job entity.JobRaw) ([]entity.JobRaw, error) {
+	jobs, err := s.jobRepo.GetDuplicates(ctx, job)
+	if err != nil {
+		s.logger.Error("Не удалось получить повторы вакансии",
+			zap.Error(err),
+			zap.Int64("id", job.ID),
+		)
+		return nil, err
+	}
+
+	return jobs, nil
+}
+
+// Search выполняет полнотекстовый поиск вакансий с пагинацией
+func (s *JobService) Search(ctx context.Context, query string, filter entity.JobFilter, page int) ([]entity.JobRaw, int, error) {
+	query = strings.TrimSpace(query)
+	if query == "" {
+		return []entity.JobRaw{}, 0, nil
+	}
+
+	// Ограничиваем длину запроса, не разрезая многобайтовые символы
+	if runes := []rune(query); len(runes) > MaxSearchQueryLength {
+		query = string(runes[:MaxSearchQueryLength])
+	}
+
+	if page < 1 {
+		page = 1
+	}
+
+	offset := (page - 1) * DefaultPageSize
+
+	// Вакансию ищут и после того, как она устарела, поэтому поиск показывает устаревшие вакансии с пометкой
+	filter.IncludeExpired = true
+
+	stopWords, err := s.applyStopWords(ctx, &filter)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	jobs, err := s.jobRepo.Search(ctx, query, filter, DefaultPageSize, offset)
+	if err != nil {
+		s.logger.Error("Не удалось выполнить поиск вакансий",
+			zap.Error(err),
+			zap.String("query", query),
+			zap.Int("page", page),
+			zap.Int("limit", DefaultPageSize),
+			zap.Int("offset", offset),
+		)
+		return nil, 0, err
+	}
+
+	markHiddenJobs(jobs, filter, stopWords)
+
+	// Получаем общее количество страниц для результатов поиска
+	totalCount, err := s.jobRepo.GetTotalCountBySearch(ctx, query, filter, maxNumberedCount)
+	if err != nil {
+		s.logger.Error("Не удалось получить количество найденных вакансий",
+			zap.Error(err),
+			zap.String("query", query),
+		)
+		return jobs, 0, nil
+	}
+
+	totalPages := (totalCount + DefaultPageSize - 1) / DefaultPageSize
+
+	return jobs, totalPages, nil
+}
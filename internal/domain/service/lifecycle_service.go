@@ -0,0 +1,76 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/zalhonan/remotejobs-site/internal/db/repository"
+	"github.com/zalhonan/remotejobs-site/internal/domain/entity"
+	"go.uber.org/zap"
+)
+
+const (
+	// DefaultStatusUpdateInterval интервал обновления статусов вакансий
+	DefaultStatusUpdateInterval = time.Hour
+)
+
+// DefaultJobLifecycle пороги жизненного цикла вакансий технологий, для которых пороги не заданы,
+// и вакансий без основной технологии
+var DefaultJobLifecycle = entity.JobLifecycle{
+	ExpireAfterDays:  30,
+	ArchiveAfterDays: 180,
+	RemoveAfterDays:  730,
+}
+
+type LifecycleService struct {
+	jobRepo *repository.JobRepository
+	logger  *zap.Logger
+}
+
+// NewLifecycleService создает новый сервис жизненного цикла вакансий
+func NewLifecycleService(jobRepo *repository.JobRepository, logger *zap.Logger) *LifecycleService {
+	return &LifecycleService{
+		jobRepo: jobRepo,
+		logger:  logger,
+	}
+}
+
+// UpdateStatuses переводит устаревшие вакансии в следующие статусы жизненного цикла
+func (s *LifecycleService) UpdateStatuses(ctx context.Context) error {
+	changed, err := s.jobRepo.UpdateStatuses(ctx, DefaultJobLifecycle, time.Now())
+	if err != nil {
+		s.logger.Error("Не удалось обновить статусы вакансий", zap.Error(err))
+		return err
+	}
+
+	if len(changed) > 0 {
+		s.logger.Info("Статусы вакансий обновлены",
+			zap.Int64(entity.JobStatusExpired, changed[entity.JobStatusExpired]),
+			zap.Int64(entity.JobStatusArchived, changed[entity.JobStatusArchived]),
+			zap.Int64(entity.JobStatusRemoved, changed[entity.JobStatusRemoved]),
+		)
+	}
+
+	return nil
+}
+
+// RunStatusUpdater обновляет статусы вакансий сразу и затем каждые interval, пока не отменен контекст.
+// Ошибка обновления не останавливает следующие попытки
+func (s *LifecycleService) RunStatusUpdater(ctx context.Context, interval time.Duration) {
+	if interval <= 0 {
+		interval = DefaultStatusUpdateInterval
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		_ = s.UpdateStatuses(ctx)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
@@ -0,0 +1,188 @@
+package service
+
+import (
+	"context"
+	"errors"
+
+	"github.com/zalhonan/remotejobs-site/internal/db/repository"
+	"github.com/zalhonan/remotejobs-site/internal/domain/entity"
+	"go.uber.org/zap"
+)
+
+const (
+	// ModerationPageSize количество вакансий на странице очереди модерации
+	ModerationPageSize = 20
+
+	// LowConfidenceScore оценка лучшей технологии, ниже которой классификация считается неуверенной.
+	// Одно совпадение ключевого слова в заголовке дает оценку 3, поэтому ниже - только упоминания в тексте
+	LowConfidenceScore = 3
+
+	// CloseScoreRatio отношение второй оценки к лучшей, начиная с которого классификация считается
+	// неуверенной: две технологии набрали почти одинаковые оценки
+	CloseScoreRatio = 0.8
+)
+
+var (
+	// ErrJobNotFound вакансия не найдена
+	ErrJobNotFound = repository.ErrJobNotFound
+
+	// ErrUnknownTechnology технологии нет в справочнике
+	ErrUnknownTechnology = errors.New("технологии нет в справочнике")
+)
+
+// ModerationQueue страница очереди модерации
+type ModerationQueue struct {
+	Jobs   []entity.JobRaw         // Вакансии страницы вместе с оценками технологий
+	Counts entity.ModerationCounts // Количество вакансий в очереди по причинам
+}
+
+type ModerationService struct {
+	jobRepo         *repository.JobRepository
+	techRepo        *repository.TechnologyRepository
+	stopWordService *StopWordService
+	logger          *zap.Logger
+}
+
+// NewModerationService создает новый сервис очереди модерации
+func NewModerationService(
+	jobRepo *repository.JobRepository,
+	techRepo *repository.TechnologyRepository,
+	stopWordService *StopWordService,
+	logger *zap.Logger,
+) *ModerationService {
+	return &ModerationService{
+		jobRepo:         jobRepo,
+		techRepo:        techRepo,
+		stopWordService: stopWordService,
+		logger:          logger,
+	}
+}
+
+// GetQueue возвращает страницу очереди модерации: непроверенные активные вакансии без технологии
+// (reason = entity.ModerationReasonUnclassified), с неуверенно определенной технологией
+// (entity.ModerationReasonLowConfidence) или любые из них (пустая причина)
+func (s *ModerationService) GetQueue(ctx context.Context, reason string, page int) (ModerationQueue, error) {
+	stopWords, err := s.stopWordService.Words(ctx)
+	if err != nil {
+		return ModerationQueue{}, err
+	}
+
+	filter := entity.ModerationFilter{
+		Reason:     reason,
+		LowScore:   LowConfidenceScore,
+		CloseRatio: CloseScoreRatio,
+		StopWords:  stopWords,
+	}
+
+	counts, err := s.jobRepo.CountModerationQueue(ctx, filter)
+	if err != nil {
+		s.logger.Error("Не удалось посчитать вакансии в очереди модерации", zap.Error(err))
+		return ModerationQueue{}, err
+	}
+
+	if page < 1 {
+		page = 1
+	}
+
+	jobs, err := s.jobRepo.GetModerationQueue(ctx, filter, ModerationPageSize, (page-1)*ModerationPageSize)
+	if err != nil {
+		s.logger.Error("Не удалось получить очередь модерации", zap.Error(err), zap.String("reason", reason))
+		return ModerationQueue{}, err
+	}
+
+	return ModerationQueue{Jobs: jobs, Counts: counts}, nil
+}
+
+// AssignTechnology назначает вакансии основную технологию и отмечает вакансию проверенной.
+// Повторная классификация эту технологию не меняет. Решение записывается в журнал изменений от имени actor.
+// Возвращает ErrNoJobChanges, если у проверенной вакансии уже эта технология
+func (s *ModerationService) AssignTechnology(ctx context.Context, jobID int64, technology, actor string) error {
+	exists, err := s.techRepo.Exists(ctx, technology)
+	if err != nil {
+		s.logger.Error("Не удалось проверить технологию", zap.Error(err), zap.String("technology", technology))
+		return err
+	}
+	if !exists {
+		return ErrUnknownTechnology
+	}
+
+	err = s.moderate(ctx, jobID, entity.JobAuditModerate, entity.ModerationApproved, technology, actor, "")
+	if err != nil {
+		if !errors.Is(err, ErrJobNotFound) && !errors.Is(err, ErrNoJobChanges) {
+			s.logger.Error("Не удалось назначить технологию вакансии", zap.Error(err), zap.Int64("jobId", jobID))
+		}
+		return err
+	}
+
+	s.logger.Info("Модератор назначил технологию вакансии",
+		zap.Int64("jobId", jobID),
+		zap.String("technology", technology),
+		zap.String("actor", actor),
+	)
+	return nil
+}
+
+// MarkNotVacancy отмечает пост как не вакансию: он больше не показывается на сайте и в очереди модерации.
+// Решение записывается в журнал изменений от имени actor. Возвращает ErrNoJobChanges, если пост уже отмечен
+func (s *ModerationService) MarkNotVacancy(ctx context.Context, jobID int64, actor string) error {
+	err := s.moderate(ctx, jobID, entity.JobAuditModerate, entity.ModerationNotVacancy, "", actor, "")
+	if err != nil {
+		if !errors.Is(err, ErrJobNotFound) && !errors.Is(err, ErrNoJobChanges) {
+			s.logger.Error("Не удалось отметить пост как не вакансию", zap.Error(err), zap.Int64("jobId", jobID))
+		}
+		return err
+	}
+
+	s.logger.Info("Модератор отметил пост как не вакансию", zap.Int64("jobId", jobID), zap.String("actor", actor))
+	return nil
+}
+
+// RestoreVacancy снимает отметку "не вакансия", поставленную по ошибке. Вакансия снова считается
+// непроверенной: с технологией она показывается на сайте, без неё - попадает в очередь модерации.
+// Изменение записывается в журнал от имени actor. Возвращает ErrNoJobChanges, если пост не отмечен
+func (s *ModerationService) RestoreVacancy(ctx context.Context, jobID int64, actor, comment string) error {
+	err := s.moderate(ctx, jobID, entity.JobAuditRestore, "", "", actor, comment)
+	if err != nil {
+		if !errors.Is(err, ErrJobNotFound) && !errors.Is(err, ErrNoJobChanges) {
+			s.logger.Error("Не удалось снять отметку \"не вакансия\"", zap.Error(err), zap.Int64("jobId", jobID))
+		}
+		return err
+	}
+
+	s.logger.Info("Снята отметка \"не вакансия\"", zap.Int64("jobId", jobID), zap.String("actor", actor))
+	return nil
+}
+
+// moderate сохраняет решение модератора и запись журнала изменений в одной транзакции.
+// Снять отметку (пустое решение) можно только с поста, отмеченного как не вакансия
+func (s *ModerationService) moderate(ctx context.Context, jobID int64, action, moderation, technology, actor, comment string) error {
+	return s.jobRepo.InTx(ctx, func(repo *repository.JobRepository) error {
+		job, err := repo.GetByIDForAdmin(ctx, jobID)
+		if err != nil {
+			return err
+		}
+		if moderation == "" && job.Moderation != entity.ModerationNotVacancy {
+			return ErrNoJobChanges
+		}
+
+		changes := appendChange(nil, entity.JobFieldModeration, job.Moderation, moderation)
+		if technology != "" {
+			changes = appendChange(changes, entity.JobFieldMainTechnology, job.MainTechnology, technology)
+		}
+		if len(changes) == 0 {
+			return ErrNoJobChanges
+		}
+
+		if err := repo.UpdateModeration(ctx, jobID, moderation, technology); err != nil {
+			return err
+		}
+
+		return repo.AddAuditEntry(ctx, entity.JobAuditEntry{
+			JobID:   jobID,
+			Action:  action,
+			Actor:   actor,
+			Comment: comment,
+			Changes: changes,
+		})
+	})
+}
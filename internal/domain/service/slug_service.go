@@ -0,0 +1,130 @@
+package service
+
+import (
+	"context"
+	"errors"
+
+	"github.com/zalhonan/remotejobs-site/internal/db/repository"
+	"github.com/zalhonan/remotejobs-site/internal/domain/entity"
+	"github.com/zalhonan/remotejobs-site/internal/domain/extract"
+	"github.com/zalhonan/remotejobs-site/internal/util/slug"
+	"go.uber.org/zap"
+)
+
+const (
+	// DefaultSlugBatchSize количество вакансий, обрабатываемых за один запрос к базе
+	DefaultSlugBatchSize = 500
+
+	// maxSlugAttempts количество вариантов слага с суффиксами, которые пробуются при коллизии
+	maxSlugAttempts = 20
+)
+
+// SlugStats итоги пересчета слагов вакансий
+type SlugStats struct {
+	Processed int // Обработано вакансий
+	Updated   int // Вакансий, у которых изменился слаг
+}
+
+type SlugService struct {
+	jobRepo *repository.JobRepository
+	logger  *zap.Logger
+}
+
+// NewSlugService создает новый сервис для формирования слагов вакансий
+func NewSlugService(jobRepo *repository.JobRepository, logger *zap.Logger) *SlugService {
+	return &SlugService{
+		jobRepo: jobRepo,
+		logger:  logger,
+	}
+}
+
+// jobSlugBase формирует слаг вакансии без ID из заголовка, а если он пустой - из первой строки текста
+func jobSlugBase(job entity.JobRaw) string {
+	title := job.Title
+	if title == "" {
+		title = extract.Title(job.ContentPure, maxTitleLength)
+	}
+
+	if base := slug.Make(title, slug.DefaultMaxLength); base != "" {
+		return base
+	}
+	return slug.Fallback
+}
+
+// Backfill формирует слаги вакансий пачками по batchSize. Если all равен false, меняются только
+// слаги, которые не имеют вида "{id}-слово-слово" (например, пустые или с кириллицей).
+// Страница вакансии находится по ID, поэтому старые ссылки продолжают работать
+func (s *SlugService) Backfill(ctx context.Context, batchSize int, all bool) (SlugStats, error) {
+	if batchSize <= 0 {
+		batchSize = DefaultSlugBatchSize
+	}
+
+	var stats SlugStats
+	var lastID int64
+
+	for {
+		jobs, err := s.jobRepo.GetBatchAfterID(ctx, lastID, batchSize)
+		if err != nil {
+			s.logger.Error("Не удалось получить вакансии для пересчета слагов",
+				zap.Error(err),
+				zap.Int64("afterId", lastID),
+			)
+			return stats, err
+		}
+
+		if len(jobs) == 0 {
+			return stats, nil
+		}
+
+		for _, job := range jobs {
+			if !all && slug.IsWellFormed(job.ID, job.Slug) {
+				continue
+			}
+
+			updated, err := s.updateSlug(ctx, job)
+			if err != nil {
+				s.logger.Error("Не удалось обновить слаг вакансии",
+					zap.Error(err),
+					zap.Int64("id", job.ID),
+				)
+				return stats, err
+			}
+			if updated {
+				stats.Updated++
+			}
+		}
+
+		stats.Processed += len(jobs)
+		lastID = jobs[len(jobs)-1].ID
+
+		s.logger.Info("Пакет слагов обработан",
+			zap.Int64("lastId", lastID),
+			zap.Int("processed", stats.Processed),
+			zap.Int("updated", stats.Updated),
+		)
+	}
+}
+
+// updateSlug сохраняет новый слаг вакансии. При коллизии пробуются варианты с суффиксами "-2", "-3" и т.д.
+// Возвращает false, если слаг не изменился
+func (s *SlugService) updateSlug(ctx context.Context, job entity.JobRaw) (bool, error) {
+	base := slug.WithID(job.ID, jobSlugBase(job))
+
+	for attempt := 1; attempt <= maxSlugAttempts; attempt++ {
+		candidate := slug.WithSuffix(base, attempt)
+		if candidate == job.Slug {
+			return false, nil
+		}
+
+		err := s.jobRepo.UpdateSlug(ctx, job.ID, candidate)
+		if errors.Is(err, repository.ErrSlugTaken) {
+			continue
+		}
+		if err != nil {
+			return false, err
+		}
+		return true, nil
+	}
+
+	return false, repository.ErrSlugTaken
+}
@@ -0,0 +1,239 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/zalhonan/remotejobs-site/internal/db/repository"
+	"github.com/zalhonan/remotejobs-site/internal/domain/entity"
+	"go.uber.org/zap"
+)
+
+const (
+	// MinStopWordLength минимальная длина стоп-слова в символах. Короткие слова
+	// (аббревиатуры, предлоги) встречаются почти в каждой вакансии и скрыли бы слишком много
+	MinStopWordLength = 3
+
+	// MaxStopWordLength максимальная длина стоп-слова (размер колонки stop_words.word)
+	MaxStopWordLength = 255
+
+	// MaxStopWordImport максимальное количество стоп-слов в одном импорте
+	MaxStopWordImport = 500
+)
+
+var (
+	// ErrInvalidStopWord стоп-слово слишком короткое или слишком длинное
+	ErrInvalidStopWord = errors.New("стоп-слово должно быть длиной от 3 до 255 символов")
+
+	// ErrStopWordNotFound стоп-слово не найдено
+	ErrStopWordNotFound = repository.ErrStopWordNotFound
+)
+
+// StopWordImport итоги импорта стоп-слов
+type StopWordImport struct {
+	Added    int64 // Добавлено новых слов
+	Existing int64 // Слов, которые уже были в справочнике
+	Marked   int64 // Вакансий, помеченных новыми словами в колонке stop_words
+}
+
+type StopWordService struct {
+	stopWordRepo *repository.StopWordRepository
+	jobRepo      *repository.JobRepository
+	logger       *zap.Logger
+}
+
+// NewStopWordService создает новый сервис справочника стоп-слов
+func NewStopWordService(
+	stopWordRepo *repository.StopWordRepository,
+	jobRepo *repository.JobRepository,
+	logger *zap.Logger,
+) *StopWordService {
+	return &StopWordService{
+		stopWordRepo: stopWordRepo,
+		jobRepo:      jobRepo,
+		logger:       logger,
+	}
+}
+
+// NormalizeStopWord приводит стоп-слово к виду, в котором оно хранится в справочнике:
+// нижний регистр, одиночные пробелы между словами. Возвращает ErrInvalidStopWord для слишком
+// короткого или длинного слова
+func NormalizeStopWord(word string) (string, error) {
+	word = strings.ToLower(strings.Join(strings.Fields(word), " "))
+
+	length := utf8.RuneCountInString(word)
+	if length < MinStopWordLength || length > MaxStopWordLength {
+		return "", ErrInvalidStopWord
+	}
+
+	return word, nil
+}
+
+// ParseStopWords разбирает стоп-слова, по одному на строке, и приводит их к виду справочника.
+// Пустые строки и повторы пропускаются. Возвращает слова и строки, не прошедшие проверку
+func ParseStopWords(text string) ([]string, []string) {
+	words := make([]string, 0)
+	invalid := make([]string, 0)
+	seen := make(map[string]struct{})
+	for _, line := range strings.Split(text, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" {
+			continue
+		}
+
+		word, err := NormalizeStopWord(line)
+		if err != nil {
+			invalid = append(invalid, line)
+			continue
+		}
+
+		if _, ok := seen[word]; ok {
+			continue
+		}
+		seen[word] = struct{}{}
+		words = append(words, word)
+	}
+
+	return words, invalid
+}
+
+// GetAll возвращает справочник стоп-слов по алфавиту
+func (s *StopWordService) GetAll(ctx context.Context) ([]entity.StopWord, error) {
+	stopWords, err := s.stopWordRepo.GetAll(ctx)
+	if err != nil {
+		s.logger.Error("Не удалось получить список стоп-слов", zap.Error(err))
+		return nil, err
+	}
+
+	return stopWords, nil
+}
+
+// Words возвращает слова из справочника стоп-слов
+func (s *StopWordService) Words(ctx context.Context) ([]string, error) {
+	stopWords, err := s.stopWordRepo.GetAll(ctx)
+	if err != nil {
+		s.logger.Error("Не удалось получить список стоп-слов", zap.Error(err))
+		return nil, err
+	}
+
+	words := make([]string, 0, len(stopWords))
+	for _, stopWord := range stopWords {
+		words = append(words, stopWord.Word)
+	}
+
+	return words, nil
+}
+
+// Add добавляет стоп-слово в справочник. Вакансии с этим словом сразу скрываются из списков.
+// Возвращает сохраненное слово и false, если оно уже было в справочнике
+func (s *StopWordService) Add(ctx context.Context, word string) (string, bool, error) {
+	word, err := NormalizeStopWord(word)
+	if err != nil {
+		return "", false, err
+	}
+
+	added, err := s.stopWordRepo.Add(ctx, word)
+	if err != nil {
+		s.logger.Error("Не удалось добавить стоп-слово", zap.Error(err), zap.String("word", word))
+		return "", false, err
+	}
+
+	if added {
+		s.logger.Info("Стоп-слово добавлено", zap.String("word", word))
+	}
+
+	return word, added, nil
+}
+
+// Preview считает, сколько вакансий затронут стоп-слова и сколько из них будут скрыты с сайта.
+// Слова, уже действующие в справочнике, не учитываются при подсчете скрываемых вакансий
+func (s *StopWordService) Preview(ctx context.Context, words []string) ([]entity.StopWordImpact, error) {
+	existing, err := s.Words(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	impacts, err := s.jobRepo.CountStopWordMatches(ctx, words, existing)
+	if err != nil {
+		s.logger.Error("Не удалось посчитать вакансии со стоп-словами", zap.Error(err))
+		return nil, err
+	}
+
+	return impacts, nil
+}
+
+// Import добавляет стоп-слова в справочник. Если apply, новые слова сразу записываются в колонку stop_words
+// вакансий, где они встречаются: вакансии остаются помеченными, даже если слово потом удалят из справочника
+func (s *StopWordService) Import(ctx context.Context, words []string, apply bool) (StopWordImport, error) {
+	added, err := s.stopWordRepo.AddMany(ctx, words)
+	if err != nil {
+		s.logger.Error("Не удалось импортировать стоп-слова", zap.Error(err), zap.Int("words", len(words)))
+		return StopWordImport{}, err
+	}
+
+	result := StopWordImport{Added: added, Existing: int64(len(words)) - added}
+	if apply {
+		for _, word := range words {
+			marked, err := s.jobRepo.MarkStopWord(ctx, word)
+			if err != nil {
+				s.logger.Error("Не удалось пометить вакансии стоп-словом", zap.Error(err), zap.String("word", word))
+				return result, err
+			}
+			result.Marked += marked
+		}
+	}
+
+	s.logger.Info("Стоп-слова импортированы",
+		zap.Int64("added", result.Added),
+		zap.Int64("existing", result.Existing),
+		zap.Int64("markedJobs", result.Marked),
+	)
+	return result, nil
+}
+
+// Apply записывает стоп-слово из справочника в колонку stop_words вакансий, где оно встречается.
+// Возвращает количество помеченных вакансий
+func (s *StopWordService) Apply(ctx context.Context, id int64) (int64, error) {
+	stopWord, err := s.stopWordRepo.GetByID(ctx, id)
+	if err != nil {
+		if !errors.Is(err, ErrStopWordNotFound) {
+			s.logger.Error("Не удалось получить стоп-слово", zap.Error(err), zap.Int64("id", id))
+		}
+		return 0, err
+	}
+
+	marked, err := s.jobRepo.MarkStopWord(ctx, stopWord.Word)
+	if err != nil {
+		s.logger.Error("Не удалось пометить вакансии стоп-словом", zap.Error(err), zap.String("word", stopWord.Word))
+		return 0, err
+	}
+
+	s.logger.Info("Вакансии помечены стоп-словом", zap.String("word", stopWord.Word), zap.Int64("markedJobs", marked))
+	return marked, nil
+}
+
+// Delete удаляет стоп-слово из справочника. Если unmark, слово убирается и из колонки stop_words вакансий,
+// иначе помеченные им вакансии остаются скрытыми. Возвращает количество вакансий, с которых снята пометка
+func (s *StopWordService) Delete(ctx context.Context, id int64, unmark bool) (int64, error) {
+	word, err := s.stopWordRepo.Delete(ctx, id)
+	if err != nil {
+		if !errors.Is(err, ErrStopWordNotFound) {
+			s.logger.Error("Не удалось удалить стоп-слово", zap.Error(err), zap.Int64("id", id))
+		}
+		return 0, err
+	}
+
+	var unmarked int64
+	if unmark {
+		unmarked, err = s.jobRepo.UnmarkStopWord(ctx, word)
+		if err != nil {
+			s.logger.Error("Не удалось убрать стоп-слово из вакансий", zap.Error(err), zap.String("word", word))
+			return 0, err
+		}
+	}
+
+	s.logger.Info("Стоп-слово удалено", zap.String("word", word), zap.Int64("unmarkedJobs", unmarked))
+	return unmarked, nil
+}
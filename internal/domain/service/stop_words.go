@@ -0,0 +1,45 @@
+package service
+
+import (
+	"github.com/zalhonan/remotejobs-site/internal/domain/entity"
+	"github.com/zalhonan/remotejobs-site/internal/domain/extract"
+)
+
+// matchStopWords возвращает стоп-слова, встречающиеся в заголовке или тексте вакансии отдельным словом.
+// Сравнение без учета регистра и по границам слов, как у ~* в запросах списка вакансий
+func matchStopWords(job entity.JobRaw, stopWords []string) []string {
+	text := job.Title + " " + job.ContentPure
+
+	matched := make([]string, 0)
+	for _, word := range stopWords {
+		if extract.ContainsWord(text, word) {
+			matched = append(matched, word)
+		}
+	}
+
+	return matched
+}
+
+// markStopWords дополняет стоп-слова вакансий совпадениями со справочником,
+// чтобы показать их в бейджах скрытых вакансий
+func markStopWords(jobs []entity.JobRaw, stopWords []string) {
+	for i := range jobs {
+		jobs[i].StopWords = mergeWords(jobs[i].StopWords, matchStopWords(jobs[i], stopWords))
+	}
+}
+
+// mergeWords объединяет списки слов без повторов, сохраняя порядок
+func mergeWords(lists ...[]string) []string {
+	seen := make(map[string]struct{})
+	merged := make([]string, 0)
+	for _, list := range lists {
+		for _, word := range list {
+			if _, ok := seen[word]; ok {
+				continue
+			}
+			seen[word] = struct{}{}
+			merged = append(merged, word)
+		}
+	}
+	return merged
+}
@@ -0,0 +1,35 @@
+package service
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/zalhonan/remotejobs-site/internal/domain/entity"
+)
+
+func TestMatchStopWords(t *testing.T) {
+	stopWords := []string{"java", "sr", "курс", "c++"}
+
+	tests := []struct {
+		name  string
+		title string
+		text  string
+		want  []string
+	}{
+		{"слово в заголовке", "Java developer", "", []string{"java"}},
+		{"слово в тексте без учета регистра", "Разработчик", "Приглашаем на КУРС по Go", []string{"курс"}},
+		{"часть другого слова", "JavaScript developer", "Ищем SRE, курсы не нужны", []string{}},
+		{"слово со знаками на конце", "C++ developer", "Опыт с C++20", []string{"c++"}},
+		{"несколько слов", "Sr Java", "", []string{"java", "sr"}},
+		{"нет стоп-слов", "Go developer", "Удаленная работа", []string{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := matchStopWords(entity.JobRaw{Title: tt.title, ContentPure: tt.text}, stopWords)
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("matchStopWords() = %v, ожидалось %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -2,22 +2,41 @@ package service
 
 import (
 	"context"
+	"errors"
+	"time"
 
 	"github.com/zalhonan/remotejobs-site/internal/db/repository"
 	"github.com/zalhonan/remotejobs-site/internal/domain/entity"
 	"go.uber.org/zap"
 )
 
+const (
+	// TechnologyRecentPeriod период, за который считается количество свежих вакансий технологии
+	TechnologyRecentPeriod = 30 * 24 * time.Hour
+
+	// DefaultCountRefreshInterval интервал пересчета количества вакансий по технологиям
+	DefaultCountRefreshInterval = 5 * time.Minute
+)
+
+// ErrTechnologyExists технология с таким именем уже есть
+var ErrTechnologyExists = repository.ErrTechnologyExists
+
 type TechnologyService struct {
-	techRepo *repository.TechnologyRepository
-	logger   *zap.Logger
+	techRepo     *repository.TechnologyRepository
+	stopWordRepo *repository.StopWordRepository
+	logger       *zap.Logger
 }
 
 // NewTechnologyService создает новый сервис для работы с технологиями
-func NewTechnologyService(techRepo *repository.TechnologyRepository, logger *zap.Logger) *TechnologyService {
+func NewTechnologyService(
+	techRepo *repository.TechnologyRepository,
+	stopWordRepo *repository.StopWordRepository,
+	logger *zap.Logger,
+) *TechnologyService {
 	return &TechnologyService{
-		techRepo: techRepo,
-		logger:   logger,
+		techRepo:     techRepo,
+		stopWordRepo: stopWordRepo,
+		logger:       logger,
 	}
 }
 
@@ -59,3 +78,131 @@ func (s *TechnologyService) Exists(ctx context.Context, name string) (bool, erro
 
 	return exists, nil
 }
+
+// FilterExisting оставляет в списке только существующие технологии без повторов
+func (s *TechnologyService) FilterExisting(ctx context.Context, names []string) ([]string, error) {
+	if len(names) == 0 {
+		return []string{}, nil
+	}
+
+	existing, err := s.techRepo.FilterExisting(ctx, names)
+	if err != nil {
+		s.logger.Error("Ошибка при проверке существования технологий",
+			zap.Error(err),
+			zap.Strings("names", names),
+		)
+		return nil, err
+	}
+
+	return existing, nil
+}
+
+// GetAllForAdmin возвращает все технологии справочника, включая технологии без вакансий
+func (s *TechnologyService) GetAllForAdmin(ctx context.Context) ([]entity.Technology, error) {
+	technologies, err := s.techRepo.GetAllForAdmin(ctx)
+	if err != nil {
+		s.logger.Error("Не удалось получить справочник технологий", zap.Error(err))
+		return nil, err
+	}
+
+	return technologies, nil
+}
+
+// GetByID возвращает технологию по её ID
+func (s *TechnologyService) GetByID(ctx context.Context, id int64) (entity.Technology, error) {
+	technology, err := s.techRepo.GetByID(ctx, id)
+	if err != nil {
+		s.logger.Error("Не удалось получить технологию по ID", zap.Error(err), zap.Int64("id", id))
+		return entity.Technology{}, err
+	}
+
+	return technology, nil
+}
+
+// Create добавляет технологию в справочник и возвращает её ID
+func (s *TechnologyService) Create(ctx context.Context, tech entity.Technology) (int64, error) {
+	id, err := s.techRepo.Create(ctx, tech)
+	if err != nil {
+		if !errors.Is(err, ErrTechnologyExists) {
+			s.logger.Error("Не удалось добавить технологию", zap.Error(err), zap.String("technology", tech.Technology))
+		}
+		return 0, err
+	}
+
+	s.logger.Info("Технология добавлена", zap.Int64("id", id), zap.String("technology", tech.Technology))
+	return id, nil
+}
+
+// Update сохраняет технологию. При переименовании вакансии технологии переходят на новое имя,
+// количество вакансий в меню пересчитывается сразу
+func (s *TechnologyService) Update(ctx context.Context, tech entity.Technology) error {
+	if err := s.techRepo.Update(ctx, tech); err != nil {
+		if !errors.Is(err, ErrTechnologyExists) {
+			s.logger.Error("Не удалось обновить технологию", zap.Error(err), zap.Int64("id", tech.ID))
+		}
+		return err
+	}
+
+	s.logger.Info("Технология обновлена", zap.Int64("id", tech.ID), zap.String("technology", tech.Technology))
+	_ = s.RefreshCounts(ctx)
+	return nil
+}
+
+// Delete удаляет технологию из справочника. Её вакансии остаются без основной технологии
+// до повторной классификации. Возвращает количество таких вакансий
+func (s *TechnologyService) Delete(ctx context.Context, id int64) (int64, error) {
+	unassigned, err := s.techRepo.Delete(ctx, id)
+	if err != nil {
+		s.logger.Error("Не удалось удалить технологию", zap.Error(err), zap.Int64("id", id))
+		return 0, err
+	}
+
+	s.logger.Info("Технология удалена", zap.Int64("id", id), zap.Int64("unassignedJobs", unassigned))
+	_ = s.RefreshCounts(ctx)
+	return unassigned, nil
+}
+
+// RefreshCounts пересчитывает количество показываемых вакансий по технологиям,
+// в том числе за последние TechnologyRecentPeriod
+func (s *TechnologyService) RefreshCounts(ctx context.Context) error {
+	stopWords, err := s.stopWordRepo.GetAll(ctx)
+	if err != nil {
+		s.logger.Error("Не удалось получить список стоп-слов", zap.Error(err))
+		return err
+	}
+
+	words := make([]string, 0, len(stopWords))
+	for _, stopWord := range stopWords {
+		words = append(words, stopWord.Word)
+	}
+
+	updated, err := s.techRepo.RecountJobs(ctx, words, time.Now().Add(-TechnologyRecentPeriod))
+	if err != nil {
+		s.logger.Error("Не удалось пересчитать количество вакансий по технологиям", zap.Error(err))
+		return err
+	}
+
+	s.logger.Debug("Количество вакансий по технологиям пересчитано", zap.Int64("technologies", updated))
+	return nil
+}
+
+// RunCountRefresher пересчитывает количество вакансий по технологиям сразу и затем каждые interval,
+// пока не отменен контекст. Ошибка пересчета не останавливает следующие попытки
+func (s *TechnologyService) RunCountRefresher(ctx context.Context, interval time.Duration) {
+	if interval <= 0 {
+		interval = DefaultCountRefreshInterval
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		_ = s.RefreshCounts(ctx)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
@@ -0,0 +1,151 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/zalhonan/remotejobs-site/internal/db/repository"
+	"github.com/zalhonan/remotejobs-site/internal/domain/entity"
+	"github.com/zalhonan/remotejobs-site/internal/source/telegram"
+	"go.uber.org/zap"
+)
+
+var (
+	// ErrInvalidChannelTag имя канала не подходит под правила имен Telegram
+	ErrInvalidChannelTag = errors.New("имя канала должно начинаться с латинской буквы и состоять из 5-32 латинских букв, цифр и _")
+
+	// ErrTelegramChannelExists канал с таким именем уже есть
+	ErrTelegramChannelExists = repository.ErrTelegramChannelExists
+
+	// ErrTelegramChannelNotFound канал не найден
+	ErrTelegramChannelNotFound = repository.ErrTelegramChannelNotFound
+)
+
+// ChannelStatsDays период в днях, за который считаются показатели вакансий каналов. Показатели считаются
+// при каждом открытии списка каналов, поэтому период ограничен, а старые вакансии не влияют на оценку канала
+const ChannelStatsDays = 90
+
+// channelTagPattern правила имен публичных каналов Telegram
+var channelTagPattern = regexp.MustCompile(`^[A-Za-z][A-Za-z0-9_]{4,31}$`)
+
+// channelLinkPrefixes префиксы ссылок на канал, которые убираются из введенного имени
+var channelLinkPrefixes = []string{"https://", "http://", "t.me/", "telegram.me/", "s/", "@"}
+
+type TelegramChannelService struct {
+	channelRepo     *repository.TelegramChannelRepository
+	stopWordService *StopWordService
+	logger          *zap.Logger
+}
+
+// NewTelegramChannelService создает новый сервис каналов Telegram
+func NewTelegramChannelService(
+	channelRepo *repository.TelegramChannelRepository,
+	stopWordService *StopWordService,
+	logger *zap.Logger,
+) *TelegramChannelService {
+	return &TelegramChannelService{
+		channelRepo:     channelRepo,
+		stopWordService: stopWordService,
+		logger:          logger,
+	}
+}
+
+// NormalizeChannelTag возвращает имя канала из имени, @имени или ссылки t.me/<tag>.
+// Возвращает ErrInvalidChannelTag, если имя не подходит под правила имен Telegram
+func NormalizeChannelTag(input string) (string, error) {
+	tag := strings.TrimSpace(input)
+	for _, prefix := range channelLinkPrefixes {
+		if len(tag) >= len(prefix) && strings.EqualFold(tag[:len(prefix)], prefix) {
+			tag = tag[len(prefix):]
+		}
+	}
+	tag = strings.TrimRight(tag, "/")
+
+	if !channelTagPattern.MatchString(tag) {
+		return "", ErrInvalidChannelTag
+	}
+
+	return tag, nil
+}
+
+// GetAllWithStats возвращает все каналы Telegram с показателями их вакансий за последние ChannelStatsDays дней
+func (s *TelegramChannelService) GetAllWithStats(ctx context.Context) ([]entity.TelegramChannelStats, error) {
+	stopWords, err := s.stopWordService.Words(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	since := time.Now().AddDate(0, 0, -ChannelStatsDays)
+	channels, err := s.channelRepo.GetAllWithStats(ctx, telegram.DefaultBaseURL, since, stopWords)
+	if err != nil {
+		s.logger.Error("Не удалось получить показатели каналов Telegram", zap.Error(err))
+		return nil, err
+	}
+
+	return channels, nil
+}
+
+// Add добавляет канал Telegram. Парсер загрузит его посты при следующем обходе каналов.
+// Возвращает сохраненное имя канала
+func (s *TelegramChannelService) Add(ctx context.Context, input string) (string, error) {
+	tag, err := NormalizeChannelTag(input)
+	if err != nil {
+		return "", err
+	}
+
+	if _, err := s.channelRepo.Create(ctx, tag); err != nil {
+		if !errors.Is(err, ErrTelegramChannelExists) {
+			s.logger.Error("Не удалось добавить канал Telegram", zap.Error(err), zap.String("channel", tag))
+		}
+		return "", err
+	}
+
+	s.logger.Info("Канал Telegram добавлен", zap.String("channel", tag))
+	return tag, nil
+}
+
+// Delete удаляет канал Telegram. Сохраненные вакансии канала остаются на сайте
+func (s *TelegramChannelService) Delete(ctx context.Context, id int64) error {
+	tag, err := s.channelRepo.Delete(ctx, id)
+	if err != nil {
+		if !errors.Is(err, ErrTelegramChannelNotFound) {
+			s.logger.Error("Не удалось удалить канал Telegram", zap.Error(err), zap.Int64("id", id))
+		}
+		return err
+	}
+
+	s.logger.Info("Канал Telegram удален", zap.String("channel", tag))
+	return nil
+}
+
+// SetPaused приостанавливает или возобновляет загрузку канала парсером
+func (s *TelegramChannelService) SetPaused(ctx context.Context, id int64, paused bool) error {
+	tag, err := s.channelRepo.SetPaused(ctx, id, paused)
+	if err != nil {
+		if !errors.Is(err, ErrTelegramChannelNotFound) {
+			s.logger.Error("Не удалось изменить паузу канала Telegram", zap.Error(err), zap.Int64("id", id))
+		}
+		return err
+	}
+
+	s.logger.Info("Изменена пауза канала Telegram", zap.String("channel", tag), zap.Bool("paused", paused))
+	return nil
+}
+
+// ResetLastPostID задает ID последнего обработанного поста канала. Парсер заново загрузит посты после него,
+// уже сохраненные вакансии пропускаются по ссылке на пост
+func (s *TelegramChannelService) ResetLastPostID(ctx context.Context, id, lastPostID int64) error {
+	tag, err := s.channelRepo.ResetLastPostID(ctx, id, lastPostID)
+	if err != nil {
+		if !errors.Is(err, ErrTelegramChannelNotFound) {
+			s.logger.Error("Не удалось изменить последний пост канала Telegram", zap.Error(err), zap.Int64("id", id))
+		}
+		return err
+	}
+
+	s.logger.Info("Сброшен последний пост канала Telegram", zap.String("channel", tag), zap.Int64("lastPostId", lastPostID))
+	return nil
+}
@@ -0,0 +1,146 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/zalhonan/remotejobs-site/internal/domain/service"
+	"github.com/zalhonan/remotejobs-site/internal/view/model"
+)
+
+// Channels отображает каналы Telegram с показателями их вакансий
+func (h *AdminHandler) Channels(w http.ResponseWriter, r *http.Request) {
+	h.renderChannels(w, r, http.StatusOK, model.AdminChannelsViewModel{
+		AdminPage: model.AdminPage{Flash: flashMessage(r)},
+	})
+}
+
+// CreateChannel добавляет канал Telegram по имени, @имени или ссылке t.me/<tag>
+func (h *AdminHandler) CreateChannel(w http.ResponseWriter, r *http.Request) {
+	if !h.parseForm(w, r) {
+		return
+	}
+
+	viewModel := model.AdminChannelsViewModel{Tag: strings.TrimSpace(r.PostFormValue("tag"))}
+
+	_, err := h.channelService.Add(r.Context(), viewModel.Tag)
+	switch {
+	case errors.Is(err, service.ErrInvalidChannelTag):
+		viewModel.Error = "Имя канала должно начинаться с латинской буквы и состоять из 5-32 латинских букв, цифр и _"
+		h.renderChannels(w, r, http.StatusUnprocessableEntity, viewModel)
+		return
+	case errors.Is(err, service.ErrTelegramChannelExists):
+		viewModel.Error = "Канал с таким именем уже есть"
+		h.renderChannels(w, r, http.StatusUnprocessableEntity, viewModel)
+		return
+	case err != nil:
+		h.renderError(w, http.StatusInternalServerError, "Ошибка сервера", "Не удалось добавить канал")
+		return
+	}
+
+	redirectWithFlash(w, r, "/admin/channels", "channel-added")
+}
+
+// PauseChannel приостанавливает (paused=1) или возобновляет загрузку канала парсером
+func (h *AdminHandler) PauseChannel(w http.ResponseWriter, r *http.Request) {
+	id, ok := parseIDParam(r, "id")
+	if !ok {
+		h.renderError(w, http.StatusNotFound, "Канал не найден", "Некорректный ID канала")
+		return
+	}
+
+	if !h.parseForm(w, r) {
+		return
+	}
+
+	paused := r.PostFormValue("paused") == "1"
+	if err := h.channelService.SetPaused(r.Context(), id, paused); err != nil {
+		h.handleChannelError(w, err)
+		return
+	}
+
+	flash := "channel-resumed"
+	if paused {
+		flash = "channel-paused"
+	}
+	redirectWithFlash(w, r, "/admin/channels", flash)
+}
+
+// ResetChannel задает ID последнего обработанного поста канала, чтобы парсер заново загрузил посты после него
+func (h *AdminHandler) ResetChannel(w http.ResponseWriter, r *http.Request) {
+	id, ok := parseIDParam(r, "id")
+	if !ok {
+		h.renderError(w, http.StatusNotFound, "Канал не найден", "Некорректный ID канала")
+		return
+	}
+
+	if !h.parseForm(w, r) {
+		return
+	}
+
+	lastPostID, err := strconv.ParseInt(strings.TrimSpace(r.PostFormValue("last_post_id")), 10, 64)
+	if err != nil || lastPostID < 0 {
+		h.renderError(w, http.StatusBadRequest, "Некорректный пост", "ID последнего поста должен быть целым числом не меньше 0")
+		return
+	}
+
+	if err := h.channelService.ResetLastPostID(r.Context(), id, lastPostID); err != nil {
+		h.handleChannelError(w, err)
+		return
+	}
+
+	redirectWithFlash(w, r, "/admin/channels", "channel-reset", lastPostID)
+}
+
+// DeleteChannel удаляет канал Telegram после подтверждения (confirm=1). Сохраненные вакансии канала остаются на сайте
+func (h *AdminHandler) DeleteChannel(w http.ResponseWriter, r *http.Request) {
+	id, ok := parseIDParam(r, "id")
+	if !ok {
+		h.renderError(w, http.StatusNotFound, "Канал не найден", "Некорректный ID канала")
+		return
+	}
+
+	if !h.parseForm(w, r) {
+		return
+	}
+
+	if r.PostFormValue("confirm") != "1" {
+		h.renderChannels(w, r, http.StatusUnprocessableEntity, model.AdminChannelsViewModel{Error: "Подтвердите удаление канала"})
+		return
+	}
+
+	if err := h.channelService.Delete(r.Context(), id); err != nil {
+		h.handleChannelError(w, err)
+		return
+	}
+
+	redirectWithFlash(w, r, "/admin/channels", "channel-deleted")
+}
+
+// handleChannelError отображает страницу ошибки действия с каналом
+func (h *AdminHandler) handleChannelError(w http.ResponseWriter, err error) {
+	if errors.Is(err, service.ErrTelegramChannelNotFound) {
+		h.renderError(w, http.StatusNotFound, "Канал не найден", "Канал не существует или был удален")
+		return
+	}
+
+	h.renderError(w, http.StatusInternalServerError, "Ошибка сервера", "Не удалось изменить канал")
+}
+
+// renderChannels отображает список каналов с формой добавления
+func (h *AdminHandler) renderChannels(w http.ResponseWriter, r *http.Request, statusCode int, viewModel model.AdminChannelsViewModel) {
+	channels, err := h.channelService.GetAllWithStats(r.Context())
+	if err != nil {
+		h.renderError(w, http.StatusInternalServerError, "Ошибка сервера", "Не удалось загрузить каналы")
+		return
+	}
+
+	viewModel.PageTitle = "Каналы Telegram"
+	viewModel.Section = model.AdminSectionChannels
+	viewModel.Channels = model.NewAdminChannelViewModels(channels)
+	viewModel.StatsDays = service.ChannelStatsDays
+
+	h.render(w, statusCode, "admin/channels.html", viewModel)
+}
@@ -0,0 +1,185 @@
+package handler
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/zalhonan/remotejobs-site/internal/domain/service"
+	"github.com/zalhonan/remotejobs-site/internal/view/model"
+	"go.uber.org/zap"
+)
+
+// maxAdminFormSize максимальный размер тела формы админки
+const maxAdminFormSize = 1 << 20
+
+type AdminHandler struct {
+	technologyService *service.TechnologyService
+	classifierService *service.ClassifierService
+	moderationService *service.ModerationService
+	stopWordService   *service.StopWordService
+	channelService    *service.TelegramChannelService
+	jobAdminService   *service.JobAdminService
+	templates         *TemplateRenderer
+	logger            *zap.Logger
+}
+
+// NewAdminHandler создает новый обработчик страниц админки
+func NewAdminHandler(
+	technologyService *service.TechnologyService,
+	classifierService *service.ClassifierService,
+	moderationService *service.ModerationService,
+	stopWordService *service.StopWordService,
+	channelService *service.TelegramChannelService,
+	jobAdminService *service.JobAdminService,
+	templates *TemplateRenderer,
+	logger *zap.Logger,
+) *AdminHandler {
+	return &AdminHandler{
+		technologyService: technologyService,
+		classifierService: classifierService,
+		moderationService: moderationService,
+		stopWordService:   stopWordService,
+		channelService:    channelService,
+		jobAdminService:   jobAdminService,
+		templates:         templates,
+		logger:            logger,
+	}
+}
+
+// Index перенаправляет на первый раздел админки
+func (h *AdminHandler) Index(w http.ResponseWriter, r *http.Request) {
+	http.Redirect(w, r, "/admin/technologies", http.StatusFound)
+}
+
+// render отображает страницу админки с HTTP статусом statusCode
+func (h *AdminHandler) render(w http.ResponseWriter, statusCode int, name string, data interface{}) {
+	// Заголовки нужно задать до отправки статуса, после WriteHeader они игнорируются
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	w.WriteHeader(statusCode)
+
+	if err := h.templates.Render(w, name, data); err != nil {
+		h.logger.Error("Ошибка при рендеринге шаблона админки",
+			zap.Error(err),
+			zap.String("template", name),
+		)
+		// Заголовки уже могли быть отправлены, поэтому только пишем сообщение в тело ответа
+		w.Write([]byte("Внутренняя ошибка сервера"))
+	}
+}
+
+// renderError отображает страницу ошибки админки
+func (h *AdminHandler) renderError(w http.ResponseWriter, statusCode int, title, message string) {
+	h.render(w, statusCode, "admin/error.html", model.AdminErrorViewModel{
+		AdminPage:  model.AdminPage{PageTitle: "Ошибка"},
+		StatusCode: statusCode,
+		Title:      title,
+		Message:    message,
+	})
+}
+
+// parseForm разбирает тело формы админки с ограничением размера.
+// При ошибке отображает страницу ошибки и возвращает false
+func (h *AdminHandler) parseForm(w http.ResponseWriter, r *http.Request) bool {
+	r.Body = http.MaxBytesReader(w, r.Body, maxAdminFormSize)
+	if err := r.ParseForm(); err != nil {
+		var maxBytesErr *http.MaxBytesError
+		if errors.As(err, &maxBytesErr) {
+			h.renderError(w, http.StatusRequestEntityTooLarge, "Слишком большая форма", "Размер формы превышает допустимый")
+			return false
+		}
+		h.renderError(w, http.StatusBadRequest, "Некорректная форма", "Не удалось разобрать отправленную форму")
+		return false
+	}
+	return true
+}
+
+// adminActor возвращает имя пользователя админки для журнала изменений.
+// Запрос уже прошел проверку AdminAuth, поэтому имя из Basic-аутентификации подлинное
+func adminActor(r *http.Request) string {
+	user, _, _ := r.BasicAuth()
+	return user
+}
+
+// parseIDParam возвращает положительный ID из параметра маршрута
+func parseIDParam(r *http.Request, name string) (int64, bool) {
+	id, err := strconv.ParseInt(chi.URLParam(r, name), 10, 64)
+	if err != nil || id <= 0 {
+		return 0, false
+	}
+	return id, true
+}
+
+// adminFlashes сообщения о результате действий в админке по кодам из параметра flash.
+// В URL передаются только код и числа для подстановки вместо %d, поэтому ссылкой нельзя
+// подставить в админку произвольный текст
+var adminFlashes = map[string]string{
+	"technology-created": "Технология добавлена. Существующие вакансии получат её после повторной классификации (go run ./cmd/reclassify)",
+	"technology-updated": "Технология сохранена. Новые ключевые слова применяются к существующим вакансиям после повторной классификации (go run ./cmd/reclassify)",
+	"technology-deleted": "Технология удалена, её вакансии остались без основной технологии до повторной классификации",
+	"job-assigned":       "Технология назначена, вакансия показывается на сайте",
+	"job-not-vacancy":    "Пост отмечен как не вакансия и скрыт с сайта",
+	"stop-word-added":    "Стоп-слово добавлено, вакансии с ним скрыты с сайта",
+	"stop-word-exists":   "Стоп-слово уже есть в справочнике",
+	"stop-words-added":   "Добавлено стоп-слов: %d, уже были в справочнике: %d, помечено вакансий: %d",
+	"stop-word-applied":  "Стоп-слово записано в вакансии, помечено вакансий: %d",
+	"stop-word-deleted":  "Стоп-слово удалено, пометка снята с вакансий: %d",
+	"channel-added":      "Канал добавлен, парсер загрузит его посты при следующем обходе каналов",
+	"channel-deleted":    "Канал удален, его вакансии остались на сайте",
+	"channel-paused":     "Канал приостановлен, парсер его не загружает",
+	"channel-resumed":    "Загрузка канала возобновлена",
+	"channel-reset":      "Последний пост канала изменен на %d, парсер заново загрузит посты после него",
+	"job-updated":        "Вакансия сохранена",
+	"job-unchanged":      "Изменений нет, вакансия не сохранялась",
+	"job-hidden":         "Вакансия скрыта с сайта",
+	"job-unhidden":       "Вакансия снова показывается на сайте",
+	"job-deleted":        "Вакансия удалена с сайта, изменение записано в журнал",
+	"job-restored":       "Отметка \"не вакансия\" снята",
+}
+
+// redirectWithFlash перенаправляет после успешного изменения на страницу target с сообщением о результате
+// и числами для подстановки в него. Перенаправление нужно, чтобы обновление страницы не отправляло форму повторно
+func redirectWithFlash(w http.ResponseWriter, r *http.Request, target, flash string, numbers ...int64) {
+	query := url.Values{"flash": {flash}}
+	for _, n := range numbers {
+		query.Add("n", strconv.FormatInt(n, 10))
+	}
+
+	separator := "?"
+	if strings.Contains(target, "?") {
+		separator = "&"
+	}
+	http.Redirect(w, r, target+separator+query.Encode(), http.StatusSeeOther)
+}
+
+// flashMessage возвращает сообщение о результате действия по коду из параметра flash.
+// Если чисел для подстановки в запросе не столько, сколько ждет сообщение, сообщение не показывается
+func flashMessage(r *http.Request) string {
+	message, ok := adminFlashes[r.URL.Query().Get("flash")]
+	if !ok {
+		return ""
+	}
+
+	values := r.URL.Query()["n"]
+	if len(values) != strings.Count(message, "%d") {
+		return ""
+	}
+	if len(values) == 0 {
+		return message
+	}
+
+	numbers := make([]interface{}, 0, len(values))
+	for _, value := range values {
+		n, err := strconv.ParseInt(value, 10, 64)
+		if err != nil {
+			return ""
+		}
+		numbers = append(numbers, n)
+	}
+
+	return fmt.Sprintf(message, numbers...)
+}
@@ -0,0 +1,259 @@
+package handler
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/zalhonan/remotejobs-site/internal/domain/entity"
+	"github.com/zalhonan/remotejobs-site/internal/domain/service"
+	"github.com/zalhonan/remotejobs-site/internal/view/model"
+)
+
+// Jobs отображает поиск вакансии по ID или ссылке и журнал последних изменений вакансий.
+// Если вакансия найдена, перенаправляет на её страницу в админке
+func (h *AdminHandler) Jobs(w http.ResponseWriter, r *http.Request) {
+	viewModel := model.AdminJobsViewModel{
+		AdminPage: model.AdminPage{
+			PageTitle: "Вакансии",
+			Section:   model.AdminSectionJobs,
+			Flash:     flashMessage(r),
+		},
+		Query: strings.TrimSpace(r.URL.Query().Get("q")),
+	}
+
+	statusCode := http.StatusOK
+	if viewModel.Query != "" {
+		if id, ok := parseJobLookup(viewModel.Query); ok {
+			http.Redirect(w, r, model.AdminJobURL(id), http.StatusFound)
+			return
+		}
+		viewModel.Error = "Укажите ID вакансии или ссылку на её страницу на сайте"
+		statusCode = http.StatusUnprocessableEntity
+	}
+
+	entries, err := h.jobAdminService.GetAuditLog(r.Context())
+	if err != nil {
+		h.renderError(w, http.StatusInternalServerError, "Ошибка сервера", "Не удалось загрузить журнал изменений")
+		return
+	}
+	viewModel.Entries = model.NewAdminJobAuditEntryViewModels(entries)
+
+	h.render(w, statusCode, "admin/jobs.html", viewModel)
+}
+
+// EditJob отображает форму изменения вакансии с её журналом изменений
+func (h *AdminHandler) EditJob(w http.ResponseWriter, r *http.Request) {
+	id, ok := parseIDParam(r, "id")
+	if !ok {
+		h.renderError(w, http.StatusNotFound, "Вакансия не найдена", "Некорректный ID вакансии")
+		return
+	}
+
+	h.renderJob(w, r, http.StatusOK, id, nil, nil)
+}
+
+// UpdateJob сохраняет заголовок, основную технологию и текст вакансии
+func (h *AdminHandler) UpdateJob(w http.ResponseWriter, r *http.Request) {
+	id, ok := parseIDParam(r, "id")
+	if !ok {
+		h.renderError(w, http.StatusNotFound, "Вакансия не найдена", "Некорректный ID вакансии")
+		return
+	}
+
+	if !h.parseForm(w, r) {
+		return
+	}
+
+	form := model.AdminJobForm{
+		Title:          r.PostFormValue("title"),
+		MainTechnology: r.PostFormValue("technology"),
+		Content:        r.PostFormValue("content"),
+		Comment:        strings.TrimSpace(r.PostFormValue("comment")),
+	}
+
+	err := h.jobAdminService.Update(r.Context(), id, entity.JobEdit{
+		Title:          form.Title,
+		MainTechnology: form.MainTechnology,
+		Content:        form.Content,
+	}, adminActor(r), form.Comment)
+	switch {
+	case errors.Is(err, service.ErrInvalidJobEdit):
+		h.renderJob(w, r, http.StatusUnprocessableEntity, id, &form, map[string]string{
+			"form": fmt.Sprintf("Заголовок и текст вакансии не должны быть пустыми, заголовок - не длиннее %d символов", service.MaxJobTitleLength),
+		})
+		return
+	case errors.Is(err, service.ErrUnknownTechnology):
+		h.renderJob(w, r, http.StatusUnprocessableEntity, id, &form, map[string]string{
+			"technology": "Технологии нет в справочнике",
+		})
+		return
+	case errors.Is(err, service.ErrNoJobChanges):
+		redirectWithFlash(w, r, model.AdminJobURL(id), "job-unchanged")
+		return
+	case err != nil:
+		h.handleJobError(w, err)
+		return
+	}
+
+	// Вакансия могла перейти к другой технологии
+	_ = h.technologyService.RefreshCounts(r.Context())
+
+	redirectWithFlash(w, r, model.AdminJobURL(id), "job-updated")
+}
+
+// HideJob скрывает вакансию с сайта (hidden=1) или снова показывает её, не удаляя из базы
+func (h *AdminHandler) HideJob(w http.ResponseWriter, r *http.Request) {
+	id, ok := parseIDParam(r, "id")
+	if !ok {
+		h.renderError(w, http.StatusNotFound, "Вакансия не найдена", "Некорректный ID вакансии")
+		return
+	}
+
+	if !h.parseForm(w, r) {
+		return
+	}
+
+	hidden := r.PostFormValue("hidden") == "1"
+	err := h.jobAdminService.SetHidden(r.Context(), id, hidden, adminActor(r), strings.TrimSpace(r.PostFormValue("comment")))
+	if err != nil && !errors.Is(err, service.ErrNoJobChanges) {
+		h.handleJobError(w, err)
+		return
+	}
+
+	_ = h.technologyService.RefreshCounts(r.Context())
+
+	flash := "job-unhidden"
+	if hidden {
+		flash = "job-hidden"
+	}
+	redirectWithFlash(w, r, model.AdminJobURL(id), flash)
+}
+
+// DeleteJob удаляет вакансию после подтверждения (confirm=1)
+func (h *AdminHandler) DeleteJob(w http.ResponseWriter, r *http.Request) {
+	id, ok := parseIDParam(r, "id")
+	if !ok {
+		h.renderError(w, http.StatusNotFound, "Вакансия не найдена", "Некорректный ID вакансии")
+		return
+	}
+
+	if !h.parseForm(w, r) {
+		return
+	}
+
+	if r.PostFormValue("confirm") != "1" {
+		h.renderJob(w, r, http.StatusUnprocessableEntity, id, nil, map[string]string{
+			"confirm": "Подтвердите удаление вакансии",
+		})
+		return
+	}
+
+	err := h.jobAdminService.Delete(r.Context(), id, adminActor(r), strings.TrimSpace(r.PostFormValue("comment")))
+	if err != nil && !errors.Is(err, service.ErrNoJobChanges) {
+		h.handleJobError(w, err)
+		return
+	}
+
+	_ = h.technologyService.RefreshCounts(r.Context())
+
+	redirectWithFlash(w, r, model.AdminJobURL(id), "job-deleted")
+}
+
+// RestoreJob снимает с вакансии отметку "не вакансия", поставленную модератором по ошибке
+func (h *AdminHandler) RestoreJob(w http.ResponseWriter, r *http.Request) {
+	id, ok := parseIDParam(r, "id")
+	if !ok {
+		h.renderError(w, http.StatusNotFound, "Вакансия не найдена", "Некорректный ID вакансии")
+		return
+	}
+
+	if !h.parseForm(w, r) {
+		return
+	}
+
+	err := h.moderationService.RestoreVacancy(r.Context(), id, adminActor(r), strings.TrimSpace(r.PostFormValue("comment")))
+	if err != nil && !errors.Is(err, service.ErrNoJobChanges) {
+		h.handleJobError(w, err)
+		return
+	}
+
+	_ = h.technologyService.RefreshCounts(r.Context())
+
+	redirectWithFlash(w, r, model.AdminJobURL(id), "job-restored")
+}
+
+// handleJobError отображает страницу ошибки действия с вакансией
+func (h *AdminHandler) handleJobError(w http.ResponseWriter, err error) {
+	if errors.Is(err, service.ErrJobNotFound) {
+		h.renderError(w, http.StatusNotFound, "Вакансия не найдена", "Вакансия не существует или была удалена")
+		return
+	}
+
+	h.renderError(w, http.StatusInternalServerError, "Ошибка сервера", "Не удалось изменить вакансию")
+}
+
+// renderJob отображает форму изменения вакансии. Если form равна nil, форма заполняется значениями вакансии
+func (h *AdminHandler) renderJob(
+	w http.ResponseWriter,
+	r *http.Request,
+	statusCode int,
+	id int64,
+	form *model.AdminJobForm,
+	formErrors map[string]string,
+) {
+	ctx := r.Context()
+
+	job, entries, err := h.jobAdminService.GetByID(ctx, id)
+	if err != nil {
+		h.handleJobError(w, err)
+		return
+	}
+
+	technologies, err := h.technologyService.GetAllForAdmin(ctx)
+	if err != nil {
+		h.renderError(w, http.StatusInternalServerError, "Ошибка сервера", "Не удалось загрузить список технологий")
+		return
+	}
+
+	viewModel := model.NewAdminJobViewModel(job, entries)
+	viewModel.PageTitle = "Вакансия " + strconv.FormatInt(job.ID, 10)
+	viewModel.Section = model.AdminSectionJobs
+	viewModel.Flash = flashMessage(r)
+	viewModel.Errors = formErrors
+	if form != nil {
+		viewModel.Form = *form
+	}
+	for _, tech := range technologies {
+		viewModel.Technologies = append(viewModel.Technologies, tech.Technology)
+	}
+
+	h.render(w, statusCode, "admin/job.html", viewModel)
+}
+
+// parseJobLookup возвращает ID вакансии из строки поиска: ID, ссылки на страницу вакансии
+// вида /job/{id}-{slug} или ссылки на её страницу в админке
+func parseJobLookup(query string) (int64, bool) {
+	if id, err := strconv.ParseInt(query, 10, 64); err == nil {
+		return id, id > 0
+	}
+
+	parsed, err := url.Parse(query)
+	if err != nil {
+		return 0, false
+	}
+
+	for _, prefix := range []string{"/job/", "/admin/jobs/"} {
+		if !strings.HasPrefix(parsed.Path, prefix) {
+			continue
+		}
+		idPart, _, _ := strings.Cut(strings.TrimPrefix(parsed.Path, prefix), "-")
+		id, err := strconv.ParseInt(idPart, 10, 64)
+		return id, err == nil && id > 0
+	}
+
+	return 0, false
+}
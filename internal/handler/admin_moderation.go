@@ -0,0 +1,147 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/zalhonan/remotejobs-site/internal/domain/entity"
+	"github.com/zalhonan/remotejobs-site/internal/domain/service"
+	"github.com/zalhonan/remotejobs-site/internal/view/model"
+)
+
+// Moderation отображает очередь модерации: вакансии без технологии и с неуверенно определенной технологией
+func (h *AdminHandler) Moderation(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	reason := parseModerationReason(r.URL.Query().Get("reason"))
+
+	page := 1
+	if value := r.URL.Query().Get("page"); value != "" {
+		parsed, err := strconv.Atoi(value)
+		if err != nil || parsed < 1 {
+			h.renderError(w, http.StatusBadRequest, "Неверный номер страницы", "Номер страницы должен быть положительным числом")
+			return
+		}
+		page = parsed
+	}
+
+	queue, err := h.moderationService.GetQueue(ctx, reason, page)
+	if err != nil {
+		h.renderError(w, http.StatusInternalServerError, "Ошибка сервера", "Не удалось загрузить очередь модерации")
+		return
+	}
+
+	technologies, err := h.technologyService.GetAllForAdmin(ctx)
+	if err != nil {
+		h.renderError(w, http.StatusInternalServerError, "Ошибка сервера", "Не удалось загрузить список технологий")
+		return
+	}
+
+	names := make([]string, 0, len(technologies))
+	for _, tech := range technologies {
+		names = append(names, tech.Technology)
+	}
+
+	total := queue.Counts.Total()
+	switch reason {
+	case entity.ModerationReasonUnclassified:
+		total = queue.Counts.Unclassified
+	case entity.ModerationReasonLowConfidence:
+		total = queue.Counts.LowConfidence
+	}
+
+	viewModel := model.AdminModerationViewModel{
+		AdminPage: model.AdminPage{
+			PageTitle: "Модерация",
+			Section:   model.AdminSectionModeration,
+			Flash:     flashMessage(r),
+		},
+		Reason:       reason,
+		Counts:       queue.Counts,
+		Jobs:         model.NewAdminModerationJobViewModels(queue.Jobs),
+		Technologies: names,
+		Page:         page,
+	}
+	if page > 1 {
+		viewModel.PrevURL = model.ModerationURL(reason, page-1)
+	}
+	if page*service.ModerationPageSize < total {
+		viewModel.NextURL = model.ModerationURL(reason, page+1)
+	}
+
+	h.render(w, http.StatusOK, "admin/moderation.html", viewModel)
+}
+
+// ModerateJob выполняет действие модератора над вакансией из очереди: назначает технологию (action=assign),
+// отмечает пост как не вакансию (action=not_vacancy) или добавляет стоп-слово из поля word (action=stop_word).
+// После действия возвращает на ту же страницу очереди
+func (h *AdminHandler) ModerateJob(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	jobID, ok := parseIDParam(r, "id")
+	if !ok {
+		h.renderError(w, http.StatusNotFound, "Вакансия не найдена", "Некорректный ID вакансии")
+		return
+	}
+
+	if !h.parseForm(w, r) {
+		return
+	}
+
+	// Возвращаемся на ту же страницу очереди, параметры собираются заново, чтобы не перенаправить на чужой адрес
+	page, err := strconv.Atoi(r.PostFormValue("page"))
+	if err != nil || page < 1 {
+		page = 1
+	}
+	back := model.ModerationURL(parseModerationReason(r.PostFormValue("reason")), page)
+
+	var flash string
+	switch r.PostFormValue("action") {
+	case "assign":
+		err = h.moderationService.AssignTechnology(ctx, jobID, r.PostFormValue("technology"), adminActor(r))
+		flash = "job-assigned"
+	case "not_vacancy":
+		err = h.moderationService.MarkNotVacancy(ctx, jobID, adminActor(r))
+		flash = "job-not-vacancy"
+	case "stop_word":
+		var added bool
+		_, added, err = h.stopWordService.Add(ctx, r.PostFormValue("word"))
+		flash = "stop-word-added"
+		if !added {
+			flash = "stop-word-exists"
+		}
+	default:
+		h.renderError(w, http.StatusBadRequest, "Неизвестное действие", "Форма модерации отправила неизвестное действие")
+		return
+	}
+
+	// Повторная отправка того же решения не ошибка
+	if errors.Is(err, service.ErrNoJobChanges) {
+		err = nil
+	}
+
+	switch {
+	case errors.Is(err, service.ErrJobNotFound):
+		h.renderError(w, http.StatusNotFound, "Вакансия не найдена", "Вакансия не существует или была удалена")
+	case errors.Is(err, service.ErrUnknownTechnology):
+		h.renderError(w, http.StatusUnprocessableEntity, "Технология не найдена", "Выберите технологию из справочника")
+	case errors.Is(err, service.ErrInvalidStopWord):
+		h.renderError(w, http.StatusUnprocessableEntity, "Некорректное стоп-слово",
+			"Стоп-слово ищется в тексте вакансии целиком и должно быть длиной от 3 до 255 символов")
+	case err != nil:
+		h.renderError(w, http.StatusInternalServerError, "Ошибка сервера", "Не удалось выполнить действие модератора")
+	default:
+		redirectWithFlash(w, r, back, flash)
+	}
+}
+
+// parseModerationReason возвращает причину попадания в очередь модерации из параметра запроса,
+// неизвестное значение означает любую причину
+func parseModerationReason(value string) string {
+	switch value {
+	case entity.ModerationReasonUnclassified, entity.ModerationReasonLowConfidence:
+		return value
+	default:
+		return ""
+	}
+}
@@ -0,0 +1,163 @@
+package handler
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/zalhonan/remotejobs-site/internal/domain/service"
+	"github.com/zalhonan/remotejobs-site/internal/view/model"
+)
+
+// StopWords отображает справочник стоп-слов с формой импорта
+func (h *AdminHandler) StopWords(w http.ResponseWriter, r *http.Request) {
+	h.renderStopWords(w, r, http.StatusOK, model.AdminStopWordsViewModel{
+		AdminPage: model.AdminPage{Flash: flashMessage(r)},
+		Apply:     true,
+	})
+}
+
+// ImportStopWords добавляет стоп-слова из формы, по одному на строке, или показывает,
+// сколько вакансий они затронут (action=preview). Если отмечено apply, новые слова сразу
+// записываются в колонку stop_words вакансий
+func (h *AdminHandler) ImportStopWords(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	if !h.parseForm(w, r) {
+		return
+	}
+
+	viewModel := model.AdminStopWordsViewModel{
+		Words: r.PostFormValue("words"),
+		Apply: r.PostFormValue("apply") == "1",
+	}
+
+	words, invalid := service.ParseStopWords(viewModel.Words)
+	for _, line := range invalid {
+		viewModel.Errors = append(viewModel.Errors,
+			fmt.Sprintf("Стоп-слово должно быть длиной от %d до %d символов: %s", service.MinStopWordLength, service.MaxStopWordLength, line))
+	}
+	if len(words) == 0 && len(invalid) == 0 {
+		viewModel.Errors = append(viewModel.Errors, "Укажите хотя бы одно стоп-слово")
+	}
+	if len(words) > service.MaxStopWordImport {
+		viewModel.Errors = append(viewModel.Errors,
+			fmt.Sprintf("За один раз можно добавить не больше %d стоп-слов", service.MaxStopWordImport))
+	}
+	if len(viewModel.Errors) > 0 {
+		h.renderStopWords(w, r, http.StatusUnprocessableEntity, viewModel)
+		return
+	}
+
+	if r.PostFormValue("action") == "preview" {
+		preview, err := h.stopWordService.Preview(ctx, words)
+		if err != nil {
+			h.renderError(w, http.StatusInternalServerError, "Ошибка сервера", "Не удалось посчитать вакансии со стоп-словами")
+			return
+		}
+
+		viewModel.Preview = preview
+		h.renderStopWords(w, r, http.StatusOK, viewModel)
+		return
+	}
+
+	result, err := h.stopWordService.Import(ctx, words, viewModel.Apply)
+	if err != nil {
+		h.renderError(w, http.StatusInternalServerError, "Ошибка сервера", "Не удалось сохранить стоп-слова")
+		return
+	}
+
+	// Вакансии со стоп-словами не учитываются в количестве вакансий технологий
+	_ = h.technologyService.RefreshCounts(ctx)
+
+	redirectWithFlash(w, r, "/admin/stop-words", "stop-words-added", result.Added, result.Existing, result.Marked)
+}
+
+// ExportStopWords выгружает справочник стоп-слов текстовым файлом, по слову на строке.
+// Файл подходит для импорта через форму справочника
+func (h *AdminHandler) ExportStopWords(w http.ResponseWriter, r *http.Request) {
+	words, err := h.stopWordService.Words(r.Context())
+	if err != nil {
+		h.renderError(w, http.StatusInternalServerError, "Ошибка сервера", "Не удалось загрузить стоп-слова")
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+	w.Header().Set("Content-Disposition", `attachment; filename="stop_words.txt"`)
+
+	var b strings.Builder
+	for _, word := range words {
+		b.WriteString(word)
+		b.WriteString("\n")
+	}
+	w.Write([]byte(b.String()))
+}
+
+// ApplyStopWord записывает стоп-слово из справочника в колонку stop_words вакансий, где оно встречается
+func (h *AdminHandler) ApplyStopWord(w http.ResponseWriter, r *http.Request) {
+	id, ok := parseIDParam(r, "id")
+	if !ok {
+		h.renderError(w, http.StatusNotFound, "Стоп-слово не найдено", "Некорректный ID стоп-слова")
+		return
+	}
+
+	marked, err := h.stopWordService.Apply(r.Context(), id)
+	if err != nil {
+		h.handleStopWordError(w, err)
+		return
+	}
+
+	_ = h.technologyService.RefreshCounts(r.Context())
+
+	redirectWithFlash(w, r, "/admin/stop-words", "stop-word-applied", marked)
+}
+
+// DeleteStopWord удаляет стоп-слово из справочника. Если отмечено unmark, слово убирается
+// и из колонки stop_words вакансий
+func (h *AdminHandler) DeleteStopWord(w http.ResponseWriter, r *http.Request) {
+	id, ok := parseIDParam(r, "id")
+	if !ok {
+		h.renderError(w, http.StatusNotFound, "Стоп-слово не найдено", "Некорректный ID стоп-слова")
+		return
+	}
+
+	if !h.parseForm(w, r) {
+		return
+	}
+
+	unmarked, err := h.stopWordService.Delete(r.Context(), id, r.PostFormValue("unmark") == "1")
+	if err != nil {
+		h.handleStopWordError(w, err)
+		return
+	}
+
+	_ = h.technologyService.RefreshCounts(r.Context())
+
+	redirectWithFlash(w, r, "/admin/stop-words", "stop-word-deleted", unmarked)
+}
+
+// handleStopWordError отображает страницу ошибки действия со стоп-словом
+func (h *AdminHandler) handleStopWordError(w http.ResponseWriter, err error) {
+	if errors.Is(err, service.ErrStopWordNotFound) {
+		h.renderError(w, http.StatusNotFound, "Стоп-слово не найдено", "Стоп-слово не существует или было удалено")
+		return
+	}
+
+	h.renderError(w, http.StatusInternalServerError, "Ошибка сервера", "Не удалось изменить стоп-слово")
+}
+
+// renderStopWords отображает справочник стоп-слов с формой импорта
+func (h *AdminHandler) renderStopWords(w http.ResponseWriter, r *http.Request, statusCode int, viewModel model.AdminStopWordsViewModel) {
+	stopWords, err := h.stopWordService.GetAll(r.Context())
+	if err != nil {
+		h.renderError(w, http.StatusInternalServerError, "Ошибка сервера", "Не удалось загрузить стоп-слова")
+		return
+	}
+
+	viewModel.PageTitle = "Стоп-слова"
+	viewModel.Section = model.AdminSectionStopWords
+	viewModel.StopWords = stopWords
+
+	h.render(w, statusCode, "admin/stop_words.html", viewModel)
+}
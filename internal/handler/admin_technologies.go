@@ -0,0 +1,375 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/zalhonan/remotejobs-site/internal/domain/entity"
+	"github.com/zalhonan/remotejobs-site/internal/domain/service"
+	"github.com/zalhonan/remotejobs-site/internal/view/model"
+)
+
+const (
+	// maxTechnologyNameLength максимальная длина названия технологии (размер колонки technologies.technology)
+	maxTechnologyNameLength = 100
+
+	// maxKeywordLength максимальная длина ключевого слова технологии
+	maxKeywordLength = 100
+
+	// minSortOrder, maxSortOrder допустимый порядок сортировки (ограничение CHECK колонки technologies.sort_order)
+	minSortOrder = -100
+	maxSortOrder = 100
+
+	// technologyPreviewDays за сколько последних дней вакансии проверяются ключевыми словами
+	technologyPreviewDays = 30
+)
+
+// reservedTechnologyNames названия, занятые маршрутами сайта: страница технологии открывается по URL /{technology}
+var reservedTechnologyNames = map[string]struct{}{
+	"admin":  {},
+	"api":    {},
+	"filter": {},
+	"job":    {},
+	"search": {},
+	"static": {},
+}
+
+// Technologies отображает справочник технологий
+func (h *AdminHandler) Technologies(w http.ResponseWriter, r *http.Request) {
+	technologies, err := h.technologyService.GetAllForAdmin(r.Context())
+	if err != nil {
+		h.renderError(w, http.StatusInternalServerError, "Ошибка сервера", "Не удалось загрузить список технологий")
+		return
+	}
+
+	h.render(w, http.StatusOK, "admin/technologies.html", model.AdminTechnologyListViewModel{
+		AdminPage: model.AdminPage{
+			PageTitle: "Технологии",
+			Section:   model.AdminSectionTechnologies,
+			Flash:     flashMessage(r),
+		},
+		Technologies: model.NewAdminTechnologyViewModels(technologies),
+	})
+}
+
+// NewTechnology отображает форму добавления технологии
+func (h *AdminHandler) NewTechnology(w http.ResponseWriter, r *http.Request) {
+	h.renderTechnologyForm(w, http.StatusOK, model.AdminTechnologyForm{SortOrder: "0"}, nil, nil, 0)
+}
+
+// CreateTechnology добавляет технологию или проверяет её ключевые слова на недавних вакансиях
+func (h *AdminHandler) CreateTechnology(w http.ResponseWriter, r *http.Request) {
+	if !h.parseForm(w, r) {
+		return
+	}
+
+	form, tech, formErrors := parseTechnologyForm(r, 0)
+	if len(formErrors) > 0 {
+		h.renderTechnologyForm(w, http.StatusUnprocessableEntity, form, formErrors, nil, 0)
+		return
+	}
+
+	if r.PostFormValue("action") == "preview" {
+		h.previewTechnology(w, r, form, tech, 0)
+		return
+	}
+
+	if _, err := h.technologyService.Create(r.Context(), tech); err != nil {
+		h.handleTechnologySaveError(w, err, form, 0)
+		return
+	}
+
+	redirectWithFlash(w, r, "/admin/technologies", "technology-created")
+}
+
+// EditTechnology отображает форму редактирования технологии
+func (h *AdminHandler) EditTechnology(w http.ResponseWriter, r *http.Request) {
+	tech, ok := h.technologyFromParam(w, r)
+	if !ok {
+		return
+	}
+
+	h.renderTechnologyForm(w, http.StatusOK, model.NewAdminTechnologyForm(tech), nil, nil, tech.Count)
+}
+
+// UpdateTechnology сохраняет технологию или проверяет её ключевые слова на недавних вакансиях
+func (h *AdminHandler) UpdateTechnology(w http.ResponseWriter, r *http.Request) {
+	current, ok := h.technologyFromParam(w, r)
+	if !ok {
+		return
+	}
+
+	if !h.parseForm(w, r) {
+		return
+	}
+
+	form, tech, formErrors := parseTechnologyForm(r, current.ID)
+	if len(formErrors) > 0 {
+		h.renderTechnologyForm(w, http.StatusUnprocessableEntity, form, formErrors, nil, current.Count)
+		return
+	}
+
+	if r.PostFormValue("action") == "preview" {
+		h.previewTechnology(w, r, form, tech, current.Count)
+		return
+	}
+
+	if err := h.technologyService.Update(r.Context(), tech); err != nil {
+		h.handleTechnologySaveError(w, err, form, current.Count)
+		return
+	}
+
+	redirectWithFlash(w, r, model.AdminTechnologyURL(current.ID), "technology-updated")
+}
+
+// DeleteTechnology удаляет технологию после подтверждения
+func (h *AdminHandler) DeleteTechnology(w http.ResponseWriter, r *http.Request) {
+	current, ok := h.technologyFromParam(w, r)
+	if !ok {
+		return
+	}
+
+	if !h.parseForm(w, r) {
+		return
+	}
+
+	if r.PostFormValue("confirm") != "1" {
+		h.renderTechnologyForm(w, http.StatusUnprocessableEntity, model.NewAdminTechnologyForm(current),
+			map[string]string{"confirm": "Подтвердите удаление технологии"}, nil, current.Count)
+		return
+	}
+
+	if _, err := h.technologyService.Delete(r.Context(), current.ID); err != nil {
+		h.renderError(w, http.StatusInternalServerError, "Ошибка сервера", "Не удалось удалить технологию")
+		return
+	}
+
+	redirectWithFlash(w, r, "/admin/technologies", "technology-deleted")
+}
+
+// technologyFromParam загружает технологию по ID из маршрута. Если технология не найдена,
+// отображает страницу ошибки и возвращает false
+func (h *AdminHandler) technologyFromParam(w http.ResponseWriter, r *http.Request) (entity.Technology, bool) {
+	id, ok := parseIDParam(r, "id")
+	if !ok {
+		h.renderError(w, http.StatusNotFound, "Технология не найдена", "Некорректный ID технологии")
+		return entity.Technology{}, false
+	}
+
+	tech, err := h.technologyService.GetByID(r.Context(), id)
+	if err != nil {
+		h.renderError(w, http.StatusNotFound, "Технология не найдена", "Технология не существует или была удалена")
+		return entity.Technology{}, false
+	}
+
+	return tech, true
+}
+
+// previewTechnology классифицирует недавние вакансии с ключевыми словами из формы и показывает,
+// какие вакансии перейдут к технологии и от неё. Ничего не сохраняется
+func (h *AdminHandler) previewTechnology(w http.ResponseWriter, r *http.Request, form model.AdminTechnologyForm, tech entity.Technology, jobsCount int64) {
+	since := time.Now().AddDate(0, 0, -technologyPreviewDays)
+	preview, err := h.classifierService.PreviewTechnology(r.Context(), tech, since)
+	if err != nil {
+		h.renderError(w, http.StatusInternalServerError, "Ошибка сервера", "Не удалось проверить ключевые слова на вакансиях")
+		return
+	}
+
+	changes := make([]model.AdminTechnologyChangeViewModel, 0, len(preview.Changes))
+	for _, change := range preview.Changes {
+		changes = append(changes, model.AdminTechnologyChangeViewModel{
+			Title: change.Title,
+			URL:   model.JobURL(change.JobID, change.Slug, change.Title),
+			From:  change.From,
+			To:    change.To,
+		})
+	}
+
+	h.renderTechnologyForm(w, http.StatusOK, form, nil, &model.AdminTechnologyPreviewViewModel{
+		Days:      technologyPreviewDays,
+		Processed: preview.Processed,
+		Matched:   preview.Matched,
+		Gained:    preview.Gained,
+		Lost:      preview.Lost,
+		Changes:   changes,
+		Truncated: preview.Truncated,
+	}, jobsCount)
+}
+
+// handleTechnologySaveError показывает форму с ошибкой, если имя технологии занято, иначе страницу ошибки
+func (h *AdminHandler) handleTechnologySaveError(w http.ResponseWriter, err error, form model.AdminTechnologyForm, jobsCount int64) {
+	if errors.Is(err, service.ErrTechnologyExists) {
+		h.renderTechnologyForm(w, http.StatusConflict, form, map[string]string{"name": "Технология с таким названием уже есть"}, nil, jobsCount)
+		return
+	}
+
+	h.renderError(w, http.StatusInternalServerError, "Ошибка сервера", "Не удалось сохранить технологию")
+}
+
+// renderTechnologyForm отображает форму добавления или редактирования технологии
+func (h *AdminHandler) renderTechnologyForm(
+	w http.ResponseWriter,
+	statusCode int,
+	form model.AdminTechnologyForm,
+	formErrors map[string]string,
+	preview *model.AdminTechnologyPreviewViewModel,
+	jobsCount int64,
+) {
+	title := "Новая технология"
+	if form.ID != 0 {
+		title = "Технология " + form.Name
+	}
+
+	h.render(w, statusCode, "admin/technology_form.html", model.AdminTechnologyFormViewModel{
+		AdminPage: model.AdminPage{
+			PageTitle: title,
+			Section:   model.AdminSectionTechnologies,
+		},
+		Form:      form,
+		Errors:    formErrors,
+		Preview:   preview,
+		JobsCount: jobsCount,
+	})
+}
+
+// parseTechnologyForm разбирает и проверяет форму технологии. Возвращает введенные значения для повторного
+// показа формы, технологию и ошибки проверки по полям формы
+func parseTechnologyForm(r *http.Request, id int64) (model.AdminTechnologyForm, entity.Technology, map[string]string) {
+	form := model.AdminTechnologyForm{
+		ID:               id,
+		Name:             strings.TrimSpace(r.PostFormValue("name")),
+		Keywords:         r.PostFormValue("keywords"),
+		NegativeKeywords: r.PostFormValue("negative_keywords"),
+		SortOrder:        strings.TrimSpace(r.PostFormValue("sort_order")),
+		ExpireAfterDays:  strings.TrimSpace(r.PostFormValue("expire_after_days")),
+		ArchiveAfterDays: strings.TrimSpace(r.PostFormValue("archive_after_days")),
+		RemoveAfterDays:  strings.TrimSpace(r.PostFormValue("remove_after_days")),
+	}
+
+	formErrors := make(map[string]string)
+	tech := entity.Technology{ID: id, Technology: form.Name}
+
+	if msg := validateTechnologyName(form.Name); msg != "" {
+		formErrors["name"] = msg
+	}
+
+	var msg string
+	if tech.Keywords, msg = parseKeywords(form.Keywords); msg != "" {
+		formErrors["keywords"] = msg
+	}
+	if tech.NegativeKeywords, msg = parseKeywords(form.NegativeKeywords); msg != "" {
+		formErrors["negative_keywords"] = msg
+	}
+
+	sortOrder, err := strconv.Atoi(form.SortOrder)
+	if form.SortOrder == "" {
+		sortOrder, err = 0, nil
+	}
+	if err != nil || sortOrder < minSortOrder || sortOrder > maxSortOrder {
+		formErrors["sort_order"] = "Порядок сортировки - целое число от -100 до 100"
+	}
+	tech.SortOrder = sortOrder
+
+	lifecycle := []struct {
+		field string
+		value string
+		days  *int
+	}{
+		{"expire_after_days", form.ExpireAfterDays, &tech.Lifecycle.ExpireAfterDays},
+		{"archive_after_days", form.ArchiveAfterDays, &tech.Lifecycle.ArchiveAfterDays},
+		{"remove_after_days", form.RemoveAfterDays, &tech.Lifecycle.RemoveAfterDays},
+	}
+	for _, threshold := range lifecycle {
+		if threshold.value == "" {
+			continue
+		}
+		days, err := strconv.Atoi(threshold.value)
+		if err != nil || days <= 0 {
+			formErrors[threshold.field] = "Порог - положительное число дней или пусто для порога по умолчанию"
+			continue
+		}
+		*threshold.days = days
+	}
+
+	if len(formErrors) == 0 && !lifecycleOrdered(tech.Lifecycle) {
+		formErrors["lifecycle"] = "Пороги с учетом значений по умолчанию должны не убывать: устаревание, архив, удаление"
+	}
+
+	return form, tech, formErrors
+}
+
+// validateTechnologyName проверяет название технологии, возвращает описание ошибки (пусто - название подходит).
+// Название используется в URL страницы технологии /{technology}, поэтому не должно совпадать с маршрутами сайта
+func validateTechnologyName(name string) string {
+	switch {
+	case name == "":
+		return "Укажите название технологии"
+	case utf8.RuneCountInString(name) > maxTechnologyNameLength:
+		return "Название технологии не длиннее 100 символов"
+	case strings.ContainsAny(name, " \t/\\?#%"):
+		return "Название используется в URL и не может содержать пробелы и символы / \\ ? # %"
+	}
+
+	if _, err := strconv.Atoi(name); err == nil {
+		return "Название из одних цифр совпадает с номером страницы списка вакансий"
+	}
+	if _, ok := reservedTechnologyNames[strings.ToLower(name)]; ok {
+		return "Название совпадает с разделом сайта"
+	}
+
+	return ""
+}
+
+// parseKeywords разбирает ключевые слова, по одному на строке, в виде "слово" или "слово:вес".
+// Пустые строки и повторы пропускаются. Возвращает описание ошибки (пусто - слова подходят)
+func parseKeywords(text string) ([]string, string) {
+	keywords := []string{}
+	seen := make(map[string]struct{})
+	for _, line := range strings.Split(text, "\n") {
+		keyword := strings.TrimSpace(line)
+		if keyword == "" {
+			continue
+		}
+
+		if utf8.RuneCountInString(keyword) > maxKeywordLength {
+			return nil, "Ключевое слово не длиннее 100 символов: " + keyword
+		}
+
+		// Классификатор берет вес после последнего двоеточия, если это число
+		if i := strings.LastIndex(keyword, ":"); i > 0 {
+			if weight, err := strconv.ParseFloat(strings.TrimSpace(keyword[i+1:]), 64); err == nil && weight <= 0 {
+				return nil, "Вес ключевого слова должен быть больше нуля: " + keyword
+			}
+		}
+
+		lower := strings.ToLower(keyword)
+		if _, ok := seen[lower]; ok {
+			continue
+		}
+		seen[lower] = struct{}{}
+		keywords = append(keywords, keyword)
+	}
+
+	return keywords, ""
+}
+
+// lifecycleOrdered проверяет, что пороги жизненного цикла с учетом порогов по умолчанию не убывают
+func lifecycleOrdered(lifecycle entity.JobLifecycle) bool {
+	effective := func(days, fallback int) int {
+		if days == 0 {
+			return fallback
+		}
+		return days
+	}
+
+	defaults := service.DefaultJobLifecycle
+	expire := effective(lifecycle.ExpireAfterDays, defaults.ExpireAfterDays)
+	archive := effective(lifecycle.ArchiveAfterDays, defaults.ArchiveAfterDays)
+	remove := effective(lifecycle.RemoveAfterDays, defaults.RemoveAfterDays)
+
+	return expire <= archive && archive <= remove
+}
@@ -0,0 +1,89 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/zalhonan/remotejobs-site/internal/domain/service"
+	"github.com/zalhonan/remotejobs-site/internal/view/model"
+	"go.uber.org/zap"
+)
+
+type APIHandler struct {
+	jobService        *service.JobService
+	technologyService *service.TechnologyService
+	logger            *zap.Logger
+}
+
+// NewAPIHandler создает новый обработчик JSON API
+func NewAPIHandler(
+	jobService *service.JobService,
+	technologyService *service.TechnologyService,
+	logger *zap.Logger,
+) *APIHandler {
+	return &APIHandler{
+		jobService:        jobService,
+		technologyService: technologyService,
+		logger:            logger,
+	}
+}
+
+// Jobs обрабатывает запрос на список вакансий в JSON с курсорной пагинацией.
+// Поддерживает те же фильтры, что и HTML-списки: tech, posted, from, to, hidden, before, after
+func (h *APIHandler) Jobs(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	filter, _, err := parseListFilter(r, time.Now())
+	if err != nil {
+		h.writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	cursor, direction, _, err := parseCursor(r)
+	if err != nil {
+		h.writeError(w, http.StatusBadRequest, "некорректный курсор")
+		return
+	}
+
+	var technologies []string
+	if requested := r.URL.Query()["tech"]; len(requested) > 0 {
+		technologies, err = h.technologyService.FilterExisting(ctx, requested)
+		if err != nil {
+			h.writeError(w, http.StatusInternalServerError, "не удалось проверить технологии")
+			return
+		}
+		if len(technologies) == 0 {
+			h.writeError(w, http.StatusNotFound, "технологии не найдены")
+			return
+		}
+	}
+
+	page, err := h.jobService.GetByCursor(ctx, technologies, filter, cursor, direction)
+	if err != nil {
+		h.logger.Error("Ошибка при получении вакансий для API",
+			zap.Error(err),
+			zap.Strings("technologies", technologies),
+			zap.String("cursor", cursor.String()),
+		)
+		h.writeError(w, http.StatusInternalServerError, "не удалось загрузить вакансии")
+		return
+	}
+
+	h.writeJSON(w, http.StatusOK, model.NewJobListAPIResponse(page))
+}
+
+// writeJSON отправляет ответ в формате JSON
+func (h *APIHandler) writeJSON(w http.ResponseWriter, statusCode int, data interface{}) {
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.WriteHeader(statusCode)
+
+	if err := json.NewEncoder(w).Encode(data); err != nil {
+		h.logger.Error("Ошибка при записи JSON-ответа", zap.Error(err))
+	}
+}
+
+// writeError отправляет ошибку в формате JSON
+func (h *APIHandler) writeError(w http.ResponseWriter, statusCode int, message string) {
+	h.writeJSON(w, statusCode, map[string]string{"error": message})
+}
@@ -1,9 +1,14 @@
 package handler
 
 import (
+	"context"
 	"net/http"
+	"net/url"
 	"strconv"
+	"strings"
+	"time"
 
+	"github.com/zalhonan/remotejobs-site/internal/domain/entity"
 	"github.com/zalhonan/remotejobs-site/internal/domain/service"
 	"github.com/zalhonan/remotejobs-site/internal/view/model"
 	"go.uber.org/zap"
@@ -63,31 +68,43 @@ func (h *HomeHandler) TechnologyPage(w http.ResponseWriter, r *http.Request, tec
 	h.renderJobsList(w, r, technology, page)
 }
 
-// renderJobsList отображает список вакансий с учетом фильтров
+// renderJobsList отображает список вакансий с учетом фильтров.
+// Страницы без фильтров нумеруются для поисковых систем, а отфильтрованные списки
+// и переходы по курсорам используют навигацию "новее/старее" без подсчета общего количества
 func (h *HomeHandler) renderJobsList(w http.ResponseWriter, r *http.Request, technology string, page int) {
 	ctx := r.Context()
 
-	// Получаем список всех технологий
-	technologies, err := h.technologyService.GetAll(ctx)
+	filter, filterViewModel, err := parseListFilter(r, time.Now())
 	if err != nil {
-		h.logger.Error("Ошибка при получении списка технологий",
-			zap.Error(err),
-		)
-		h.renderError(w, http.StatusInternalServerError, "Ошибка сервера", "Не удалось загрузить список технологий")
+		h.renderError(w, http.StatusBadRequest, "Неверный фильтр", "Проверьте параметры фильтра: "+err.Error())
 		return
 	}
 
-	// Преобразуем в view-модели для шаблона
-	techViewModels := make([]model.TechnologyViewModel, 0, len(technologies))
-	for _, tech := range technologies {
-		techViewModel := model.NewTechnologyViewModelFromEntity(tech)
-		techViewModels = append(techViewModels, techViewModel)
+	cursor, direction, hasCursor, err := parseCursor(r)
+	if err != nil {
+		h.renderError(w, http.StatusBadRequest, "Неверная ссылка", "Ссылка для перехода по страницам некорректна")
+		return
 	}
 
-	var jobs []model.JobViewModel
-	var totalPages int
+	cursorMode := hasCursor || filterViewModel.IsActive()
+	if cursorMode && page > 1 {
+		// Отфильтрованные списки не нумеруются, переходим на их начало
+		http.Redirect(w, r, "/"+url.PathEscape(technology)+"?"+r.URL.RawQuery, http.StatusFound)
+		return
+	}
 
-	// Получаем вакансии в зависимости от фильтра по технологии
+	if !cursorMode && page > service.MaxNumberedPages {
+		h.renderError(w, http.StatusNotFound, "Страница не найдена", "Более старые вакансии доступны по ссылке «Более старые вакансии» на последней странице")
+		return
+	}
+
+	techViewModels, err := h.getTechnologyViewModels(ctx)
+	if err != nil {
+		h.renderError(w, http.StatusInternalServerError, "Ошибка сервера", "Не удалось загрузить список технологий")
+		return
+	}
+
+	var technologies []string
 	if technology != "" {
 		// Проверяем, существует ли такая технология
 		exists, err := h.technologyService.Exists(ctx, technology)
@@ -105,8 +122,31 @@ func (h *HomeHandler) renderJobsList(w http.ResponseWriter, r *http.Request, tec
 			return
 		}
 
+		technologies = []string{technology}
+		model.MarkSelectedTechnologies(techViewModels, technologies)
+	}
+
+	var jobsRaw []entity.JobRaw
+	var totalPages int
+	var cursorPage entity.JobCursorPage
+
+	// Получаем вакансии в зависимости от способа навигации и фильтра по технологии
+	switch {
+	case cursorMode:
+		cursorPage, err = h.jobService.GetByCursor(ctx, technologies, filter, cursor, direction)
+		if err != nil {
+			h.logger.Error("Ошибка при получении вакансий по курсору",
+				zap.Error(err),
+				zap.String("technology", technology),
+				zap.String("cursor", cursor.String()),
+			)
+			h.renderError(w, http.StatusInternalServerError, "Ошибка сервера", "Не удалось загрузить список вакансий")
+			return
+		}
+		jobsRaw = cursorPage.Jobs
+	case technology != "":
 		// Получаем вакансии по технологии
-		jobsRaw, pages, err := h.jobService.GetByTechnology(ctx, technology, page)
+		jobsRaw, totalPages, err = h.jobService.GetByTechnology(ctx, technology, filter, page)
 		if err != nil {
 			h.logger.Error("Ошибка при получении вакансий по технологии",
 				zap.Error(err),
@@ -116,18 +156,9 @@ func (h *HomeHandler) renderJobsList(w http.ResponseWriter, r *http.Request, tec
 			h.renderError(w, http.StatusInternalServerError, "Ошибка сервера", "Не удалось загрузить вакансии по выбранной технологии")
 			return
 		}
-
-		totalPages = pages
-
-		// Преобразуем в view-модели
-		jobs = make([]model.JobViewModel, 0, len(jobsRaw))
-		for _, job := range jobsRaw {
-			jobViewModel := model.NewJobViewModelFromEntity(job, job.Slug)
-			jobs = append(jobs, jobViewModel)
-		}
-	} else {
+	default:
 		// Получаем все вакансии
-		jobsRaw, pages, err := h.jobService.GetLatest(ctx, page)
+		jobsRaw, totalPages, err = h.jobService.GetLatest(ctx, filter, page)
 		if err != nil {
 			h.logger.Error("Ошибка при получении последних вакансий",
 				zap.Error(err),
@@ -136,21 +167,169 @@ func (h *HomeHandler) renderJobsList(w http.ResponseWriter, r *http.Request, tec
 			h.renderError(w, http.StatusInternalServerError, "Ошибка сервера", "Не удалось загрузить список вакансий")
 			return
 		}
+	}
 
-		totalPages = pages
+	// Формируем модель представления для списка вакансий
+	viewModel := model.NewJobListViewModel(model.NewJobViewModels(jobsRaw), techViewModels, page, totalPages, technology)
+	viewModel.Filter = filterViewModel
 
-		// Преобразуем в view-модели
-		jobs = make([]model.JobViewModel, 0, len(jobsRaw))
-		for _, job := range jobsRaw {
-			jobViewModel := model.NewJobViewModelFromEntity(job, job.Slug)
-			jobs = append(jobs, jobViewModel)
-		}
+	if cursorMode {
+		viewModel.ApplyCursorPage(cursorPage)
+	} else if page == service.MaxNumberedPages && len(jobsRaw) == service.DefaultPageSize {
+		// С последней нумерованной страницы продолжаем список по курсору
+		viewModel.OlderURL = viewModel.CursorURL(entity.NewJobCursor(jobsRaw[len(jobsRaw)-1]), entity.CursorOlder)
 	}
 
-	// Формируем модель представления для списка вакансий
-	viewModel := model.NewJobListViewModel(jobs, techViewModels, page, totalPages, technology)
+	h.renderHome(w, viewModel)
+}
+
+// Search обрабатывает запрос на страницу полнотекстового поиска вакансий
+func (h *HomeHandler) Search(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	searchQuery := strings.TrimSpace(r.URL.Query().Get("q"))
+	if searchQuery == "" {
+		http.Redirect(w, r, "/", http.StatusFound)
+		return
+	}
+
+	page, ok := parsePageParam(r)
+	if !ok || page > service.MaxNumberedPages {
+		h.renderError(w, http.StatusBadRequest, "Неверный номер страницы", "Указанный номер страницы некорректен")
+		return
+	}
+
+	filter, filterViewModel, err := parseListFilter(r, time.Now())
+	if err != nil {
+		h.renderError(w, http.StatusBadRequest, "Неверный фильтр", "Проверьте параметры фильтра: "+err.Error())
+		return
+	}
+
+	techViewModels, err := h.getTechnologyViewModels(ctx)
+	if err != nil {
+		h.renderError(w, http.StatusInternalServerError, "Ошибка сервера", "Не удалось загрузить список технологий")
+		return
+	}
+
+	jobsRaw, totalPages, err := h.jobService.Search(ctx, searchQuery, filter, page)
+	if err != nil {
+		h.logger.Error("Ошибка при поиске вакансий",
+			zap.Error(err),
+			zap.String("query", searchQuery),
+			zap.Int("page", page),
+		)
+		h.renderError(w, http.StatusInternalServerError, "Ошибка сервера", "Не удалось выполнить поиск вакансий")
+		return
+	}
+
+	viewModel := model.NewSearchListViewModel(model.NewJobViewModels(jobsRaw), techViewModels, page, totalPages, searchQuery)
+	viewModel.Filter = filterViewModel
+
+	h.renderHome(w, viewModel)
+}
+
+// Filter обрабатывает запрос на страницу с вакансиями по нескольким технологиям
+func (h *HomeHandler) Filter(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	requested := r.URL.Query()["tech"]
+	if len(requested) == 0 {
+		http.Redirect(w, r, "/", http.StatusFound)
+		return
+	}
+
+	filter, filterViewModel, err := parseListFilter(r, time.Now())
+	if err != nil {
+		h.renderError(w, http.StatusBadRequest, "Неверный фильтр", "Проверьте параметры фильтра: "+err.Error())
+		return
+	}
+
+	cursor, direction, _, err := parseCursor(r)
+	if err != nil {
+		h.renderError(w, http.StatusBadRequest, "Неверная ссылка", "Ссылка для перехода по страницам некорректна")
+		return
+	}
+
+	// Оставляем только существующие технологии
+	selected, err := h.technologyService.FilterExisting(ctx, requested)
+	if err != nil {
+		h.logger.Error("Ошибка при проверке существования технологий",
+			zap.Error(err),
+			zap.Strings("technologies", requested),
+		)
+		h.renderError(w, http.StatusInternalServerError, "Ошибка сервера", "Не удалось проверить существование технологий")
+		return
+	}
+
+	if len(selected) == 0 {
+		h.renderError(w, http.StatusNotFound, "Технологии не найдены", "Ни одна из запрошенных технологий не существует")
+		return
+	}
+
+	techViewModels, err := h.getTechnologyViewModels(ctx)
+	if err != nil {
+		h.renderError(w, http.StatusInternalServerError, "Ошибка сервера", "Не удалось загрузить список технологий")
+		return
+	}
+	model.MarkSelectedTechnologies(techViewModels, selected)
+
+	// Список по нескольким технологиям не индексируется, поэтому использует только курсорную навигацию
+	cursorPage, err := h.jobService.GetByCursor(ctx, selected, filter, cursor, direction)
+	if err != nil {
+		h.logger.Error("Ошибка при получении вакансий по нескольким технологиям",
+			zap.Error(err),
+			zap.Strings("technologies", selected),
+			zap.String("cursor", cursor.String()),
+		)
+		h.renderError(w, http.StatusInternalServerError, "Ошибка сервера", "Не удалось загрузить вакансии по выбранным технологиям")
+		return
+	}
+
+	viewModel := model.NewMultiTechnologyListViewModel(model.NewJobViewModels(cursorPage.Jobs), techViewModels, 1, 0, selected)
+	viewModel.Filter = filterViewModel
+	viewModel.ApplyCursorPage(cursorPage)
+
+	h.renderHome(w, viewModel)
+}
+
+// parsePageParam извлекает номер страницы из query-параметра page.
+// Первая страница в пагинации передается с пустым параметром
+func parsePageParam(r *http.Request) (int, bool) {
+	pageStr := r.URL.Query().Get("page")
+	if pageStr == "" {
+		return 1, true
+	}
+
+	page, err := strconv.Atoi(pageStr)
+	if err != nil || page < 1 {
+		return 0, false
+	}
+
+	return page, true
+}
+
+// getTechnologyViewModels возвращает список технологий для меню в виде view-моделей
+func (h *HomeHandler) getTechnologyViewModels(ctx context.Context) ([]model.TechnologyViewModel, error) {
+	technologies, err := h.technologyService.GetAll(ctx)
+	if err != nil {
+		h.logger.Error("Ошибка при получении списка технологий",
+			zap.Error(err),
+		)
+		return nil, err
+	}
+
+	// Преобразуем в view-модели для шаблона
+	techViewModels := make([]model.TechnologyViewModel, 0, len(technologies))
+	for _, tech := range technologies {
+		techViewModel := model.NewTechnologyViewModelFromEntity(tech)
+		techViewModels = append(techViewModels, techViewModel)
+	}
+
+	return techViewModels, nil
+}
 
-	// Отображаем страницу
+// renderHome отображает страницу со списком вакансий
+func (h *HomeHandler) renderHome(w http.ResponseWriter, viewModel model.JobListViewModel) {
 	if err := h.templates.Render(w, "pages/home.html", viewModel); err != nil {
 		h.logger.Error("Ошибка при рендеринге шаблона home.html",
 			zap.Error(err),
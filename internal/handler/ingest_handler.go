@@ -0,0 +1,251 @@
+package handler
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"html"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/zalhonan/remotejobs-site/internal/domain/content"
+	"github.com/zalhonan/remotejobs-site/internal/domain/entity"
+	"github.com/zalhonan/remotejobs-site/internal/domain/service"
+	"github.com/zalhonan/remotejobs-site/internal/view/model"
+	"go.uber.org/zap"
+)
+
+// Заголовки запроса API загрузки вакансий
+const (
+	headerIngestClient    = "X-Ingest-Client"
+	headerIngestTimestamp = "X-Ingest-Timestamp"
+	headerIngestSignature = "X-Ingest-Signature"
+)
+
+const (
+	// maxIngestBodySize максимальный размер тела запроса API загрузки
+	maxIngestBodySize = 2 << 20
+
+	// maxIngestItems максимальное количество вакансий в одном запросе
+	maxIngestItems = 100
+
+	// maxIngestContentSize максимальный размер текста одной вакансии
+	maxIngestContentSize = 100 << 10
+
+	// maxSourceLinkLength максимальная длина ссылки на источник (размер колонки jobs_raw.source_link)
+	maxSourceLinkLength = 2048
+
+	// maxIngestFutureSkew насколько дата публикации может опережать время сервера
+	maxIngestFutureSkew = time.Hour
+)
+
+type IngestHandler struct {
+	ingestService *service.IngestService
+	clientService *service.IngestClientService
+	logger        *zap.Logger
+}
+
+// NewIngestHandler создает новый обработчик API загрузки вакансий внешними парсерами
+func NewIngestHandler(
+	ingestService *service.IngestService,
+	clientService *service.IngestClientService,
+	logger *zap.Logger,
+) *IngestHandler {
+	return &IngestHandler{
+		ingestService: ingestService,
+		clientService: clientService,
+		logger:        logger,
+	}
+}
+
+// Ingest принимает вакансии от внешнего парсера. Запрос подписывается секретом клиента
+// (см. service.IngestSignature), тело - объект {"items": [...]}, массив вакансий или одна вакансия.
+// Вакансии проверяются и сохраняются по отдельности, в ответе итог по каждой вакансии
+func (h *IngestHandler) Ingest(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxIngestBodySize))
+	if err != nil {
+		var maxBytesErr *http.MaxBytesError
+		if errors.As(err, &maxBytesErr) {
+			h.writeError(w, http.StatusRequestEntityTooLarge, fmt.Sprintf("тело запроса больше %d байт", maxIngestBodySize))
+			return
+		}
+		h.writeError(w, http.StatusBadRequest, "не удалось прочитать тело запроса")
+		return
+	}
+
+	now := time.Now()
+	client, err := h.clientService.Authenticate(ctx,
+		r.Header.Get(headerIngestClient),
+		r.Header.Get(headerIngestTimestamp),
+		r.Header.Get(headerIngestSignature),
+		body,
+		now,
+	)
+	if err != nil {
+		h.writeError(w, http.StatusUnauthorized, err.Error())
+		return
+	}
+
+	requested, err := parseIngestRequest(body)
+	if err != nil {
+		h.writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	// Проверяем каждую вакансию, сохраняем только прошедшие проверку
+	response := model.IngestAPIResponse{Results: make([]model.IngestItemAPIResult, 0, len(requested))}
+	results := make([]model.IngestItemAPIResult, len(requested))
+	items := make([]entity.SourceItem, 0, len(requested))
+	indexes := make([]int, 0, len(requested))
+	for i, requestedItem := range requested {
+		item, err := parseIngestItem(requestedItem, now)
+		if err != nil {
+			results[i] = model.IngestItemAPIResult{Index: i, Status: model.IngestStatusInvalid, Error: err.Error()}
+			continue
+		}
+		items = append(items, item)
+		indexes = append(indexes, i)
+	}
+
+	saved, err := h.ingestService.IngestItems(ctx, items)
+	if err != nil && len(saved) == 0 {
+		h.logger.Error("Ошибка при загрузке вакансий через API",
+			zap.Error(err),
+			zap.String("client", client.Name),
+		)
+		h.writeError(w, http.StatusInternalServerError, "не удалось загрузить вакансии")
+		return
+	}
+
+	for j, index := range indexes {
+		if j < len(saved) {
+			results[index] = model.NewIngestItemAPIResult(index, saved[j].Job, saved[j].Inserted, saved[j].Err)
+		} else {
+			// Запрос прерван до сохранения вакансии
+			results[index] = model.NewIngestItemAPIResult(index, entity.JobRaw{}, false, err)
+		}
+	}
+	for _, result := range results {
+		response.Add(result)
+	}
+
+	h.clientService.RecordRequest(ctx, client, len(requested))
+
+	h.logger.Info("Вакансии загружены через API",
+		zap.String("client", client.Name),
+		zap.Int("items", len(requested)),
+		zap.Int("created", response.Created),
+		zap.Int("exists", response.Exists),
+		zap.Int("failed", response.Failed),
+	)
+
+	h.writeJSON(w, http.StatusOK, response)
+}
+
+// parseIngestRequest разбирает тело запроса: объект {"items": [...]}, массив вакансий или одну вакансию
+func parseIngestRequest(body []byte) ([]model.IngestItemAPIRequest, error) {
+	trimmed := bytes.TrimSpace(body)
+	if len(trimmed) == 0 {
+		return nil, errors.New("пустое тело запроса")
+	}
+
+	var items []model.IngestItemAPIRequest
+	switch trimmed[0] {
+	case '[':
+		if err := json.Unmarshal(trimmed, &items); err != nil {
+			return nil, fmt.Errorf("некорректный JSON: %w", err)
+		}
+	case '{':
+		var request struct {
+			model.IngestAPIRequest
+			model.IngestItemAPIRequest
+		}
+		if err := json.Unmarshal(trimmed, &request); err != nil {
+			return nil, fmt.Errorf("некорректный JSON: %w", err)
+		}
+		items = request.Items
+		if items == nil {
+			items = []model.IngestItemAPIRequest{request.IngestItemAPIRequest}
+		}
+	default:
+		return nil, errors.New("тело запроса должно быть JSON-объектом или массивом")
+	}
+
+	if len(items) == 0 {
+		return nil, errors.New("в запросе нет вакансий")
+	}
+	if len(items) > maxIngestItems {
+		return nil, fmt.Errorf("в запросе больше %d вакансий", maxIngestItems)
+	}
+
+	return items, nil
+}
+
+// parseIngestItem проверяет вакансию из запроса и преобразует её в публикацию источника.
+// Недостающий текст без разметки формируется из HTML, недостающий HTML - из текста
+func parseIngestItem(item model.IngestItemAPIRequest, now time.Time) (entity.SourceItem, error) {
+	sourceLink := strings.TrimSpace(item.SourceLink)
+	if sourceLink == "" {
+		return entity.SourceItem{}, errors.New("не указана ссылка на источник (source_link)")
+	}
+	if len(sourceLink) > maxSourceLinkLength {
+		return entity.SourceItem{}, fmt.Errorf("ссылка на источник длиннее %d символов", maxSourceLinkLength)
+	}
+	parsedLink, err := url.Parse(sourceLink)
+	if err != nil || (parsedLink.Scheme != "http" && parsedLink.Scheme != "https") || parsedLink.Host == "" {
+		return entity.SourceItem{}, errors.New("ссылка на источник должна быть абсолютным адресом http(s)")
+	}
+
+	if len(item.Content)+len(item.Text) > maxIngestContentSize {
+		return entity.SourceItem{}, fmt.Errorf("текст вакансии больше %d байт", maxIngestContentSize)
+	}
+
+	contentHTML := strings.TrimSpace(item.Content)
+	text := strings.TrimSpace(item.Text)
+	if text == "" {
+		text = content.PlainText(contentHTML)
+	}
+	if text == "" {
+		return entity.SourceItem{}, errors.New("не указан текст вакансии (content или text)")
+	}
+	if contentHTML == "" {
+		contentHTML = strings.ReplaceAll(html.EscapeString(text), "\n", "<br>")
+	}
+
+	var datePosted time.Time
+	if item.DatePosted != nil {
+		datePosted = *item.DatePosted
+		if datePosted.After(now.Add(maxIngestFutureSkew)) {
+			return entity.SourceItem{}, errors.New("дата публикации (date_posted) в будущем")
+		}
+	}
+
+	return entity.SourceItem{
+		Title:      strings.TrimSpace(item.Title),
+		HTML:       contentHTML,
+		Text:       text,
+		Link:       parsedLink.String(),
+		DatePosted: datePosted,
+	}, nil
+}
+
+// writeJSON отправляет ответ в формате JSON
+func (h *IngestHandler) writeJSON(w http.ResponseWriter, statusCode int, data interface{}) {
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.WriteHeader(statusCode)
+
+	if err := json.NewEncoder(w).Encode(data); err != nil {
+		h.logger.Error("Ошибка при записи JSON-ответа", zap.Error(err))
+	}
+}
+
+// writeError отправляет ошибку в формате JSON
+func (h *IngestHandler) writeError(w http.ResponseWriter, statusCode int, message string) {
+	h.writeJSON(w, statusCode, map[string]string{"error": message})
+}
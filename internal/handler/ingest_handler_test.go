@@ -0,0 +1,122 @@
+package handler
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/zalhonan/remotejobs-site/internal/view/model"
+)
+
+func TestParseIngestRequest(t *testing.T) {
+	item := `{"source_link": "https://board.example/jobs/1", "text": "Go developer"}`
+
+	tests := []struct {
+		name  string
+		body  string
+		items int
+		ok    bool
+	}{
+		{"объект с вакансиями", `{"items": [` + item + `, ` + item + `]}`, 2, true},
+		{"массив вакансий", `[` + item + `]`, 1, true},
+		{"одна вакансия", item, 1, true},
+		{"пустое тело", "", 0, false},
+		{"тело из пробелов", " \n\t", 0, false},
+		{"пустой список вакансий", `{"items": []}`, 0, false},
+		{"пустой массив", `[]`, 0, false},
+		{"не JSON", `source_link=https://board.example/jobs/1`, 0, false},
+		{"некорректный JSON", `{"items": [`, 0, false},
+		{"слишком много вакансий", `[` + strings.TrimSuffix(strings.Repeat(item+",", maxIngestItems+1), ",") + `]`, 0, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			items, err := parseIngestRequest([]byte(tt.body))
+			if (err == nil) != tt.ok {
+				t.Fatalf("получена ошибка %v, ожидался успех: %v", err, tt.ok)
+			}
+			if len(items) != tt.items {
+				t.Errorf("получено вакансий: %d, ожидалось %d", len(items), tt.items)
+			}
+		})
+	}
+}
+
+func TestParseIngestItem(t *testing.T) {
+	now := time.Date(2025, 6, 15, 12, 0, 0, 0, time.UTC)
+	at := func(offset time.Duration) *time.Time {
+		date := now.Add(offset)
+		return &date
+	}
+
+	tests := []struct {
+		name     string
+		item     model.IngestItemAPIRequest
+		ok       bool
+		wantHTML string
+		wantText string
+	}{
+		{
+			name:     "только HTML",
+			item:     model.IngestItemAPIRequest{SourceLink: "https://board.example/jobs/1", Content: "<p>Go <b>developer</b></p>"},
+			ok:       true,
+			wantHTML: "<p>Go <b>developer</b></p>",
+			wantText: "Go developer",
+		},
+		{
+			name:     "только текст",
+			item:     model.IngestItemAPIRequest{SourceLink: "https://board.example/jobs/1", Text: "Go & Rust\nудаленно"},
+			ok:       true,
+			wantHTML: "Go &amp; Rust<br>удаленно",
+			wantText: "Go & Rust\nудаленно",
+		},
+		{
+			name: "дата публикации в пределах допуска",
+			item: model.IngestItemAPIRequest{SourceLink: "https://board.example/jobs/1", Text: "Go", DatePosted: at(30 * time.Minute)},
+			ok:   true,
+		},
+		{
+			name: "дата публикации в будущем",
+			item: model.IngestItemAPIRequest{SourceLink: "https://board.example/jobs/1", Text: "Go", DatePosted: at(2 * time.Hour)},
+		},
+		{
+			name: "нет ссылки",
+			item: model.IngestItemAPIRequest{Text: "Go"},
+		},
+		{
+			name: "относительная ссылка",
+			item: model.IngestItemAPIRequest{SourceLink: "/jobs/1", Text: "Go"},
+		},
+		{
+			name: "ссылка не http",
+			item: model.IngestItemAPIRequest{SourceLink: "ftp://board.example/jobs/1", Text: "Go"},
+		},
+		{
+			name: "слишком длинная ссылка",
+			item: model.IngestItemAPIRequest{SourceLink: "https://board.example/" + strings.Repeat("a", maxSourceLinkLength), Text: "Go"},
+		},
+		{
+			name: "нет текста",
+			item: model.IngestItemAPIRequest{SourceLink: "https://board.example/jobs/1", Content: "<p> </p>"},
+		},
+		{
+			name: "слишком длинный текст",
+			item: model.IngestItemAPIRequest{SourceLink: "https://board.example/jobs/1", Text: strings.Repeat("a", maxIngestContentSize+1)},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			item, err := parseIngestItem(tt.item, now)
+			if (err == nil) != tt.ok {
+				t.Fatalf("получена ошибка %v, ожидался успех: %v", err, tt.ok)
+			}
+			if tt.wantHTML != "" && item.HTML != tt.wantHTML {
+				t.Errorf("HTML %q, ожидался %q", item.HTML, tt.wantHTML)
+			}
+			if tt.wantText != "" && item.Text != tt.wantText {
+				t.Errorf("текст %q, ожидался %q", item.Text, tt.wantText)
+			}
+		})
+	}
+}
@@ -6,6 +6,7 @@ import (
 	"strconv"
 	"strings"
 
+	"github.com/zalhonan/remotejobs-site/internal/domain/entity"
 	"github.com/zalhonan/remotejobs-site/internal/domain/service"
 	"github.com/zalhonan/remotejobs-site/internal/view/model"
 	"go.uber.org/zap"
@@ -76,6 +77,12 @@ func (h *JobHandler) Details(w http.ResponseWriter, r *http.Request, urlPath str
 	ctx := r.Context()
 	job, err := h.jobService.GetByID(ctx, jobID)
 	if err != nil {
+		// Адрес удаленного повтора ведет на оставленную вакансию
+		if redirectID, ok := h.jobService.GetRedirect(ctx, jobID); ok {
+			http.Redirect(w, r, "/job/"+strconv.FormatInt(redirectID, 10), http.StatusMovedPermanently)
+			return
+		}
+
 		h.logger.Error("Ошибка при получении вакансии по ID",
 			zap.Error(err),
 			zap.Int64("jobId", jobID),
@@ -84,12 +91,38 @@ func (h *JobHandler) Details(w http.ResponseWriter, r *http.Request, urlPath str
 		return
 	}
 
+	// Удаленная по возрасту или администратором вакансия больше не показывается, поисковики должны убрать её из индекса
+	if job.Status == entity.JobStatusRemoved {
+		h.renderError(w, http.StatusGone, "Вакансия удалена", "Вакансия больше не актуальна и удалена с сайта")
+		return
+	}
+
 	// Преобразуем в view-модель
 	jobViewModel := model.NewJobViewModelFromEntity(job, job.Slug)
 
-	// Получаем похожие вакансии (например, с той же технологией)
-	// Для простоты используем пустой массив
+	// Получаем похожие вакансии. Без них страница все равно полезна, поэтому ошибку только логируем
 	relatedJobs := []model.JobViewModel{}
+	related, err := h.jobService.GetRelated(ctx, job)
+	if err != nil {
+		h.logger.Error("Ошибка при получении похожих вакансий",
+			zap.Error(err),
+			zap.Int64("jobId", jobID),
+		)
+	} else {
+		relatedJobs = model.NewJobViewModels(related)
+	}
+
+	// Получаем публикации этой же вакансии в других источниках. Ошибку только логируем
+	alsoPosted := []model.JobSourceViewModel{}
+	duplicates, err := h.jobService.GetDuplicates(ctx, job)
+	if err != nil {
+		h.logger.Error("Ошибка при получении повторов вакансии",
+			zap.Error(err),
+			zap.Int64("jobId", jobID),
+		)
+	} else {
+		alsoPosted = model.NewJobSourceViewModels(duplicates)
+	}
 
 	// Получаем список всех технологий для меню
 	technologies, err := h.technologyService.GetAll(ctx)
@@ -112,9 +145,12 @@ func (h *JobHandler) Details(w http.ResponseWriter, r *http.Request, urlPath str
 	viewModel := model.JobDetailViewModel{
 		JobViewModel:    jobViewModel,
 		RelatedJobs:     relatedJobs,
+		AlsoPosted:      alsoPosted,
 		PageTitle:       jobViewModel.Title,           // Используем заголовок вакансии в качестве заголовка страницы
 		Technologies:    techViewModels,               // Добавляем список технологий для меню
 		MetaDescription: jobViewModel.MetaDescription, // Используем мета-описание из модели вакансии
+		StatusNotice:    model.JobStatusNotice(job.Status),
+		NoIndex:         job.Status == entity.JobStatusArchived,
 	}
 
 	// Отображаем страницу
@@ -0,0 +1,244 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/zalhonan/remotejobs-site/internal/domain/entity"
+	"github.com/zalhonan/remotejobs-site/internal/view/model"
+)
+
+// errInvalidPostedFilter ошибка разбора фильтра по дате публикации
+var errInvalidPostedFilter = errors.New("некорректный фильтр по дате публикации")
+
+// errInvalidSalaryFilter ошибка разбора фильтра по зарплате
+var errInvalidSalaryFilter = errors.New("некорректный фильтр по зарплате")
+
+// errInvalidSeniorityFilter ошибка разбора фильтра по уровню позиции
+var errInvalidSeniorityFilter = errors.New("некорректный фильтр по уровню позиции")
+
+// errInvalidLocationFilter ошибка разбора фильтра по месту работы
+var errInvalidLocationFilter = errors.New("некорректный фильтр по месту работы")
+
+// errInvalidCursor ошибка разбора курсора навигации
+var errInvalidCursor = errors.New("некорректный курсор навигации")
+
+// parseCursor разбирает курсор навигации "новее/старее" из параметров before и after.
+// Возвращает false, если курсор в запросе не передан
+func parseCursor(r *http.Request) (entity.JobCursor, entity.CursorDirection, bool, error) {
+	values := r.URL.Query()
+	before, after := values.Get("before"), values.Get("after")
+
+	switch {
+	case before != "" && after != "":
+		return entity.JobCursor{}, entity.CursorOlder, false, errInvalidCursor
+	case before != "":
+		cursor, err := entity.ParseJobCursor(before)
+		if err != nil {
+			return entity.JobCursor{}, entity.CursorOlder, false, errInvalidCursor
+		}
+		return cursor, entity.CursorOlder, true, nil
+	case after != "":
+		cursor, err := entity.ParseJobCursor(after)
+		if err != nil {
+			return entity.JobCursor{}, entity.CursorOlder, false, errInvalidCursor
+		}
+		return cursor, entity.CursorNewer, true, nil
+	}
+
+	return entity.JobCursor{}, entity.CursorOlder, false, nil
+}
+
+// parseListFilter разбирает фильтры списка вакансий из параметров запроса
+func parseListFilter(r *http.Request, now time.Time) (entity.JobFilter, model.ListFilterViewModel, error) {
+	filter, postedFilter, err := parsePostedFilter(r, now)
+	if err != nil {
+		return entity.JobFilter{}, model.ListFilterViewModel{}, err
+	}
+
+	salaryFilter, err := parseSalaryFilter(r, &filter)
+	if err != nil {
+		return entity.JobFilter{}, model.ListFilterViewModel{}, err
+	}
+
+	seniority, err := parseSeniorityFilter(r)
+	if err != nil {
+		return entity.JobFilter{}, model.ListFilterViewModel{}, err
+	}
+	filter.Seniority = seniority
+
+	location, country, err := parseLocationFilter(r)
+	if err != nil {
+		return entity.JobFilter{}, model.ListFilterViewModel{}, err
+	}
+	filter.Location = location
+	filter.Country = country
+
+	// Скрытые стоп-словами вакансии показываются только по явному запросу
+	filter.ShowHidden = r.URL.Query().Get("hidden") == "1"
+
+	viewModel := model.ListFilterViewModel{
+		Posted:     postedFilter,
+		Salary:     salaryFilter,
+		Seniority:  seniority,
+		Location:   location,
+		Country:    country,
+		ShowHidden: filter.ShowHidden,
+	}
+
+	return filter, viewModel, nil
+}
+
+// parsePostedFilter разбирает фильтр "опубликовано за" из параметров запроса:
+// posted=24h|3d|7d|30d или posted=custom&from=ГГГГ-ММ-ДД&to=ГГГГ-ММ-ДД
+func parsePostedFilter(r *http.Request, now time.Time) (entity.JobFilter, model.PostedFilterViewModel, error) {
+	values := r.URL.Query()
+	posted := values.Get("posted")
+
+	if posted == "" {
+		return entity.JobFilter{}, model.PostedFilterViewModel{}, nil
+	}
+
+	if option, ok := model.FindPostedOption(posted); ok {
+		filter := entity.JobFilter{PostedFrom: now.Add(-option.Period)}
+		return filter, model.PostedFilterViewModel{Value: option.Value}, nil
+	}
+
+	if posted != model.PostedCustom {
+		return entity.JobFilter{}, model.PostedFilterViewModel{}, errInvalidPostedFilter
+	}
+
+	// Произвольный период может быть старше срока актуальности вакансий, поэтому в нем показываются и устаревшие
+	filter := entity.JobFilter{IncludeExpired: true}
+	viewModel := model.PostedFilterViewModel{Value: model.PostedCustom}
+
+	if fromStr := values.Get("from"); fromStr != "" {
+		from, err := time.ParseInLocation(model.PostedDateLayout, fromStr, now.Location())
+		if err != nil {
+			return entity.JobFilter{}, model.PostedFilterViewModel{}, errInvalidPostedFilter
+		}
+		filter.PostedFrom = from
+		viewModel.From = fromStr
+	}
+
+	if toStr := values.Get("to"); toStr != "" {
+		to, err := time.ParseInLocation(model.PostedDateLayout, toStr, now.Location())
+		if err != nil {
+			return entity.JobFilter{}, model.PostedFilterViewModel{}, errInvalidPostedFilter
+		}
+		// Дата окончания включается в период целиком
+		filter.PostedTo = to.AddDate(0, 0, 1)
+		viewModel.To = toStr
+	}
+
+	if !filter.PostedFrom.IsZero() && !filter.PostedTo.IsZero() && !filter.PostedFrom.Before(filter.PostedTo) {
+		return entity.JobFilter{}, model.PostedFilterViewModel{}, errInvalidPostedFilter
+	}
+
+	// Произвольный период без дат не ограничивает список
+	if viewModel.From == "" && viewModel.To == "" {
+		return entity.JobFilter{}, model.PostedFilterViewModel{}, nil
+	}
+
+	return filter, viewModel, nil
+}
+
+// parseSalaryFilter разбирает фильтр по зарплате в месяц из параметров запроса:
+// salary_from, salary_to и currency. Без указания валюты суммы считаются в рублях,
+// одна валюта без сумм оставляет только вакансии с зарплатой в этой валюте
+func parseSalaryFilter(r *http.Request, filter *entity.JobFilter) (model.SalaryFilterViewModel, error) {
+	values := r.URL.Query()
+	fromStr, toStr, currency := values.Get("salary_from"), values.Get("salary_to"), values.Get("currency")
+
+	if fromStr == "" && toStr == "" && currency == "" {
+		return model.SalaryFilterViewModel{}, nil
+	}
+
+	if currency == "" {
+		currency = model.SalaryCurrencies[0].Code
+	}
+	if _, ok := model.FindSalaryCurrency(currency); !ok {
+		return model.SalaryFilterViewModel{}, errInvalidSalaryFilter
+	}
+
+	parseAmount := func(value string) (int64, error) {
+		if value == "" {
+			return 0, nil
+		}
+		amount, err := strconv.ParseInt(value, 10, 64)
+		if err != nil || amount < 0 {
+			return 0, errInvalidSalaryFilter
+		}
+		return amount, nil
+	}
+
+	from, err := parseAmount(fromStr)
+	if err != nil {
+		return model.SalaryFilterViewModel{}, err
+	}
+	to, err := parseAmount(toStr)
+	if err != nil {
+		return model.SalaryFilterViewModel{}, err
+	}
+	if from > 0 && to > 0 && from > to {
+		return model.SalaryFilterViewModel{}, errInvalidSalaryFilter
+	}
+
+	filter.SalaryCurrency = currency
+	filter.SalaryFrom = from
+	filter.SalaryTo = to
+
+	return model.SalaryFilterViewModel{From: fromStr, To: toStr, Currency: currency}, nil
+}
+
+// parseSeniorityFilter разбирает фильтр по уровню позиции из параметров level.
+// Уровни возвращаются без повторов в порядке возрастания
+func parseSeniorityFilter(r *http.Request) ([]string, error) {
+	requested := make(map[string]bool)
+	for _, level := range r.URL.Query()["level"] {
+		if _, ok := model.FindSeniorityOption(level); !ok {
+			return nil, errInvalidSeniorityFilter
+		}
+		requested[level] = true
+	}
+
+	var levels []string
+	for _, level := range entity.SeniorityLevels {
+		if requested[level] {
+			levels = append(levels, level)
+		}
+	}
+	return levels, nil
+}
+
+// parseLocationFilter разбирает фильтр по ограничениям места работы из параметров location
+// и страну, из которой нужно работать, из параметра country
+func parseLocationFilter(r *http.Request) ([]string, string, error) {
+	values := r.URL.Query()
+
+	requested := make(map[string]bool)
+	for _, value := range values["location"] {
+		if _, ok := model.FindLocationOption(value); !ok {
+			return nil, "", errInvalidLocationFilter
+		}
+		requested[value] = true
+	}
+
+	var location []string
+	for _, value := range entity.LocationConstraints {
+		if requested[value] {
+			location = append(location, value)
+		}
+	}
+
+	country := values.Get("country")
+	if country != "" {
+		if _, ok := model.FindCountryOption(country); !ok {
+			return nil, "", errInvalidLocationFilter
+		}
+	}
+
+	return location, country, nil
+}
@@ -34,6 +34,9 @@ func NewTemplateRenderer(templateDir, baseTemplate string, logger *zap.Logger) (
 	return renderer, nil
 }
 
+// adminBaseTemplate базовый шаблон страниц админки
+const adminBaseTemplate = "admin/layout.html"
+
 // precompileTemplates предварительно компилирует шаблоны при инициализации
 func (tr *TemplateRenderer) precompileTemplates() error {
 	// Шаблоны для страниц
@@ -50,8 +53,34 @@ func (tr *TemplateRenderer) precompileTemplates() error {
 		"layout/components/pagination.html",
 	}
 
+	if err := tr.compilePages(tr.baseTemplate, commonTemplates, pageTemplates); err != nil {
+		return err
+	}
+
+	// Страницы админки используют свой базовый шаблон без меню технологий
+	adminTemplates := []string{
+		"admin/error.html",
+		"admin/technologies.html",
+		"admin/technology_form.html",
+		"admin/moderation.html",
+		"admin/stop_words.html",
+		"admin/channels.html",
+		"admin/jobs.html",
+		"admin/job.html",
+	}
+
+	// Общие компоненты админки
+	adminComponents := []string{
+		"admin/components/audit_log.html",
+	}
+
+	return tr.compilePages(adminBaseTemplate, adminComponents, adminTemplates)
+}
+
+// compilePages компилирует шаблоны страниц вместе с базовым шаблоном и общими компонентами
+func (tr *TemplateRenderer) compilePages(baseTemplate string, commonTemplates, pageTemplates []string) error {
 	// Базовый шаблон
-	basePath := filepath.Join(tr.templateDir, tr.baseTemplate)
+	basePath := filepath.Join(tr.templateDir, baseTemplate)
 
 	// Загружаем каждый шаблон страницы
 	for _, page := range pageTemplates {
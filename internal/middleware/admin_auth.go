@@ -0,0 +1,97 @@
+package middleware
+
+import (
+	"crypto/subtle"
+	"net/http"
+	"net/url"
+
+	"go.uber.org/zap"
+)
+
+// AdminAuth - middleware для защиты админки: HTTP Basic-аутентификация и проверка,
+// что изменяющие запросы отправлены со страниц этого же сайта (защита от CSRF)
+type AdminAuth struct {
+	user     string
+	password string
+	logger   *zap.Logger
+}
+
+// NewAdminAuth создает новый middleware админки. Если имя пользователя или пароль не заданы,
+// админка выключена и отвечает 404
+func NewAdminAuth(user, password string, logger *zap.Logger) *AdminAuth {
+	return &AdminAuth{
+		user:     user,
+		password: password,
+		logger:   logger,
+	}
+}
+
+// Enabled сообщает, заданы ли имя пользователя и пароль админки
+func (a *AdminAuth) Enabled() bool {
+	return a.user != "" && a.password != ""
+}
+
+// Middleware возвращает функцию middleware для проверки доступа к админке
+func (a *AdminAuth) Middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !a.Enabled() {
+			http.NotFound(w, r)
+			return
+		}
+
+		// Сравниваем за постоянное время, чтобы по времени ответа нельзя было подобрать пароль
+		user, password, ok := r.BasicAuth()
+		if !ok ||
+			subtle.ConstantTimeCompare([]byte(user), []byte(a.user)) != 1 ||
+			subtle.ConstantTimeCompare([]byte(password), []byte(a.password)) != 1 {
+			w.Header().Set("WWW-Authenticate", `Basic realm="admin", charset="UTF-8"`)
+			http.Error(w, "Требуется авторизация", http.StatusUnauthorized)
+			return
+		}
+
+		if !isSafeMethod(r.Method) && !isSameOrigin(r) {
+			a.logger.Warn("Отклонен запрос к админке с другого сайта",
+				zap.String("method", r.Method),
+				zap.String("path", r.URL.Path),
+				zap.String("origin", r.Header.Get("Origin")),
+				zap.String("referer", r.Referer()),
+			)
+			http.Error(w, "Запрос отправлен с другого сайта", http.StatusForbidden)
+			return
+		}
+
+		// Страницы админки не кэшируются и не индексируются
+		w.Header().Set("Cache-Control", "no-store")
+		w.Header().Set("X-Robots-Tag", "noindex, nofollow")
+
+		next.ServeHTTP(w, r)
+	})
+}
+
+// isSafeMethod сообщает, что метод запроса не изменяет данные
+func isSafeMethod(method string) bool {
+	return method == http.MethodGet || method == http.MethodHead || method == http.MethodOptions
+}
+
+// isSameOrigin проверяет, что запрос отправлен со страницы этого же сайта: по заголовку Sec-Fetch-Site,
+// а если браузер его не передал - по хосту из Origin или Referer. Запрос без этих заголовков отклоняется
+func isSameOrigin(r *http.Request) bool {
+	if site := r.Header.Get("Sec-Fetch-Site"); site != "" {
+		return site == "same-origin"
+	}
+
+	source := r.Header.Get("Origin")
+	if source == "" || source == "null" {
+		source = r.Referer()
+	}
+	if source == "" {
+		return false
+	}
+
+	parsed, err := url.Parse(source)
+	if err != nil {
+		return false
+	}
+
+	return parsed.Host == r.Host
+}
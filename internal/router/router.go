@@ -8,6 +8,7 @@ import (
 	"github.com/go-chi/chi/v5"
 	"github.com/go-chi/chi/v5/middleware"
 	"github.com/zalhonan/remotejobs-site/internal/handler"
+	adminmiddleware "github.com/zalhonan/remotejobs-site/internal/middleware"
 	"go.uber.org/zap"
 )
 
@@ -15,6 +16,10 @@ import (
 func NewRouter(
 	homeHandler *handler.HomeHandler,
 	jobHandler *handler.JobHandler,
+	apiHandler *handler.APIHandler,
+	ingestHandler *handler.IngestHandler,
+	adminHandler *handler.AdminHandler,
+	adminAuth *adminmiddleware.AdminAuth,
 	logger *zap.Logger,
 ) http.Handler {
 	r := chi.NewRouter()
@@ -34,6 +39,60 @@ func NewRouter(
 	// Маршруты
 	r.Get("/", homeHandler.Index)
 
+	// Полнотекстовый поиск вакансий
+	r.Get("/search", homeHandler.Search)
+
+	// Фильтр по нескольким технологиям
+	r.Get("/filter", homeHandler.Filter)
+
+	// JSON API со списком вакансий и курсорной пагинацией
+	r.Get("/api/jobs", apiHandler.Jobs)
+
+	// Загрузка вакансий внешними парсерами, запросы подписываются секретом клиента
+	r.Post("/api/ingest", ingestHandler.Ingest)
+
+	// Админка: Basic-аутентификация, изменяющие запросы только со страниц сайта.
+	// Маршрут объявлен до /{page}, чтобы /admin не считался технологией
+	r.Route("/admin", func(r chi.Router) {
+		r.Use(adminAuth.Middleware)
+
+		r.Get("/", adminHandler.Index)
+
+		// Справочник технологий
+		r.Get("/technologies", adminHandler.Technologies)
+		r.Post("/technologies", adminHandler.CreateTechnology)
+		r.Get("/technologies/new", adminHandler.NewTechnology)
+		r.Get("/technologies/{id}", adminHandler.EditTechnology)
+		r.Post("/technologies/{id}", adminHandler.UpdateTechnology)
+		r.Post("/technologies/{id}/delete", adminHandler.DeleteTechnology)
+
+		// Очередь модерации вакансий без технологии и с неуверенно определенной технологией
+		r.Get("/moderation", adminHandler.Moderation)
+		r.Post("/moderation/{id}", adminHandler.ModerateJob)
+
+		// Справочник стоп-слов
+		r.Get("/stop-words", adminHandler.StopWords)
+		r.Post("/stop-words", adminHandler.ImportStopWords)
+		r.Get("/stop-words/export", adminHandler.ExportStopWords)
+		r.Post("/stop-words/{id}/apply", adminHandler.ApplyStopWord)
+		r.Post("/stop-words/{id}/delete", adminHandler.DeleteStopWord)
+
+		// Каналы Telegram и показатели их вакансий
+		r.Get("/channels", adminHandler.Channels)
+		r.Post("/channels", adminHandler.CreateChannel)
+		r.Post("/channels/{id}/pause", adminHandler.PauseChannel)
+		r.Post("/channels/{id}/reset", adminHandler.ResetChannel)
+		r.Post("/channels/{id}/delete", adminHandler.DeleteChannel)
+
+		// Изменение, скрытие и удаление вакансий с журналом изменений
+		r.Get("/jobs", adminHandler.Jobs)
+		r.Get("/jobs/{id}", adminHandler.EditJob)
+		r.Post("/jobs/{id}", adminHandler.UpdateJob)
+		r.Post("/jobs/{id}/hide", adminHandler.HideJob)
+		r.Post("/jobs/{id}/delete", adminHandler.DeleteJob)
+		r.Post("/jobs/{id}/restore", adminHandler.RestoreJob)
+	})
+
 	// Пагинация на главной странице
 	r.Get("/{page}", func(w http.ResponseWriter, r *http.Request) {
 		page := chi.URLParam(r, "page")
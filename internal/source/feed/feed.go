@@ -0,0 +1,123 @@
+// Package feed загружает вакансии из лент RSS 2.0, RSS 1.0 и Atom
+package feed
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"time"
+
+	"github.com/zalhonan/remotejobs-site/internal/domain/entity"
+	"github.com/zalhonan/remotejobs-site/internal/source"
+	"go.uber.org/zap"
+)
+
+const (
+	// Kind тип источника в таблице sources
+	Kind = "rss"
+
+	// requestTimeout ограничение времени одного запроса
+	requestTimeout = 30 * time.Second
+
+	// maxFeedSize максимальный размер ленты, который будет прочитан
+	maxFeedSize = 10 << 20
+
+	// userAgent заголовок User-Agent запросов к лентам
+	userAgent = "Mozilla/5.0 (compatible; RemoteJobsBot/1.0)"
+)
+
+type Feed struct {
+	name       string
+	url        string
+	httpClient *http.Client
+	logger     *zap.Logger
+}
+
+// NewFactory возвращает фабрику адаптеров лент для реестра источников. Адаптеры используют общий HTTP-клиент
+func NewFactory(logger *zap.Logger) source.Factory {
+	httpClient := &http.Client{Timeout: requestTimeout}
+
+	return func(cfg entity.Source) (source.Source, error) {
+		if cfg.URL == "" {
+			return nil, fmt.Errorf("не указан адрес ленты")
+		}
+
+		return &Feed{
+			name:       cfg.Name,
+			url:        cfg.URL,
+			httpClient: httpClient,
+			logger:     logger,
+		}, nil
+	}
+}
+
+// Fetch загружает ленту и возвращает публикации не старше даты из state (RFC3339) в порядке публикации.
+// Публикации с той же датой, что в state, и публикации без даты возвращаются всегда: в ту же секунду могло
+// выйти несколько публикаций, а повторно сохраненные ссылки отбрасываются при сохранении.
+// Новое состояние - дата самой поздней публикации ленты
+func (f *Feed) Fetch(ctx context.Context, state string) ([]entity.SourceItem, string, error) {
+	var since time.Time
+	if state != "" {
+		parsed, err := time.Parse(time.RFC3339, state)
+		if err != nil {
+			return nil, state, fmt.Errorf("некорректное состояние ленты %s: %w", f.name, err)
+		}
+		since = parsed
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, f.url, nil)
+	if err != nil {
+		return nil, state, fmt.Errorf("не удалось создать запрос к %s: %w", f.url, err)
+	}
+	req.Header.Set("User-Agent", userAgent)
+	req.Header.Set("Accept", "application/rss+xml, application/atom+xml, application/xml;q=0.9, */*;q=0.8")
+
+	resp, err := f.httpClient.Do(req)
+	if err != nil {
+		return nil, state, fmt.Errorf("не удалось загрузить %s: %w", f.url, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, state, fmt.Errorf("не удалось загрузить %s: статус %d", f.url, resp.StatusCode)
+	}
+
+	items, err := parseFeed(io.LimitReader(resp.Body, maxFeedSize), resp.Request.URL)
+	if err != nil {
+		return nil, state, fmt.Errorf("не удалось разобрать ленту %s: %w", f.url, err)
+	}
+
+	sort.SliceStable(items, func(i, j int) bool {
+		return items[i].DatePosted.Before(items[j].DatePosted)
+	})
+
+	latest := since
+	filtered := items[:0]
+	for _, item := range items {
+		if item.DatePosted.IsZero() {
+			filtered = append(filtered, item)
+			continue
+		}
+		if item.DatePosted.Before(since) {
+			continue
+		}
+		filtered = append(filtered, item)
+		if item.DatePosted.After(latest) {
+			latest = item.DatePosted
+		}
+	}
+
+	if !latest.IsZero() {
+		state = latest.UTC().Format(time.RFC3339)
+	}
+
+	f.logger.Debug("Загружена лента",
+		zap.String("source", f.name),
+		zap.Int("items", len(filtered)),
+		zap.String("state", state),
+	)
+
+	return filtered, state, nil
+}
@@ -0,0 +1,77 @@
+package feed
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/zalhonan/remotejobs-site/internal/domain/entity"
+	"go.uber.org/zap"
+)
+
+func TestFetch(t *testing.T) {
+	server := httptest.NewServer(http.FileServer(http.Dir("testdata")))
+	defer server.Close()
+
+	source, err := NewFactory(zap.NewNop())(entity.Source{Name: "jobs", URL: server.URL + "/rss2.xml"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Публикация без даты возвращается при каждой загрузке, публикация 2 вышла в 2025-06-03T09:30:00Z
+	const (
+		jobNoDate = "https://jobs.example.com/jobs/5"
+		job2      = "https://jobs.example.com/jobs/2"
+	)
+
+	tests := []struct {
+		name      string
+		state     string
+		wantLinks []string
+		wantState string
+	}{
+		{"первая загрузка", "", []string{jobNoDate, "{feed}/jobs/1", job2}, "2025-06-03T09:30:00Z"},
+		{"публикация в ту же секунду, что в состоянии", "2025-06-03T09:30:00Z", []string{jobNoDate, job2}, "2025-06-03T09:30:00Z"},
+		{"новых публикаций нет", "2025-06-04T00:00:00Z", []string{jobNoDate}, "2025-06-04T00:00:00Z"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			items, state, err := source.Fetch(context.Background(), tt.state)
+			if err != nil {
+				t.Fatalf("Fetch: %v", err)
+			}
+
+			links := make([]string, 0, len(items))
+			for _, item := range items {
+				links = append(links, item.Link)
+			}
+			// Относительная ссылка дополняется адресом ленты
+			wantLinks := make([]string, 0, len(tt.wantLinks))
+			for _, link := range tt.wantLinks {
+				wantLinks = append(wantLinks, strings.ReplaceAll(link, "{feed}", server.URL))
+			}
+
+			if !slices.Equal(links, wantLinks) {
+				t.Errorf("ссылки %v, ожидались %v", links, wantLinks)
+			}
+			if state != tt.wantState {
+				t.Errorf("состояние %q, ожидалось %q", state, tt.wantState)
+			}
+		})
+	}
+}
+
+func TestFetchInvalidState(t *testing.T) {
+	source, err := NewFactory(zap.NewNop())(entity.Source{Name: "jobs", URL: "http://127.0.0.1:0/rss.xml"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, state, err := source.Fetch(context.Background(), "вчера"); err == nil || state != "вчера" {
+		t.Errorf("ожидалась ошибка и прежнее состояние, получено %q, %v", state, err)
+	}
+}
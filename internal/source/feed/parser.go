@@ -0,0 +1,260 @@
+package feed
+
+import (
+	"bytes"
+	"encoding/xml"
+	"errors"
+	"html"
+	"io"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/zalhonan/remotejobs-site/internal/domain/content"
+	"github.com/zalhonan/remotejobs-site/internal/domain/entity"
+	"golang.org/x/net/html/charset"
+)
+
+// rssDocument документ RSS 2.0 (<rss><channel><item>) или RSS 1.0 (<rdf:RDF><item>)
+type rssDocument struct {
+	ChannelItems []rssItem `xml:"channel>item"`
+	Items        []rssItem `xml:"item"`
+}
+
+type rssItem struct {
+	Title       string `xml:"title"`
+	Link        string `xml:"link"`
+	GUID        string `xml:"guid"`
+	Description string `xml:"description"`
+	Encoded     string `xml:"http://purl.org/rss/1.0/modules/content/ encoded"`
+	PubDate     string `xml:"pubDate"`
+	Date        string `xml:"http://purl.org/dc/elements/1.1/ date"`
+}
+
+// atomDocument документ Atom (<feed><entry>)
+type atomDocument struct {
+	Entries []atomEntry `xml:"entry"`
+}
+
+type atomEntry struct {
+	Title     atomText   `xml:"title"`
+	Links     []atomLink `xml:"link"`
+	ID        string     `xml:"id"`
+	Content   atomText   `xml:"content"`
+	Summary   atomText   `xml:"summary"`
+	Published string     `xml:"published"`
+	Updated   string     `xml:"updated"`
+}
+
+type atomLink struct {
+	Href string `xml:"href,attr"`
+	Rel  string `xml:"rel,attr"`
+}
+
+// atomText текстовый элемент Atom. Тип "xhtml" хранит разметку вложенными элементами
+type atomText struct {
+	Type  string `xml:"type,attr"`
+	Text  string `xml:",chardata"`
+	Inner string `xml:",innerxml"`
+}
+
+// dateLayouts форматы дат, встречающиеся в лентах
+var dateLayouts = []string{
+	time.RFC1123Z,
+	time.RFC1123,
+	"Mon, 2 Jan 2006 15:04:05 -0700",
+	"Mon, 2 Jan 2006 15:04:05 MST",
+	time.RFC822Z,
+	time.RFC822,
+	"2 Jan 2006 15:04:05 -0700",
+	"2 Jan 2006 15:04:05 MST",
+	time.RFC3339,
+	"2006-01-02T15:04:05",
+	"2006-01-02",
+}
+
+// parseFeed разбирает ленту RSS или Atom. Публикации без ссылки пропускаются,
+// относительные ссылки дополняются адресом ленты
+func parseFeed(body io.Reader, base *url.URL) ([]entity.SourceItem, error) {
+	data, err := io.ReadAll(body)
+	if err != nil {
+		return nil, err
+	}
+
+	root, err := rootElement(data)
+	if err != nil {
+		return nil, err
+	}
+
+	var items []entity.SourceItem
+	switch root {
+	case "rss", "RDF":
+		var doc rssDocument
+		if err := unmarshal(data, &doc); err != nil {
+			return nil, err
+		}
+		for _, raw := range append(doc.ChannelItems, doc.Items...) {
+			if item, ok := parseRSSItem(raw, base); ok {
+				items = append(items, item)
+			}
+		}
+	case "feed":
+		var doc atomDocument
+		if err := unmarshal(data, &doc); err != nil {
+			return nil, err
+		}
+		for _, raw := range doc.Entries {
+			if item, ok := parseAtomEntry(raw, base); ok {
+				items = append(items, item)
+			}
+		}
+	default:
+		return nil, errors.New("неизвестный формат ленты <" + root + ">")
+	}
+
+	return items, nil
+}
+
+// rootElement возвращает имя корневого элемента документа без пространства имен
+func rootElement(data []byte) (string, error) {
+	decoder := newDecoder(data)
+	for {
+		token, err := decoder.Token()
+		if err != nil {
+			return "", err
+		}
+		if start, ok := token.(xml.StartElement); ok {
+			return start.Name.Local, nil
+		}
+	}
+}
+
+// unmarshal разбирает документ с учетом кодировки из XML-декларации
+func unmarshal(data []byte, v interface{}) error {
+	return newDecoder(data).Decode(v)
+}
+
+func newDecoder(data []byte) *xml.Decoder {
+	decoder := xml.NewDecoder(bytes.NewReader(data))
+	decoder.CharsetReader = charset.NewReaderLabel
+	decoder.Strict = false
+	decoder.Entity = xml.HTMLEntity
+	return decoder
+}
+
+// parseRSSItem разбирает публикацию RSS. Полный текст берется из content:encoded, иначе из description
+func parseRSSItem(raw rssItem, base *url.URL) (entity.SourceItem, bool) {
+	link := strings.TrimSpace(raw.Link)
+	if link == "" && strings.HasPrefix(strings.TrimSpace(raw.GUID), "http") {
+		link = strings.TrimSpace(raw.GUID)
+	}
+
+	link, ok := resolveLink(base, link)
+	if !ok {
+		return entity.SourceItem{}, false
+	}
+
+	body := raw.Encoded
+	if strings.TrimSpace(body) == "" {
+		body = raw.Description
+	}
+
+	date := raw.PubDate
+	if date == "" {
+		date = raw.Date
+	}
+
+	return newItem(content.PlainText(raw.Title), body, link, parseDate(date)), true
+}
+
+// parseAtomEntry разбирает публикацию Atom. Ссылка берется из link rel="alternate" или link без rel
+func parseAtomEntry(raw atomEntry, base *url.URL) (entity.SourceItem, bool) {
+	var link string
+	for _, candidate := range raw.Links {
+		if candidate.Rel == "" || candidate.Rel == "alternate" {
+			link = candidate.Href
+			break
+		}
+	}
+	if link == "" && strings.HasPrefix(strings.TrimSpace(raw.ID), "http") {
+		link = raw.ID
+	}
+
+	link, ok := resolveLink(base, strings.TrimSpace(link))
+	if !ok {
+		return entity.SourceItem{}, false
+	}
+
+	body := atomHTML(raw.Content)
+	if strings.TrimSpace(body) == "" {
+		body = atomHTML(raw.Summary)
+	}
+
+	date := raw.Published
+	if date == "" {
+		date = raw.Updated
+	}
+
+	return newItem(content.PlainText(atomHTML(raw.Title)), body, link, parseDate(date)), true
+}
+
+// atomHTML возвращает HTML текстового элемента Atom в зависимости от его типа
+func atomHTML(text atomText) string {
+	switch text.Type {
+	case "html":
+		return text.Text
+	case "xhtml":
+		return text.Inner
+	default:
+		return strings.ReplaceAll(html.EscapeString(strings.TrimSpace(text.Text)), "\n", "<br>")
+	}
+}
+
+// newItem собирает публикацию из HTML текста
+func newItem(title, body, link string, datePosted time.Time) entity.SourceItem {
+	body = strings.TrimSpace(body)
+
+	return entity.SourceItem{
+		Title:      strings.TrimSpace(title),
+		HTML:       body,
+		Text:       content.PlainText(body),
+		Link:       link,
+		DatePosted: datePosted,
+	}
+}
+
+// resolveLink дополняет относительную ссылку адресом ленты. Принимаются только ссылки http(s)
+func resolveLink(base *url.URL, link string) (string, bool) {
+	if link == "" {
+		return "", false
+	}
+
+	parsed, err := url.Parse(link)
+	if err != nil {
+		return "", false
+	}
+	if base != nil {
+		parsed = base.ResolveReference(parsed)
+	}
+	if parsed.Scheme != "http" && parsed.Scheme != "https" {
+		return "", false
+	}
+
+	return parsed.String(), true
+}
+
+// parseDate разбирает дату публикации. Нулевое значение - дата отсутствует или не распознана
+func parseDate(value string) time.Time {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return time.Time{}
+	}
+
+	for _, layout := range dateLayouts {
+		if parsed, err := time.Parse(layout, value); err == nil {
+			return parsed
+		}
+	}
+
+	return time.Time{}
+}
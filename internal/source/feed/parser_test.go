@@ -0,0 +1,120 @@
+package feed
+
+import (
+	"net/url"
+	"os"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestParseFeed(t *testing.T) {
+	type wantItem struct {
+		title string
+		link  string
+		html  string // Фрагмент HTML публикации
+		date  time.Time
+	}
+
+	tests := []struct {
+		name    string
+		fixture string
+		base    string
+		want    []wantItem
+	}{
+		{"RSS 2.0", "testdata/rss2.xml", "https://jobs.example.com/feeds/rss.xml", []wantItem{
+			{"Go разработчик", "https://jobs.example.com/jobs/1", "<p>Полная удаленка, <b>Go</b> и PostgreSQL</p>",
+				time.Date(2025, 6, 2, 7, 0, 0, 0, time.UTC)},
+			{"Python & Django developer", "https://jobs.example.com/jobs/2", "<p>Django, Celery</p>",
+				time.Date(2025, 6, 3, 9, 30, 0, 0, time.UTC)},
+			{"Без даты", "https://jobs.example.com/jobs/5", "Дата не указана", time.Time{}},
+		}},
+		{"RSS 1.0", "testdata/rss1.xml", "https://board.example.org/feed.rdf", []wantItem{
+			{"Frontend developer", "https://board.example.org/vacancy/10", "React, TypeScript",
+				time.Date(2025, 6, 4, 8, 0, 0, 0, time.UTC)},
+			{"QA engineer", "https://board.example.org/vacancy/11", "Автотесты на Java",
+				time.Date(2025, 6, 5, 0, 0, 0, 0, time.UTC)},
+		}},
+		{"Atom", "testdata/atom.xml", "https://atom.example.net/feeds/atom.xml", []wantItem{
+			{"Rust engineer", "https://atom.example.net/feeds/jobs/20", "<p>Tokio, gRPC</p>",
+				time.Date(2025, 6, 6, 9, 0, 0, 0, time.UTC)},
+			{"DevOps", "https://atom.example.net/jobs/21", "<p>Kubernetes, Terraform</p>",
+				time.Date(2025, 6, 7, 9, 0, 0, 0, time.UTC)},
+			{"Data engineer", "https://atom.example.net/jobs/22", "Airflow &amp; Spark<br>Удаленно",
+				time.Date(2025, 6, 7, 8, 0, 0, 0, time.UTC)},
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			file, err := os.Open(tt.fixture)
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer file.Close()
+
+			base, err := url.Parse(tt.base)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			items, err := parseFeed(file, base)
+			if err != nil {
+				t.Fatalf("parseFeed: %v", err)
+			}
+			if len(items) != len(tt.want) {
+				t.Fatalf("получено публикаций: %d, ожидалось %d: %+v", len(items), len(tt.want), items)
+			}
+
+			for i, want := range tt.want {
+				item := items[i]
+				if item.Title != want.title {
+					t.Errorf("публикация %d: заголовок %q, ожидался %q", i, item.Title, want.title)
+				}
+				if item.Link != want.link {
+					t.Errorf("публикация %d: ссылка %q, ожидалась %q", i, item.Link, want.link)
+				}
+				if !strings.Contains(item.HTML, want.html) {
+					t.Errorf("публикация %d: HTML %q не содержит %q", i, item.HTML, want.html)
+				}
+				if !item.DatePosted.Equal(want.date) {
+					t.Errorf("публикация %d: дата %v, ожидалась %v", i, item.DatePosted, want.date)
+				}
+			}
+		})
+	}
+}
+
+func TestParseFeedUnknownFormat(t *testing.T) {
+	if _, err := parseFeed(strings.NewReader(`<html><body>Not a feed</body></html>`), nil); err == nil {
+		t.Error("ожидалась ошибка для документа, который не является лентой")
+	}
+}
+
+func TestParseDate(t *testing.T) {
+	tests := []struct {
+		value string
+		want  time.Time
+	}{
+		{"Mon, 02 Jun 2025 10:00:00 +0300", time.Date(2025, 6, 2, 7, 0, 0, 0, time.UTC)},
+		{"Mon, 02 Jun 2025 10:00:00 GMT", time.Date(2025, 6, 2, 10, 0, 0, 0, time.UTC)},
+		{"Mon, 2 Jun 2025 10:00:00 +0000", time.Date(2025, 6, 2, 10, 0, 0, 0, time.UTC)},
+		{"02 Jun 25 10:00 +0300", time.Date(2025, 6, 2, 7, 0, 0, 0, time.UTC)},
+		{"2 Jun 2025 10:00:00 -0500", time.Date(2025, 6, 2, 15, 0, 0, 0, time.UTC)},
+		{"2025-06-02T10:00:00+03:00", time.Date(2025, 6, 2, 7, 0, 0, 0, time.UTC)},
+		{"2025-06-02T10:00:00Z", time.Date(2025, 6, 2, 10, 0, 0, 0, time.UTC)},
+		{"2025-06-02T10:00:00", time.Date(2025, 6, 2, 10, 0, 0, 0, time.UTC)},
+		{"2025-06-02", time.Date(2025, 6, 2, 0, 0, 0, 0, time.UTC)},
+		{"  2025-06-02  ", time.Date(2025, 6, 2, 0, 0, 0, 0, time.UTC)},
+		{"вчера", time.Time{}},
+		{"", time.Time{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			if got := parseDate(tt.value); !got.Equal(tt.want) {
+				t.Errorf("parseDate(%q) = %v, ожидалось %v", tt.value, got, tt.want)
+			}
+		})
+	}
+}
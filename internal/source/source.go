@@ -0,0 +1,62 @@
+// Package source описывает адаптеры источников вакансий и реестр адаптеров по типу источника
+package source
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/zalhonan/remotejobs-site/internal/domain/entity"
+)
+
+// Source адаптер источника вакансий
+type Source interface {
+	// Fetch загружает публикации, появившиеся после состояния state, которое вернула предыдущая загрузка
+	// (пустое состояние - первая загрузка). Возвращает публикации от ранних к поздним и новое состояние
+	Fetch(ctx context.Context, state string) ([]entity.SourceItem, string, error)
+}
+
+// Factory создает адаптер по настройкам источника
+type Factory func(cfg entity.Source) (Source, error)
+
+// Registry реестр адаптеров по типу источника
+type Registry struct {
+	factories map[string]Factory
+}
+
+// NewRegistry создает пустой реестр адаптеров
+func NewRegistry() *Registry {
+	return &Registry{
+		factories: make(map[string]Factory),
+	}
+}
+
+// Register регистрирует адаптер для типа источника. Повторная регистрация заменяет адаптер
+func (r *Registry) Register(kind string, factory Factory) {
+	r.factories[kind] = factory
+}
+
+// New создает адаптер для источника по его типу
+func (r *Registry) New(cfg entity.Source) (Source, error) {
+	factory, ok := r.factories[cfg.Kind]
+	if !ok {
+		return nil, fmt.Errorf("неизвестный тип источника %q", cfg.Kind)
+	}
+
+	src, err := factory(cfg)
+	if err != nil {
+		return nil, fmt.Errorf("не удалось создать адаптер источника %s: %w", cfg.Name, err)
+	}
+
+	return src, nil
+}
+
+// Kinds возвращает зарегистрированные типы источников в алфавитном порядке
+func (r *Registry) Kinds() []string {
+	kinds := make([]string, 0, len(r.factories))
+	for kind := range r.factories {
+		kinds = append(kinds, kind)
+	}
+	sort.Strings(kinds)
+	return kinds
+}
@@ -0,0 +1,117 @@
+// Package telegram загружает посты публичных каналов из веб-превью t.me/s/<tag>
+package telegram
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/zalhonan/remotejobs-site/internal/domain/entity"
+	"go.uber.org/zap"
+)
+
+const (
+	// DefaultBaseURL адрес веб-превью Telegram
+	DefaultBaseURL = "https://t.me"
+
+	// requestTimeout ограничение времени одного запроса
+	requestTimeout = 30 * time.Second
+
+	// userAgent заголовок User-Agent запросов к веб-превью
+	userAgent = "Mozilla/5.0 (compatible; RemoteJobsBot/1.0)"
+)
+
+type Client struct {
+	baseURL     string
+	httpClient  *http.Client
+	minInterval time.Duration
+	logger      *zap.Logger
+
+	mu          sync.Mutex
+	lastRequest time.Time
+}
+
+// NewClient создает клиент веб-превью каналов. minInterval - минимальная пауза между запросами,
+// чтобы не получить ограничение со стороны Telegram
+func NewClient(baseURL string, minInterval time.Duration, logger *zap.Logger) *Client {
+	return &Client{
+		baseURL:     strings.TrimRight(baseURL, "/"),
+		httpClient:  &http.Client{Timeout: requestTimeout},
+		minInterval: minInterval,
+		logger:      logger,
+	}
+}
+
+// FetchAfter возвращает страницу постов канала с ID больше afterID в порядке возрастания ID.
+// При afterID = 0 возвращаются последние посты канала
+func (c *Client) FetchAfter(ctx context.Context, tag string, afterID int64) ([]entity.TelegramPost, error) {
+	pageURL := c.baseURL + "/s/" + url.PathEscape(tag)
+	if afterID > 0 {
+		pageURL += "?after=" + strconv.FormatInt(afterID, 10)
+	}
+
+	if err := c.wait(ctx); err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, pageURL, nil)
+	if err != nil {
+		return nil, fmt.Errorf("не удалось создать запрос к %s: %w", pageURL, err)
+	}
+	req.Header.Set("User-Agent", userAgent)
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("не удалось загрузить %s: %w", pageURL, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("не удалось загрузить %s: статус %d", pageURL, resp.StatusCode)
+	}
+
+	posts, err := parsePosts(resp.Body, tag, c.baseURL)
+	if err != nil {
+		return nil, fmt.Errorf("не удалось разобрать страницу %s: %w", pageURL, err)
+	}
+
+	filtered := posts[:0]
+	for _, post := range posts {
+		if post.ID > afterID {
+			filtered = append(filtered, post)
+		}
+	}
+
+	c.logger.Debug("Загружена страница канала",
+		zap.String("channel", tag),
+		zap.Int64("afterId", afterID),
+		zap.Int("posts", len(filtered)),
+	)
+
+	return filtered, nil
+}
+
+// wait выдерживает паузу между запросами
+func (c *Client) wait(ctx context.Context) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if delay := time.Until(c.lastRequest.Add(c.minInterval)); delay > 0 {
+		timer := time.NewTimer(delay)
+		defer timer.Stop()
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-timer.C:
+		}
+	}
+
+	c.lastRequest = time.Now()
+	return nil
+}
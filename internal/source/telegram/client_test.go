@@ -0,0 +1,133 @@
+package telegram
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/zalhonan/remotejobs-site/internal/domain/entity"
+	"go.uber.org/zap"
+)
+
+func TestFetchAfter(t *testing.T) {
+	page, err := os.ReadFile("testdata/channel_page.html")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var requests []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, r.URL.RequestURI())
+		_, _ = w.Write(page)
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL+"/", 0, zap.NewNop())
+
+	tests := []struct {
+		name        string
+		afterID     int64
+		wantRequest string
+		wantIDs     []int64
+	}{
+		{"последние посты", 0, "/s/golangjobs", []int64{98, 99, 100, 101}},
+		{"посты после ID", 99, "/s/golangjobs?after=99", []int64{100, 101}},
+		{"новых постов нет", 101, "/s/golangjobs?after=101", []int64{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			requests = nil
+
+			posts, err := client.FetchAfter(context.Background(), "golangjobs", tt.afterID)
+			if err != nil {
+				t.Fatalf("FetchAfter: %v", err)
+			}
+
+			if !slices.Equal(requests, []string{tt.wantRequest}) {
+				t.Errorf("запросы %v, ожидался %s", requests, tt.wantRequest)
+			}
+
+			ids := make([]int64, 0, len(posts))
+			for _, post := range posts {
+				ids = append(ids, post.ID)
+			}
+			if !slices.Equal(ids, tt.wantIDs) {
+				t.Errorf("ID постов %v, ожидались %v", ids, tt.wantIDs)
+			}
+		})
+	}
+}
+
+func TestFetchAfterStatusError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusTooManyRequests)
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, 0, zap.NewNop())
+	if _, err := client.FetchAfter(context.Background(), "golangjobs", 0); err == nil {
+		t.Error("ожидалась ошибка при статусе 429")
+	}
+}
+
+func TestParsePosts(t *testing.T) {
+	page, err := os.Open("testdata/channel_page.html")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer page.Close()
+
+	posts, err := parsePosts(page, "golangjobs", DefaultBaseURL)
+	if err != nil {
+		t.Fatalf("parsePosts: %v", err)
+	}
+
+	byID := make(map[int64]entity.TelegramPost, len(posts))
+	for _, post := range posts {
+		byID[post.ID] = post
+	}
+
+	tests := []struct {
+		name string
+		id   int64
+		text string
+	}{
+		{"переводы строк", 98, "Go разработчик\nУдаленно, от 300 000 ₽"},
+		{"ответ без цитаты", 99, "Вакансия закрыта, спасибо всем откликнувшимся"},
+		{"ссылки и форматирование", 100, "Senior Go Developer в Example\n\nПолная удаленка, Kubernetes"},
+		{"ответ на вакансию без её текста", 101, "Junior Go Developer в ту же команду"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			post, ok := byID[tt.id]
+			if !ok {
+				t.Fatalf("пост %d не найден", tt.id)
+			}
+			if post.Text != tt.text {
+				t.Errorf("текст %q, ожидался %q", post.Text, tt.text)
+			}
+			if post.Channel != "golangjobs" {
+				t.Errorf("канал %q", post.Channel)
+			}
+			if want := fmt.Sprintf("https://t.me/golangjobs/%d", tt.id); post.Link != want {
+				t.Errorf("ссылка %q, ожидалась %q", post.Link, want)
+			}
+		})
+	}
+
+	post := byID[100]
+	if want := time.Date(2025, 6, 11, 8, 0, 0, 0, time.UTC); !post.DatePosted.Equal(want) {
+		t.Errorf("дата публикации %v, ожидалась %v", post.DatePosted, want)
+	}
+	if !strings.Contains(post.HTML, `<a href="https://example.com/company"`) || !strings.Contains(post.HTML, "<i>Kubernetes</i>") {
+		t.Errorf("HTML поста без ссылки или форматирования: %s", post.HTML)
+	}
+}
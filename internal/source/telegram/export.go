@@ -0,0 +1,208 @@
+package telegram
+
+import (
+	"encoding/json"
+	"fmt"
+	"html"
+	"io"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/zalhonan/remotejobs-site/internal/domain/entity"
+)
+
+// exportTypePublicPrefix начало типа чата в экспорте публичного канала или группы ("public_channel", "public_supergroup")
+const exportTypePublicPrefix = "public_"
+
+// exportDocument файл result.json, который Telegram Desktop создает при экспорте истории канала в формате JSON
+type exportDocument struct {
+	Name     string          `json:"name"`
+	Type     string          `json:"type"`
+	ID       int64           `json:"id"`
+	Messages []exportMessage `json:"messages"`
+}
+
+type exportMessage struct {
+	ID           int64            `json:"id"`
+	Type         string           `json:"type"`
+	Date         string           `json:"date"`
+	DateUnixtime string           `json:"date_unixtime"`
+	Text         json.RawMessage  `json:"text"`
+	TextEntities []exportTextPart `json:"text_entities"`
+}
+
+// exportTextPart фрагмент текста сообщения с типом форматирования ("plain", "bold", "text_link" и т.д.)
+type exportTextPart struct {
+	Type string `json:"type"`
+	Text string `json:"text"`
+	Href string `json:"href"`
+}
+
+// ExportChannel история канала из экспорта Telegram Desktop
+type ExportChannel struct {
+	Name  string                // Название канала
+	ID    int64                 // ID канала в Telegram
+	Posts []entity.TelegramPost // Посты с текстом в порядке возрастания ID
+}
+
+// ParseExport разбирает result.json экспорта канала. Ссылки на посты формируются как {baseURL}/{tag}/{id},
+// без tag - как ссылки на закрытый канал {baseURL}/c/{id канала}/{id}. У публичного канала tag обязателен:
+// парсер сохраняет его посты по ссылкам с именем канала. Служебные сообщения и посты без текста пропускаются
+func ParseExport(body io.Reader, baseURL, tag string) (ExportChannel, error) {
+	var doc exportDocument
+	if err := json.NewDecoder(body).Decode(&doc); err != nil {
+		return ExportChannel{}, fmt.Errorf("не удалось разобрать экспорт канала: %w", err)
+	}
+
+	baseURL = strings.TrimRight(baseURL, "/")
+	tag = strings.ToLower(strings.TrimPrefix(tag, "@"))
+	channelPath := url.PathEscape(tag)
+	if tag == "" {
+		if strings.HasPrefix(doc.Type, exportTypePublicPrefix) {
+			return ExportChannel{}, fmt.Errorf("экспорт публичного канала %q: имя канала нужно указать явно", doc.Name)
+		}
+		if doc.ID == 0 {
+			return ExportChannel{}, fmt.Errorf("в экспорте нет ID канала, имя канала нужно указать явно")
+		}
+		channelPath = "c/" + strconv.FormatInt(doc.ID, 10)
+	}
+
+	channel := ExportChannel{
+		Name: doc.Name,
+		ID:   doc.ID,
+	}
+
+	for _, message := range doc.Messages {
+		if message.Type != "message" {
+			continue
+		}
+
+		parts, err := message.textParts()
+		if err != nil {
+			return ExportChannel{}, fmt.Errorf("не удалось разобрать текст сообщения %d: %w", message.ID, err)
+		}
+
+		text, content := renderTextParts(parts, baseURL)
+		if strings.TrimSpace(text) == "" {
+			continue
+		}
+
+		channel.Posts = append(channel.Posts, entity.TelegramPost{
+			ID:         message.ID,
+			Channel:    tag,
+			HTML:       strings.TrimSpace(content),
+			Text:       strings.TrimSpace(text),
+			Link:       canonicalLink(baseURL + "/" + channelPath + "/" + strconv.FormatInt(message.ID, 10)),
+			DatePosted: message.datePosted(),
+		})
+	}
+
+	sort.Slice(channel.Posts, func(i, j int) bool {
+		return channel.Posts[i].ID < channel.Posts[j].ID
+	})
+
+	return channel, nil
+}
+
+// textParts возвращает фрагменты текста сообщения. Массив text_entities есть в экспортах новых версий,
+// в старых текст хранится в поле text строкой или массивом из строк и фрагментов с форматированием
+func (m exportMessage) textParts() ([]exportTextPart, error) {
+	if len(m.TextEntities) > 0 {
+		return m.TextEntities, nil
+	}
+
+	if len(m.Text) == 0 {
+		return nil, nil
+	}
+
+	var plain string
+	if err := json.Unmarshal(m.Text, &plain); err == nil {
+		return []exportTextPart{{Type: "plain", Text: plain}}, nil
+	}
+
+	var raw []json.RawMessage
+	if err := json.Unmarshal(m.Text, &raw); err != nil {
+		return nil, err
+	}
+
+	parts := make([]exportTextPart, 0, len(raw))
+	for _, item := range raw {
+		var part exportTextPart
+		if err := json.Unmarshal(item, &plain); err == nil {
+			part = exportTextPart{Type: "plain", Text: plain}
+		} else if err := json.Unmarshal(item, &part); err != nil {
+			return nil, err
+		}
+		parts = append(parts, part)
+	}
+
+	return parts, nil
+}
+
+// datePosted возвращает дату публикации. Поле date хранит местное время экспортировавшего без часового пояса,
+// поэтому сначала используется date_unixtime
+func (m exportMessage) datePosted() time.Time {
+	if seconds, err := strconv.ParseInt(m.DateUnixtime, 10, 64); err == nil {
+		return time.Unix(seconds, 0).UTC()
+	}
+
+	if datePosted, err := time.ParseInLocation("2006-01-02T15:04:05", m.Date, time.Local); err == nil {
+		return datePosted
+	}
+
+	return time.Time{}
+}
+
+// renderTextParts собирает текст и HTML сообщения из фрагментов так же, как их показывает веб-превью:
+// форматирование передается тегами, переводы строк - тегом <br>, упоминания ведут на {baseURL}/{имя}
+func renderTextParts(parts []exportTextPart, baseURL string) (string, string) {
+	var text, content strings.Builder
+
+	for _, part := range parts {
+		text.WriteString(part.Text)
+
+		escaped := strings.ReplaceAll(html.EscapeString(part.Text), "\n", "<br/>")
+
+		switch part.Type {
+		case "bold":
+			content.WriteString("<b>" + escaped + "</b>")
+		case "italic":
+			content.WriteString("<i>" + escaped + "</i>")
+		case "underline":
+			content.WriteString("<u>" + escaped + "</u>")
+		case "strikethrough":
+			content.WriteString("<s>" + escaped + "</s>")
+		case "code":
+			content.WriteString("<code>" + escaped + "</code>")
+		case "pre":
+			content.WriteString("<pre>" + html.EscapeString(part.Text) + "</pre>")
+		case "blockquote":
+			content.WriteString("<blockquote>" + escaped + "</blockquote>")
+		case "text_link":
+			content.WriteString(exportLink(part.Href, escaped))
+		case "link":
+			href := part.Text
+			if !strings.Contains(href, "://") {
+				href = "https://" + href
+			}
+			content.WriteString(exportLink(href, escaped))
+		case "email":
+			content.WriteString(exportLink("mailto:"+part.Text, escaped))
+		case "mention":
+			content.WriteString(exportLink(baseURL+"/"+strings.TrimPrefix(part.Text, "@"), escaped))
+		default:
+			// plain, hashtag, phone, spoiler, custom_emoji и другие фрагменты без ссылок выводятся текстом
+			content.WriteString(escaped)
+		}
+	}
+
+	return text.String(), content.String()
+}
+
+// exportLink формирует ссылку из фрагмента сообщения
+func exportLink(href, escapedText string) string {
+	return `<a href="` + html.EscapeString(href) + `" target="_blank" rel="noopener">` + escapedText + `</a>`
+}
@@ -0,0 +1,101 @@
+package telegram
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestParseExportMentionUsesBaseURL(t *testing.T) {
+	body := `{
+		"name": "Jobs",
+		"id": 42,
+		"messages": [
+			{"id": 7, "type": "message", "date_unixtime": "1700000000",
+			 "text_entities": [{"type": "plain", "text": "Пишите "}, {"type": "mention", "text": "@hr_team"}]}
+		]
+	}`
+
+	channel, err := ParseExport(strings.NewReader(body), "https://tg.example.com/", "jobs")
+	if err != nil {
+		t.Fatalf("ParseExport: %v", err)
+	}
+	if len(channel.Posts) != 1 {
+		t.Fatalf("получено постов: %d, ожидался 1", len(channel.Posts))
+	}
+
+	post := channel.Posts[0]
+	if post.Link != "https://tg.example.com/jobs/7" {
+		t.Errorf("ссылка на пост %q", post.Link)
+	}
+	if !strings.Contains(post.HTML, `href="https://tg.example.com/hr_team"`) {
+		t.Errorf("упоминание не ведет на baseURL: %s", post.HTML)
+	}
+}
+
+func TestPostLinkIgnoresChannelCase(t *testing.T) {
+	page := `<div class="tgme_widget_message" data-post="GolangJobs/5">
+		<a class="tgme_widget_message_date" href="https://t.me/GolangJobs/5"><time datetime="2023-11-14T22:13:20+00:00"></time></a>
+		<div class="tgme_widget_message_text">Go developer</div>
+	</div>`
+	export := `{"name": "Golang Jobs", "type": "public_channel", "id": 42, "messages": [
+		{"id": 5, "type": "message", "date_unixtime": "1700000000", "text": "Go developer"}
+	]}`
+
+	posts, err := parsePosts(strings.NewReader(page), "golangjobs", DefaultBaseURL)
+	if err != nil || len(posts) != 1 {
+		t.Fatalf("parsePosts: %v, постов %d", err, len(posts))
+	}
+
+	channel, err := ParseExport(strings.NewReader(export), DefaultBaseURL, "@GOLANGJOBS")
+	if err != nil || len(channel.Posts) != 1 {
+		t.Fatalf("ParseExport: %v, постов %d", err, len(channel.Posts))
+	}
+
+	const want = "https://t.me/golangjobs/5"
+	if posts[0].Link != want {
+		t.Errorf("ссылка парсера %q, ожидалась %q", posts[0].Link, want)
+	}
+	if channel.Posts[0].Link != want {
+		t.Errorf("ссылка импорта %q, ожидалась %q", channel.Posts[0].Link, want)
+	}
+}
+
+func TestParseExportChannelRequirement(t *testing.T) {
+	export := func(chatType string) string {
+		return `{"name": "Jobs", "type": "` + chatType + `", "id": 42, "messages": [
+			{"id": 7, "type": "message", "date_unixtime": "1700000000", "text": "Go developer"}
+		]}`
+	}
+
+	tests := []struct {
+		name     string
+		chatType string
+		tag      string
+		wantLink string
+		wantErr  bool
+	}{
+		{"публичный канал с именем", "public_channel", "jobs", "https://t.me/jobs/7", false},
+		{"публичный канал без имени", "public_channel", "", "", true},
+		{"публичная группа без имени", "public_supergroup", "", "", true},
+		{"закрытый канал без имени", "private_channel", "", "https://t.me/c/42/7", false},
+		{"закрытый канал с именем", "private_channel", "jobs", "https://t.me/jobs/7", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			channel, err := ParseExport(strings.NewReader(export(tt.chatType)), DefaultBaseURL, tt.tag)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatal("ожидалась ошибка")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ParseExport: %v", err)
+			}
+			if len(channel.Posts) != 1 || channel.Posts[0].Link != tt.wantLink {
+				t.Errorf("посты %+v, ожидалась ссылка %q", channel.Posts, tt.wantLink)
+			}
+		})
+	}
+}
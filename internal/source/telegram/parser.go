@@ -0,0 +1,166 @@
+package telegram
+
+import (
+	"bytes"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/zalhonan/remotejobs-site/internal/domain/entity"
+	"golang.org/x/net/html"
+	"golang.org/x/net/html/atom"
+)
+
+// Классы элементов веб-превью Telegram
+const (
+	classMessage     = "tgme_widget_message"
+	classMessageText = "tgme_widget_message_text"
+	classMessageDate = "tgme_widget_message_date"
+	classReply       = "tgme_widget_message_reply"
+)
+
+// parsePosts разбирает посты из HTML страницы t.me/s/<tag>. Посты без текста пропускаются
+func parsePosts(body io.Reader, tag, baseURL string) ([]entity.TelegramPost, error) {
+	doc, err := html.Parse(body)
+	if err != nil {
+		return nil, err
+	}
+
+	var posts []entity.TelegramPost
+
+	var walk func(node *html.Node)
+	walk = func(node *html.Node) {
+		if node.Type == html.ElementNode && hasClass(node, classMessage) && attr(node, "data-post") != "" {
+			if post, ok := parsePost(node, tag, baseURL); ok {
+				posts = append(posts, post)
+			}
+			return
+		}
+		for child := node.FirstChild; child != nil; child = child.NextSibling {
+			walk(child)
+		}
+	}
+	walk(doc)
+
+	sort.Slice(posts, func(i, j int) bool {
+		return posts[i].ID < posts[j].ID
+	})
+
+	return posts, nil
+}
+
+// parsePost разбирает один пост. Атрибут data-post имеет вид "<tag>/<id>"
+func parsePost(node *html.Node, tag, baseURL string) (entity.TelegramPost, bool) {
+	dataPost := attr(node, "data-post")
+	separator := strings.LastIndex(dataPost, "/")
+	if separator < 0 {
+		return entity.TelegramPost{}, false
+	}
+
+	id, err := strconv.ParseInt(dataPost[separator+1:], 10, 64)
+	if err != nil {
+		return entity.TelegramPost{}, false
+	}
+
+	post := entity.TelegramPost{
+		ID:      id,
+		Channel: tag,
+		Link:    canonicalLink(baseURL + "/" + dataPost),
+	}
+
+	if dateLink := findElement(node, func(n *html.Node) bool { return hasClass(n, classMessageDate) }); dateLink != nil {
+		if href := attr(dateLink, "href"); href != "" {
+			post.Link = canonicalLink(href)
+		}
+		if timeNode := findElement(dateLink, func(n *html.Node) bool { return n.DataAtom == atom.Time }); timeNode != nil {
+			if datePosted, err := time.Parse(time.RFC3339, attr(timeNode, "datetime")); err == nil {
+				post.DatePosted = datePosted
+			}
+		}
+	}
+
+	textNode := findElement(node, func(n *html.Node) bool { return hasClass(n, classMessageText) })
+	if textNode == nil {
+		return post, true
+	}
+
+	post.HTML = strings.TrimSpace(innerHTML(textNode))
+	post.Text = strings.TrimSpace(innerText(textNode))
+
+	return post, true
+}
+
+// canonicalLink приводит ссылку на пост к нижнему регистру. Telegram не различает регистр имени канала,
+// а в href и в имени канала для импорта оно записано так, как его указал владелец канала или оператор.
+// Без приведения один и тот же пост, загруженный парсером и импортом, сохранился бы дважды
+func canonicalLink(link string) string {
+	return strings.ToLower(link)
+}
+
+// findElement ищет первый элемент, удовлетворяющий условию, пропуская цитаты отвеченных постов
+func findElement(node *html.Node, match func(*html.Node) bool) *html.Node {
+	for child := node.FirstChild; child != nil; child = child.NextSibling {
+		if child.Type != html.ElementNode || hasClass(child, classReply) {
+			continue
+		}
+		if match(child) {
+			return child
+		}
+		if found := findElement(child, match); found != nil {
+			return found
+		}
+	}
+	return nil
+}
+
+// innerHTML возвращает HTML содержимого элемента
+func innerHTML(node *html.Node) string {
+	var buf bytes.Buffer
+	for child := node.FirstChild; child != nil; child = child.NextSibling {
+		_ = html.Render(&buf, child)
+	}
+	return buf.String()
+}
+
+// innerText возвращает текст элемента, заменяя переносы <br> на перевод строки
+func innerText(node *html.Node) string {
+	var builder strings.Builder
+
+	var walk func(n *html.Node)
+	walk = func(n *html.Node) {
+		switch {
+		case n.Type == html.TextNode:
+			builder.WriteString(n.Data)
+		case n.Type == html.ElementNode && n.DataAtom == atom.Br:
+			builder.WriteString("\n")
+		}
+		for child := n.FirstChild; child != nil; child = child.NextSibling {
+			walk(child)
+		}
+	}
+	walk(node)
+
+	return builder.String()
+}
+
+// hasClass сообщает, есть ли у элемента CSS-класс
+func hasClass(node *html.Node, class string) bool {
+	for _, value := range strings.Fields(attr(node, "class")) {
+		if value == class {
+			return true
+		}
+	}
+	return false
+}
+
+// attr возвращает значение атрибута элемента
+func attr(node *html.Node, name string) string {
+	for _, attribute := range node.Attr {
+		if attribute.Key == name {
+			return attribute.Val
+		}
+	}
+	return ""
+}
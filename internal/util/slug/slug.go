@@ -0,0 +1,168 @@
+// Package slug формирует человекочитаемые части URL латиницей
+package slug
+
+import (
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+const (
+	// DefaultMaxLength максимальная длина слага без ID вакансии
+	DefaultMaxLength = 80
+
+	// Fallback слаг для текста, в котором не осталось букв и цифр
+	Fallback = "vakansiya"
+
+	// MaxLength максимальная длина слага вместе с ID и суффиксом (колонка jobs_raw.slug VARCHAR(255))
+	MaxLength = 255
+)
+
+// cyrillic транслитерация кириллицы по ГОСТ 7.79-2000 (система Б, ISO 9) без апострофов,
+// которые нельзя использовать в URL. Буква "ц" обрабатывается отдельно
+var cyrillic = map[rune]string{
+	'а': "a", 'б': "b", 'в': "v", 'г': "g", 'д': "d", 'е': "e", 'ё': "yo", 'ж': "zh",
+	'з': "z", 'и': "i", 'й': "j", 'к': "k", 'л': "l", 'м': "m", 'н': "n", 'о': "o",
+	'п': "p", 'р': "r", 'с': "s", 'т': "t", 'у': "u", 'ф': "f", 'х': "x", 'ч': "ch",
+	'ш': "sh", 'щ': "shh", 'ъ': "", 'ы': "y", 'ь': "", 'э': "e", 'ю': "yu", 'я': "ya",
+	// Украинские и белорусские буквы
+	'і': "i", 'ї': "yi", 'є': "ye", 'ґ': "g", 'ў': "u",
+}
+
+// softTs буквы, перед которыми "ц" передается как "c", а не "cz"
+var softTs = map[rune]bool{'е': true, 'и': true, 'ы': true, 'й': true, 'і': true, 'є': true}
+
+// terms названия технологий, которые потеряли бы смысл после удаления знаков
+var terms = strings.NewReplacer(
+	"c++", " cpp ",
+	"c#", " csharp ",
+	"f#", " fsharp ",
+	".net", " dotnet ",
+	".js", "js",
+)
+
+// stopWords служебные слова, которые не несут смысла в URL
+var stopWords = map[string]bool{
+	"в": true, "во": true, "на": true, "и": true, "для": true, "с": true, "со": true, "по": true,
+	"к": true, "ко": true, "от": true, "до": true, "из": true, "за": true, "о": true, "об": true,
+	"а": true, "но": true, "или": true, "у": true,
+	"a": true, "an": true, "the": true, "of": true, "for": true, "in": true, "on": true,
+	"at": true, "to": true, "and": true, "or": true, "with": true,
+}
+
+// Make формирует слаг из текста: транслитерирует кириллицу, убирает служебные слова и знаки
+// и обрезает результат по границе слова до maxLength символов. Возвращает пустую строку,
+// если в тексте нет букв и цифр
+func Make(text string, maxLength int) string {
+	if maxLength <= 0 {
+		maxLength = DefaultMaxLength
+	}
+
+	words := strings.FieldsFunc(terms.Replace(strings.ToLower(text)), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+
+	// Служебные слова убираются, только если после этого что-то остается
+	meaningful := make([]string, 0, len(words))
+	for _, word := range words {
+		if !stopWords[word] {
+			meaningful = append(meaningful, word)
+		}
+	}
+	if len(meaningful) > 0 {
+		words = meaningful
+	}
+
+	var b strings.Builder
+	for _, word := range words {
+		word = Transliterate(word)
+		if word == "" {
+			continue
+		}
+
+		if b.Len() == 0 {
+			// Первое слово обрезается, даже если оно длиннее ограничения
+			if len(word) > maxLength {
+				word = word[:maxLength]
+			}
+			b.WriteString(word)
+			continue
+		}
+
+		if b.Len()+1+len(word) > maxLength {
+			break
+		}
+		b.WriteByte('-')
+		b.WriteString(word)
+	}
+
+	return b.String()
+}
+
+// Transliterate переводит текст в нижний регистр и транслитерирует кириллицу.
+// Из остальных символов сохраняются только латинские буквы и цифры
+func Transliterate(text string) string {
+	runes := []rune(strings.ToLower(text))
+
+	var b strings.Builder
+	for i, r := range runes {
+		switch {
+		case r == 'ц':
+			if i+1 < len(runes) && softTs[runes[i+1]] {
+				b.WriteString("c")
+			} else {
+				b.WriteString("cz")
+			}
+		case cyrillic[r] != "":
+			b.WriteString(cyrillic[r])
+		case r >= 'a' && r <= 'z', r >= '0' && r <= '9':
+			b.WriteRune(r)
+		}
+	}
+
+	return b.String()
+}
+
+// WithID добавляет ID вакансии в начало слага: страница вакансии находится по ID из URL вида /job/{id}-{slug}
+func WithID(id int64, slug string) string {
+	if slug == "" {
+		slug = Fallback
+	}
+	return strconv.FormatInt(id, 10) + "-" + slug
+}
+
+// WithSuffix возвращает вариант слага для разрешения коллизий: "slug" при attempt <= 1,
+// иначе "slug-{attempt}". Слишком длинный слаг обрезается, чтобы суффикс поместился в MaxLength
+func WithSuffix(slug string, attempt int) string {
+	if attempt <= 1 {
+		return slug
+	}
+
+	suffix := "-" + strconv.Itoa(attempt)
+	if len(slug)+len(suffix) > MaxLength {
+		slug = strings.TrimRight(slug[:MaxLength-len(suffix)], "-")
+	}
+	return slug + suffix
+}
+
+// IsWellFormed сообщает, что слаг вакансии имеет вид "{id}-слово-слово": начинается с ID вакансии
+// и дальше содержит только латинские буквы в нижнем регистре, цифры и одиночные дефисы
+func IsWellFormed(id int64, slug string) bool {
+	rest, ok := strings.CutPrefix(slug, strconv.FormatInt(id, 10)+"-")
+	if !ok || rest == "" || len(slug) > MaxLength {
+		return false
+	}
+
+	previous := '-'
+	for _, r := range rest {
+		switch {
+		case r >= 'a' && r <= 'z', r >= '0' && r <= '9':
+		case r == '-' && previous != '-':
+		default:
+			return false
+		}
+		previous = r
+	}
+
+	return previous != '-'
+}
@@ -0,0 +1,134 @@
+package slug
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestTransliterate(t *testing.T) {
+	tests := []struct {
+		name string
+		text string
+		want string
+	}{
+		{"ц перед е", "цех", "cex"},
+		{"ц перед и", "Цитата", "citata"},
+		{"ц перед ы", "цыган", "cygan"},
+		{"ц перед а", "Царь", "czar"},
+		{"ц в конце слова", "кварц", "kvarcz"},
+		{"ё", "Ёлка", "yolka"},
+		{"щ и мягкий знак", "щель", "shhel"},
+		{"украинские буквы", "їжак", "yizhak"},
+		{"латиница и цифры", "Go1.24", "go124"},
+		{"знаки убираются", "Go-разработчик!", "gorazrabotchik"},
+		{"другие алфавиты убираются", "日本 dev", "dev"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Transliterate(tt.text); got != tt.want {
+				t.Errorf("Transliterate(%q) = %q, ожидалось %q", tt.text, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMake(t *testing.T) {
+	tests := []struct {
+		name      string
+		text      string
+		maxLength int
+		want      string
+	}{
+		{"служебные слова убираются", "Go-разработчик в Яндекс", 0, "go-razrabotchik-yandeks"},
+		{"английские служебные слова", "Head of Engineering for the Platform", 0, "head-engineering-platform"},
+		{"только служебные слова", "в на", 0, "v-na"},
+		{"названия технологий", ".NET / C# разработчик", 0, "dotnet-csharp-razrabotchik"},
+		{"c++", "C++ developer", 0, "cpp-developer"},
+		{"node.js", "Node.js", 0, "nodejs"},
+		{"знаки и тире", "Senior Developer (Remote) – Москва", 0, "senior-developer-remote-moskva"},
+		{"нет букв и цифр", "!!! ???", 0, ""},
+		{"обрезка по границе слова", "alpha beta gamma", 10, "alpha-beta"},
+		{"длинное первое слово обрезается", "абвгдежзийклмн", 5, "abvgd"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Make(tt.text, tt.maxLength); got != tt.want {
+				t.Errorf("Make(%q, %d) = %q, ожидалось %q", tt.text, tt.maxLength, got, tt.want)
+			}
+		})
+	}
+
+	long := Make(strings.Repeat("word ", 30), 0)
+	if len(long) > DefaultMaxLength || strings.HasSuffix(long, "-") || !strings.HasSuffix(long, "word") {
+		t.Errorf("слаг длинного текста %q (длина %d) не обрезан по границе слова до %d символов", long, len(long), DefaultMaxLength)
+	}
+}
+
+func TestWithID(t *testing.T) {
+	if got := WithID(7, "go-developer"); got != "7-go-developer" {
+		t.Errorf("WithID = %q", got)
+	}
+	if got := WithID(7, ""); got != "7-"+Fallback {
+		t.Errorf("WithID с пустым слагом = %q", got)
+	}
+}
+
+func TestWithSuffix(t *testing.T) {
+	full := strings.Repeat("a", MaxLength)
+	hyphenAtCut := strings.Repeat("a", 251) + "-ccc"
+
+	tests := []struct {
+		name    string
+		slug    string
+		attempt int
+		want    string
+	}{
+		{"первая попытка", "go-developer", 1, "go-developer"},
+		{"попытка меньше единицы", "go-developer", 0, "go-developer"},
+		{"суффикс", "go-developer", 3, "go-developer-3"},
+		{"обрезка под суффикс", full, 2, strings.Repeat("a", MaxLength-2) + "-2"},
+		{"дефис на месте обрезки", hyphenAtCut, 10, strings.Repeat("a", 251) + "-10"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := WithSuffix(tt.slug, tt.attempt)
+			if got != tt.want {
+				t.Errorf("WithSuffix(%q, %d) = %q, ожидалось %q", tt.slug, tt.attempt, got, tt.want)
+			}
+			if len(got) > MaxLength {
+				t.Errorf("длина слага %d больше %d", len(got), MaxLength)
+			}
+		})
+	}
+}
+
+func TestIsWellFormed(t *testing.T) {
+	tests := []struct {
+		name string
+		slug string
+		want bool
+	}{
+		{"правильный слаг", "42-go-developer", true},
+		{"одно слово", "42-a", true},
+		{"без слов", "42-", false},
+		{"только ID", "42", false},
+		{"чужой ID", "43-go-developer", false},
+		{"двойной дефис", "42-go--developer", false},
+		{"дефис после ID", "42--go", false},
+		{"дефис в конце", "42-go-", false},
+		{"заглавные буквы", "42-Go-developer", false},
+		{"кириллица", "42-разработчик", false},
+		{"слишком длинный", "42-" + strings.Repeat("a", MaxLength-2), false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := IsWellFormed(42, tt.slug); got != tt.want {
+				t.Errorf("IsWellFormed(42, %q) = %v, ожидалось %v", tt.slug, got, tt.want)
+			}
+		})
+	}
+}
@@ -3,11 +3,10 @@ package helper
 import (
 	"fmt"
 	"html/template"
-	"regexp"
 	"strings"
 	"time"
 
-	"github.com/microcosm-cc/bluemonday"
+	"github.com/zalhonan/remotejobs-site/internal/domain/content"
 )
 
 // TemplateFuncs возвращает карту функций для использования в шаблонах
@@ -22,23 +21,26 @@ func TemplateFuncs() template.FuncMap {
 		"escapeJS":              escapeJS,
 		"formatNumber":          formatNumber,
 		"split":                 strings.Split,
+		"join":                  strings.Join,
 		"prepareContentPreview": prepareContentPreview,
 	}
 }
 
-// truncate обрезает строку до указанной длины и добавляет многоточие, если строка была обрезана
+// truncate обрезает строку до указанной длины в символах и добавляет многоточие, если строка была обрезана
 func truncate(s string, maxLen int) string {
-	if len(s) <= maxLen {
+	runes := []rune(s)
+	if len(runes) <= maxLen {
 		return s
 	}
 
 	// Ищем последний пробел перед maxLen
-	lastSpace := strings.LastIndex(s[:maxLen], " ")
+	truncated := string(runes[:maxLen])
+	lastSpace := strings.LastIndex(truncated, " ")
 	if lastSpace > 0 {
-		return s[:lastSpace] + "..."
+		return truncated[:lastSpace] + "..."
 	}
 
-	return s[:maxLen] + "..."
+	return truncated + "..."
 }
 
 // formatDate форматирует время в читаемую строку
@@ -47,35 +49,10 @@ func formatDate(t time.Time) string {
 }
 
 // safeHTML помечает строку как безопасный HTML
-// Очищает HTML от потенциально опасных элементов и атрибутов (JavaScript)
+// Очищает HTML от потенциально опасных элементов и атрибутов (JavaScript).
+// Текст вакансий очищается при загрузке, функция нужна для HTML из других источников
 func safeHTML(s string) template.HTML {
-	// Используем UGCPolicy из bluemonday - политику для пользовательского контента
-	p := bluemonday.UGCPolicy()
-
-	// Разрешаем базовые элементы форматирования текста и таблицы
-	p.AllowElements("p", "br", "strong", "em", "u", "s", "ul", "ol", "li",
-		"blockquote", "code", "pre", "h1", "h2", "h3", "h4", "h5", "h6",
-		"table", "thead", "tbody", "tr", "td", "th")
-
-	// Разрешаем ссылки, но только для безопасных протоколов
-	p.AllowAttrs("href").OnElements("a")
-	p.AllowURLSchemes("http", "https", "mailto")
-
-	// Очищаем HTML
-	sanitized := p.Sanitize(s)
-
-	// Удаляем только лишние пробелы в начале каждой строки, но сохраняем пустые строки
-	re := regexp.MustCompile(`(?m)^[\t ]+`)
-	sanitized = re.ReplaceAllString(sanitized, "")
-
-	// Удаляем пробелы в начале и конце всего текста
-	sanitized = strings.TrimSpace(sanitized)
-
-	// Заменяем последовательности из более чем 3-х переносов строк на 2 переноса
-	re = regexp.MustCompile(`\n{3,}`)
-	sanitized = re.ReplaceAllString(sanitized, "\n\n")
-
-	return template.HTML(sanitized)
+	return template.HTML(content.Sanitize(s))
 }
 
 // iterate создает слайс целых чисел от start до end (включительно)
@@ -121,46 +98,8 @@ func formatNumber(n int) string {
 }
 
 // prepareContentPreview подготавливает превью контента для отображения
-// Возвращает первые n строк контента, но не более 700 символов
-func prepareContentPreview(content string, lines int) template.HTML {
-	if content == "" {
-		return ""
-	}
-
-	// Разбиваем на строки
-	contentLines := strings.Split(content, "\n")
-
-	// Удаляем пустые строки, сохраняя структуру абзацев
-	var nonEmptyLines []string
-	for _, line := range contentLines {
-		trimmedLine := strings.TrimSpace(line)
-		if trimmedLine != "" {
-			nonEmptyLines = append(nonEmptyLines, line)
-		}
-	}
-
-	// Определяем, сколько строк взять
-	maxLines := lines
-	if len(nonEmptyLines) < maxLines {
-		maxLines = len(nonEmptyLines)
-	}
-
-	// Берем первые maxLines строк
-	result := strings.Join(nonEmptyLines[:maxLines], "<br>")
-
-	// Если превью превышает 700 символов, обрезаем
-	if len(result) > 700 {
-		// Ищем последний пробел перед 700 символом для красивого обрезания
-		lastSpace := strings.LastIndex(result[:700], " ")
-		if lastSpace > 0 {
-			result = result[:lastSpace] + "..."
-		} else {
-			result = result[:700] + "..."
-		}
-	} else if len(nonEmptyLines) > maxLines {
-		// Если есть еще строки, добавляем многоточие
-		result += "..."
-	}
-
-	return template.HTML(result)
+// Возвращает первые n строк контента, но не более 700 символов.
+// Превью вакансий готовится при загрузке, функция нужна для текста из других источников
+func prepareContentPreview(text string, lines int) template.HTML {
+	return template.HTML(content.Preview(text, lines, content.PreviewMaxRunes))
 }
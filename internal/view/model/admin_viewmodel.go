@@ -0,0 +1,451 @@
+package model
+
+import (
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/zalhonan/remotejobs-site/internal/domain/entity"
+)
+
+// Разделы меню админки
+const (
+	AdminSectionTechnologies = "technologies"
+	AdminSectionModeration   = "moderation"
+	AdminSectionStopWords    = "stop-words"
+	AdminSectionChannels     = "channels"
+	AdminSectionJobs         = "jobs"
+)
+
+// AdminPage общие поля страниц админки
+type AdminPage struct {
+	PageTitle string // Заголовок страницы
+	Section   string // Активный раздел меню админки
+	Flash     string // Сообщение о выполненном действии (пусто - нет)
+}
+
+// AdminErrorViewModel модель представления страницы ошибки админки
+type AdminErrorViewModel struct {
+	AdminPage
+	StatusCode int    // HTTP статус ответа
+	Title      string // Заголовок ошибки
+	Message    string // Описание ошибки
+}
+
+// AdminTechnologyViewModel технология в справочнике админки
+type AdminTechnologyViewModel struct {
+	ID                    int64  // ID технологии
+	Name                  string // Название технологии
+	URL                   string // Страница вакансий технологии на сайте
+	EditURL               string // Страница редактирования технологии
+	KeywordsCount         int    // Количество ключевых слов
+	NegativeKeywordsCount int    // Количество исключающих слов
+	SortOrder             int    // Порядок сортировки
+	JobsCount             int64  // Количество показываемых вакансий
+	JobsCountRecent       int64  // Количество показываемых вакансий за последние 30 дней
+	Lifecycle             string // Пороги жизненного цикла в днях (пусто - пороги по умолчанию)
+}
+
+// NewAdminTechnologyViewModels создает модели представления технологий для справочника админки
+func NewAdminTechnologyViewModels(technologies []entity.Technology) []AdminTechnologyViewModel {
+	viewModels := make([]AdminTechnologyViewModel, 0, len(technologies))
+	for _, tech := range technologies {
+		viewModels = append(viewModels, AdminTechnologyViewModel{
+			ID:                    tech.ID,
+			Name:                  tech.Technology,
+			URL:                   "/" + tech.Technology,
+			EditURL:               AdminTechnologyURL(tech.ID),
+			KeywordsCount:         len(tech.Keywords),
+			NegativeKeywordsCount: len(tech.NegativeKeywords),
+			SortOrder:             tech.SortOrder,
+			JobsCount:             tech.Count,
+			JobsCountRecent:       tech.CountRecent,
+			Lifecycle:             formatLifecycle(tech.Lifecycle),
+		})
+	}
+	return viewModels
+}
+
+// formatLifecycle форматирует пороги жизненного цикла технологии, незаданный порог обозначается "-"
+func formatLifecycle(lifecycle entity.JobLifecycle) string {
+	if lifecycle == (entity.JobLifecycle{}) {
+		return ""
+	}
+
+	days := []int{lifecycle.ExpireAfterDays, lifecycle.ArchiveAfterDays, lifecycle.RemoveAfterDays}
+	parts := make([]string, 0, len(days))
+	for _, d := range days {
+		if d == 0 {
+			parts = append(parts, "-")
+			continue
+		}
+		parts = append(parts, strconv.Itoa(d))
+	}
+	return strings.Join(parts, " / ")
+}
+
+// AdminTechnologyURL возвращает URL страницы редактирования технологии
+func AdminTechnologyURL(id int64) string {
+	return fmt.Sprintf("/admin/technologies/%d", id)
+}
+
+// AdminTechnologyListViewModel модель представления справочника технологий
+type AdminTechnologyListViewModel struct {
+	AdminPage
+	Technologies []AdminTechnologyViewModel
+}
+
+// AdminTechnologyForm значения формы технологии в том виде, в котором их ввел пользователь
+type AdminTechnologyForm struct {
+	ID               int64  // ID технологии, 0 - новая технология
+	Name             string // Название технологии
+	Keywords         string // Ключевые слова, по одному на строке
+	NegativeKeywords string // Исключающие слова, по одному на строке
+	SortOrder        string // Порядок сортировки
+	ExpireAfterDays  string // Пороги жизненного цикла в днях, пусто - порог по умолчанию
+	ArchiveAfterDays string
+	RemoveAfterDays  string
+}
+
+// NewAdminTechnologyForm заполняет форму значениями технологии
+func NewAdminTechnologyForm(tech entity.Technology) AdminTechnologyForm {
+	return AdminTechnologyForm{
+		ID:               tech.ID,
+		Name:             tech.Technology,
+		Keywords:         strings.Join(tech.Keywords, "\n"),
+		NegativeKeywords: strings.Join(tech.NegativeKeywords, "\n"),
+		SortOrder:        strconv.Itoa(tech.SortOrder),
+		ExpireAfterDays:  formatDays(tech.Lifecycle.ExpireAfterDays),
+		ArchiveAfterDays: formatDays(tech.Lifecycle.ArchiveAfterDays),
+		RemoveAfterDays:  formatDays(tech.Lifecycle.RemoveAfterDays),
+	}
+}
+
+// formatDays форматирует порог жизненного цикла для формы (пусто - порог не задан)
+func formatDays(days int) string {
+	if days == 0 {
+		return ""
+	}
+	return strconv.Itoa(days)
+}
+
+// Action возвращает URL, на который отправляется форма
+func (f AdminTechnologyForm) Action() string {
+	if f.ID == 0 {
+		return "/admin/technologies"
+	}
+	return AdminTechnologyURL(f.ID)
+}
+
+// AdminTechnologyFormViewModel модель представления страницы добавления и редактирования технологии
+type AdminTechnologyFormViewModel struct {
+	AdminPage
+	Form      AdminTechnologyForm
+	Errors    map[string]string                // Ошибки проверки по полям формы
+	Preview   *AdminTechnologyPreviewViewModel // Результат проверки ключевых слов (nil - проверка не запускалась)
+	JobsCount int64                            // Количество показываемых вакансий технологии
+}
+
+// AdminTechnologyPreviewViewModel результат проверки ключевых слов технологии на недавних вакансиях
+type AdminTechnologyPreviewViewModel struct {
+	Days      int                              // За сколько последних дней проверены вакансии
+	Processed int                              // Проверено вакансий
+	Matched   int                              // Вакансий, которые получат технологию
+	Gained    int                              // Вакансий, которые перейдут к технологии
+	Lost      int                              // Вакансий, которые уйдут от технологии
+	Changes   []AdminTechnologyChangeViewModel // Примеры переходов
+	Truncated bool                             // Проверены не все вакансии за период, а только самые новые
+}
+
+// AdminTechnologyChangeViewModel переход вакансии между технологиями при проверке ключевых слов
+type AdminTechnologyChangeViewModel struct {
+	Title string // Заголовок вакансии
+	URL   string // URL страницы вакансии
+	From  string // Текущая технология, пустая строка - не определена
+	To    string // Технология после изменения, пустая строка - не определена
+}
+
+// AdminModerationJobViewModel вакансия в очереди модерации
+type AdminModerationJobViewModel struct {
+	JobViewModel
+	Reason              string   // Причина попадания в очередь
+	Scores              []string // Оценки технологий классификатором в порядке убывания
+	SuggestedTechnology string   // Технология, выбранная в форме по умолчанию
+}
+
+// NewAdminModerationJobViewModels создает модели представления вакансий очереди модерации
+func NewAdminModerationJobViewModels(jobs []entity.JobRaw) []AdminModerationJobViewModel {
+	viewModels := make([]AdminModerationJobViewModel, 0, len(jobs))
+	for _, job := range jobs {
+		viewModel := AdminModerationJobViewModel{
+			JobViewModel:        NewJobViewModelFromEntity(job, job.Slug),
+			Reason:              "Технология определена неуверенно",
+			SuggestedTechnology: job.MainTechnology,
+		}
+		if job.MainTechnology == "" {
+			viewModel.Reason = "Технология не определена"
+		}
+
+		for _, score := range job.TechnologyScores {
+			viewModel.Scores = append(viewModel.Scores, fmt.Sprintf("%s %.1f", score.Technology, score.Score))
+		}
+		if viewModel.SuggestedTechnology == "" && len(job.TechnologyScores) > 0 {
+			viewModel.SuggestedTechnology = job.TechnologyScores[0].Technology
+		}
+
+		viewModels = append(viewModels, viewModel)
+	}
+	return viewModels
+}
+
+// AdminModerationViewModel модель представления очереди модерации
+type AdminModerationViewModel struct {
+	AdminPage
+	Reason       string                  // Выбранная причина попадания в очередь, пусто - любая
+	Counts       entity.ModerationCounts // Количество вакансий в очереди по причинам
+	Jobs         []AdminModerationJobViewModel
+	Technologies []string // Названия технологий для назначения
+	Page         int      // Номер текущей страницы
+	PrevURL      string   // URL предыдущей страницы (пусто - её нет)
+	NextURL      string   // URL следующей страницы (пусто - её нет)
+}
+
+// ModerationURL возвращает URL страницы очереди модерации с причиной reason
+func ModerationURL(reason string, page int) string {
+	query := url.Values{}
+	if reason != "" {
+		query.Set("reason", reason)
+	}
+	if page > 1 {
+		query.Set("page", strconv.Itoa(page))
+	}
+
+	if len(query) == 0 {
+		return "/admin/moderation"
+	}
+	return "/admin/moderation?" + query.Encode()
+}
+
+// ReasonURL возвращает URL первой страницы очереди с другой причиной
+func (m AdminModerationViewModel) ReasonURL(reason string) string {
+	return ModerationURL(reason, 1)
+}
+
+// AdminStopWordsViewModel модель представления справочника стоп-слов
+type AdminStopWordsViewModel struct {
+	AdminPage
+	StopWords []entity.StopWord       // Справочник стоп-слов по алфавиту
+	Words     string                  // Слова из формы импорта, по одному на строке
+	Apply     bool                    // Записать новые слова в колонку stop_words вакансий
+	Errors    []string                // Ошибки проверки формы импорта
+	Preview   []entity.StopWordImpact // Сколько вакансий затронут слова (пусто - проверка не запускалась)
+}
+
+// AdminChannelViewModel канал Telegram с показателями его вакансий
+type AdminChannelViewModel struct {
+	ID                int64
+	Tag               string // Имя канала
+	URL               string // Ссылка на канал в Telegram
+	Paused            bool   // Канал не загружается парсером
+	PostsParsed       int64  // Обработано постов
+	LastPostID        int64  // ID последнего обработанного поста
+	DateAddedStr      string // Дата добавления канала
+	DateLastParsedStr string // Дата последней обработки, пусто - канал еще не обрабатывался
+	Jobs              int64  // Сохранено вакансий
+	Classified        string // Доля вакансий с определенной технологией
+	Duplicates        string // Доля повторов
+	StopWordHits      string // Доля вакансий, скрытых стоп-словами
+}
+
+// NewAdminChannelViewModels создает модели представления каналов Telegram
+func NewAdminChannelViewModels(channels []entity.TelegramChannelStats) []AdminChannelViewModel {
+	viewModels := make([]AdminChannelViewModel, 0, len(channels))
+	for _, channel := range channels {
+		viewModel := AdminChannelViewModel{
+			ID:           channel.ID,
+			Tag:          channel.Tag,
+			URL:          "https://t.me/" + url.PathEscape(channel.Tag),
+			Paused:       channel.Paused,
+			PostsParsed:  channel.PostsParsed,
+			LastPostID:   channel.LastPostID,
+			DateAddedStr: channel.DateChannelAdded.Format("02.01.2006"),
+			Jobs:         channel.Jobs,
+			Classified:   formatShare(channel.Classified, channel.Jobs),
+			Duplicates:   formatShare(channel.Duplicates, channel.Jobs),
+			StopWordHits: formatShare(channel.StopWordHits, channel.Jobs),
+		}
+		if channel.DateLastParsed != nil {
+			viewModel.DateLastParsedStr = channel.DateLastParsed.Format("02.01.2006 15:04")
+		}
+
+		viewModels = append(viewModels, viewModel)
+	}
+	return viewModels
+}
+
+// formatShare форматирует долю part от total в процентах с количеством, "-" - если total равен 0
+func formatShare(part, total int64) string {
+	if total == 0 {
+		return "-"
+	}
+	return fmt.Sprintf("%d%% (%d)", part*100/total, part)
+}
+
+// AdminChannelsViewModel модель представления списка каналов Telegram
+type AdminChannelsViewModel struct {
+	AdminPage
+	Channels  []AdminChannelViewModel
+	StatsDays int    // Период в днях, за который считаются показатели вакансий
+	Tag       string // Имя канала из формы добавления
+	Error     string // Ошибка проверки формы добавления
+}
+
+// jobAuditActions названия действий журнала изменений вакансий
+var jobAuditActions = map[string]string{
+	entity.JobAuditUpdate:   "Изменение",
+	entity.JobAuditHide:     "Скрытие",
+	entity.JobAuditUnhide:   "Показ",
+	entity.JobAuditDelete:   "Удаление",
+	entity.JobAuditModerate: "Модерация",
+	entity.JobAuditRestore:  "Снята отметка \"не вакансия\"",
+}
+
+// jobAuditFields названия полей вакансии в журнале изменений
+var jobAuditFields = map[string]string{
+	entity.JobFieldTitle:          "Заголовок",
+	entity.JobFieldMainTechnology: "Технология",
+	entity.JobFieldContent:        "Текст",
+	entity.JobFieldHidden:         "Скрыта",
+	entity.JobFieldStatus:         "Статус",
+	entity.JobFieldModeration:     "Решение модератора",
+}
+
+// AdminJobURL возвращает URL страницы вакансии в админке
+func AdminJobURL(id int64) string {
+	return fmt.Sprintf("/admin/jobs/%d", id)
+}
+
+// AdminJobAuditEntryViewModel запись журнала изменений вакансии
+type AdminJobAuditEntryViewModel struct {
+	JobID          int64
+	JobURL         string // Страница вакансии в админке
+	Action         string // Название действия
+	Actor          string // Кто выполнил действие
+	Comment        string // Причина изменения
+	DateCreatedStr string // Дата и время изменения
+	Changes        []AdminJobFieldChangeViewModel
+}
+
+// AdminJobFieldChangeViewModel изменение поля вакансии
+type AdminJobFieldChangeViewModel struct {
+	Field  string // Название поля
+	Before string // Значение до изменения
+	After  string // Значение после изменения
+	Long   bool   // Значение - текст вакансии, показывается свернутым
+}
+
+// NewAdminJobAuditEntryViewModels создает модели представления записей журнала изменений вакансий
+func NewAdminJobAuditEntryViewModels(entries []entity.JobAuditEntry) []AdminJobAuditEntryViewModel {
+	viewModels := make([]AdminJobAuditEntryViewModel, 0, len(entries))
+	for _, entry := range entries {
+		viewModel := AdminJobAuditEntryViewModel{
+			JobID:          entry.JobID,
+			JobURL:         AdminJobURL(entry.JobID),
+			Action:         entry.Action,
+			Actor:          entry.Actor,
+			Comment:        entry.Comment,
+			DateCreatedStr: entry.DateCreated.Format("02.01.2006 15:04"),
+		}
+		if name, ok := jobAuditActions[entry.Action]; ok {
+			viewModel.Action = name
+		}
+
+		for _, change := range entry.Changes {
+			field := change.Field
+			if name, ok := jobAuditFields[change.Field]; ok {
+				field = name
+			}
+			viewModel.Changes = append(viewModel.Changes, AdminJobFieldChangeViewModel{
+				Field:  field,
+				Before: formatAuditValue(change.Field, change.Before),
+				After:  formatAuditValue(change.Field, change.After),
+				Long:   change.Field == entity.JobFieldContent,
+			})
+		}
+
+		viewModels = append(viewModels, viewModel)
+	}
+	return viewModels
+}
+
+// formatAuditValue форматирует значение поля из журнала изменений для показа
+func formatAuditValue(field, value string) string {
+	if field != entity.JobFieldHidden {
+		return value
+	}
+	if value == "true" {
+		return "да"
+	}
+	return "нет"
+}
+
+// AdminJobsViewModel модель представления поиска вакансии и журнала изменений вакансий
+type AdminJobsViewModel struct {
+	AdminPage
+	Query   string // Строка поиска вакансии
+	Error   string // Ошибка поиска
+	Entries []AdminJobAuditEntryViewModel
+}
+
+// AdminJobForm значения формы вакансии в том виде, в котором их ввел пользователь
+type AdminJobForm struct {
+	Title          string
+	MainTechnology string
+	Content        string // HTML текста вакансии
+	Comment        string // Причина изменения
+}
+
+// NewAdminJobForm заполняет форму значениями вакансии
+func NewAdminJobForm(job entity.JobRaw) AdminJobForm {
+	return AdminJobForm{
+		Title:          job.Title,
+		MainTechnology: job.MainTechnology,
+		Content:        job.Content,
+	}
+}
+
+// AdminJobViewModel модель представления страницы изменения вакансии
+type AdminJobViewModel struct {
+	AdminPage
+	ID           int64
+	Action       string // URL, на который отправляется форма
+	URL          string // Страница вакансии на сайте
+	SourceLink   string // Ссылка на источник
+	Status       string // Статус жизненного цикла
+	Hidden       bool   // Скрыта администратором
+	NotVacancy   bool   // Модератор отметил пост как не вакансию
+	CanonicalID  int64  // ID канонической вакансии, 0 - вакансия сама каноническая
+	StopWords    []string
+	Form         AdminJobForm
+	Errors       map[string]string // Ошибки проверки по полям формы
+	Technologies []string          // Названия технологий для выбора
+	Entries      []AdminJobAuditEntryViewModel
+}
+
+// NewAdminJobViewModel создает модель представления страницы изменения вакансии
+func NewAdminJobViewModel(job entity.JobRaw, entries []entity.JobAuditEntry) AdminJobViewModel {
+	return AdminJobViewModel{
+		ID:          job.ID,
+		Action:      AdminJobURL(job.ID),
+		URL:         JobURL(job.ID, job.Slug, job.Title),
+		SourceLink:  job.SourceLink,
+		Status:      job.Status,
+		Hidden:      job.Hidden,
+		NotVacancy:  job.Moderation == entity.ModerationNotVacancy,
+		CanonicalID: job.CanonicalID,
+		StopWords:   job.StopWords,
+		Form:        NewAdminJobForm(job),
+		Entries:     NewAdminJobAuditEntryViewModels(entries),
+	}
+}
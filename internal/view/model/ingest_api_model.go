@@ -0,0 +1,80 @@
+package model
+
+import (
+	"time"
+
+	"github.com/zalhonan/remotejobs-site/internal/domain/entity"
+)
+
+// Статусы обработки вакансии в ответе API загрузки
+const (
+	IngestStatusCreated = "created" // Вакансия сохранена
+	IngestStatusExists  = "exists"  // Вакансия с той же ссылкой на источник уже сохранена
+	IngestStatusInvalid = "invalid" // Вакансия не прошла проверку
+	IngestStatusError   = "error"   // Вакансию не удалось сохранить
+)
+
+// IngestItemAPIRequest вакансия в запросе API загрузки
+type IngestItemAPIRequest struct {
+	Title      string     `json:"title"`       // Заголовок, пусто - формируется из текста
+	Content    string     `json:"content"`     // HTML текста вакансии
+	Text       string     `json:"text"`        // Текст без разметки, пусто - формируется из content
+	SourceLink string     `json:"source_link"` // Ссылка на вакансию в источнике
+	DatePosted *time.Time `json:"date_posted"` // Дата публикации в RFC 3339, пусто - время загрузки
+}
+
+// IngestAPIRequest запрос API загрузки с несколькими вакансиями
+type IngestAPIRequest struct {
+	Items []IngestItemAPIRequest `json:"items"`
+}
+
+// IngestItemAPIResult итог обработки одной вакансии в ответе API загрузки
+type IngestItemAPIResult struct {
+	Index          int    `json:"index"` // Номер вакансии в запросе, начиная с нуля
+	Status         string `json:"status"`
+	ID             int64  `json:"id,omitempty"`
+	URL            string `json:"url,omitempty"`
+	MainTechnology string `json:"main_technology,omitempty"`
+	Error          string `json:"error,omitempty"`
+}
+
+// IngestAPIResponse ответ API загрузки с итогами по каждой вакансии в порядке запроса
+type IngestAPIResponse struct {
+	Created int                   `json:"created"`
+	Exists  int                   `json:"exists"`
+	Failed  int                   `json:"failed"`
+	Results []IngestItemAPIResult `json:"results"`
+}
+
+// Add добавляет итог обработки вакансии и обновляет счетчики
+func (r *IngestAPIResponse) Add(result IngestItemAPIResult) {
+	switch result.Status {
+	case IngestStatusCreated:
+		r.Created++
+	case IngestStatusExists:
+		r.Exists++
+	default:
+		r.Failed++
+	}
+	r.Results = append(r.Results, result)
+}
+
+// NewIngestItemAPIResult создает итог обработки вакансии из результата сохранения.
+// Текст ошибки сохранения не передается клиенту, он есть в логах
+func NewIngestItemAPIResult(index int, job entity.JobRaw, inserted bool, err error) IngestItemAPIResult {
+	switch {
+	case err != nil:
+		return IngestItemAPIResult{Index: index, Status: IngestStatusError, Error: "не удалось сохранить вакансию"}
+	case !inserted:
+		return IngestItemAPIResult{Index: index, Status: IngestStatusExists}
+	}
+
+	viewModel := NewJobViewModelFromEntity(job, job.Slug)
+	return IngestItemAPIResult{
+		Index:          index,
+		Status:         IngestStatusCreated,
+		ID:             viewModel.ID,
+		URL:            viewModel.URL,
+		MainTechnology: job.MainTechnology,
+	}
+}
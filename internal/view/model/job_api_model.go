@@ -0,0 +1,101 @@
+package model
+
+import (
+	"time"
+
+	"github.com/zalhonan/remotejobs-site/internal/domain/entity"
+)
+
+// JobAPIModel представление вакансии в JSON API
+type JobAPIModel struct {
+	ID             int64             `json:"id"`
+	Title          string            `json:"title"`
+	URL            string            `json:"url"`
+	SourceLink     string            `json:"source_link"`
+	MainTechnology string            `json:"main_technology"`
+	DatePosted     time.Time         `json:"date_posted"`
+	StopWords      []string          `json:"stop_words,omitempty"`
+	Salary         *SalaryAPIModel   `json:"salary,omitempty"`
+	Seniority      []string          `json:"seniority,omitempty"`
+	Location       *LocationAPIModel `json:"location,omitempty"`
+}
+
+// LocationAPIModel ограничения по месту работы в JSON API
+type LocationAPIModel struct {
+	Constraints []string `json:"constraints"`
+	Countries   []string `json:"countries,omitempty"`
+}
+
+// newLocationAPIModel создает представление ограничений по месту работы (nil - не указаны)
+func newLocationAPIModel(location entity.Location) *LocationAPIModel {
+	if len(location.Constraints) == 0 {
+		return nil
+	}
+
+	return &LocationAPIModel{
+		Constraints: location.Constraints,
+		Countries:   location.Countries,
+	}
+}
+
+// SalaryAPIModel зарплата вакансии в JSON API
+type SalaryAPIModel struct {
+	Min      int64  `json:"min,omitempty"`
+	Max      int64  `json:"max,omitempty"`
+	Currency string `json:"currency"`
+	Period   string `json:"period"`
+	Tax      string `json:"tax,omitempty"`
+}
+
+// newSalaryAPIModel создает представление зарплаты для JSON API (nil - зарплата не указана)
+func newSalaryAPIModel(salary entity.Salary) *SalaryAPIModel {
+	if salary.IsZero() {
+		return nil
+	}
+
+	return &SalaryAPIModel{
+		Min:      salary.Min,
+		Max:      salary.Max,
+		Currency: salary.Currency,
+		Period:   salary.Period,
+		Tax:      salary.Tax,
+	}
+}
+
+// JobListAPIResponse ответ JSON API со страницей вакансий и курсорами навигации
+type JobListAPIResponse struct {
+	Jobs  []JobAPIModel `json:"jobs"`
+	Older string        `json:"older,omitempty"` // Значение параметра before для более старых вакансий
+	Newer string        `json:"newer,omitempty"` // Значение параметра after для более новых вакансий
+}
+
+// NewJobListAPIResponse создает ответ JSON API из страницы вакансий
+func NewJobListAPIResponse(page entity.JobCursorPage) JobListAPIResponse {
+	response := JobListAPIResponse{
+		Jobs: make([]JobAPIModel, 0, len(page.Jobs)),
+	}
+
+	for i, job := range NewJobViewModels(page.Jobs) {
+		response.Jobs = append(response.Jobs, JobAPIModel{
+			ID:             job.ID,
+			Title:          job.Title,
+			URL:            job.URL,
+			SourceLink:     job.SourceLink,
+			MainTechnology: job.MainTechnology,
+			DatePosted:     job.DatePosted,
+			StopWords:      job.StopWords,
+			Salary:         newSalaryAPIModel(page.Jobs[i].Salary),
+			Seniority:      page.Jobs[i].Seniority,
+			Location:       newLocationAPIModel(page.Jobs[i].Location),
+		})
+	}
+
+	if page.Older != nil {
+		response.Older = page.Older.String()
+	}
+	if page.Newer != nil {
+		response.Newer = page.Newer.String()
+	}
+
+	return response
+}
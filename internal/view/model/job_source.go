@@ -0,0 +1,50 @@
+package model
+
+import (
+	"net/url"
+	"strings"
+
+	"github.com/zalhonan/remotejobs-site/internal/domain/entity"
+)
+
+// JobSourceViewModel публикация вакансии в другом источнике
+type JobSourceViewModel struct {
+	Label         string // Название источника: канал Telegram или сайт
+	SourceLink    string // Ссылка на публикацию в источнике
+	DatePostedStr string // Форматированная дата публикации
+}
+
+// NewJobSourceViewModels создает модели представления публикаций вакансии в других источниках
+func NewJobSourceViewModels(jobs []entity.JobRaw) []JobSourceViewModel {
+	sources := make([]JobSourceViewModel, 0, len(jobs))
+	for _, job := range jobs {
+		sources = append(sources, JobSourceViewModel{
+			Label:         sourceLabel(job.SourceLink),
+			SourceLink:    job.SourceLink,
+			DatePostedStr: job.DatePosted.Format("02.01.2006"),
+		})
+	}
+	return sources
+}
+
+// sourceLabel возвращает название источника по ссылке: "@канал" для постов Telegram, иначе домен сайта
+func sourceLabel(link string) string {
+	parsed, err := url.Parse(link)
+	if err != nil || parsed.Host == "" {
+		return link
+	}
+
+	host := strings.TrimPrefix(strings.ToLower(parsed.Host), "www.")
+	if host == "t.me" || host == "telegram.me" {
+		// Ссылка на пост имеет вид t.me/{канал}/{id} или t.me/s/{канал}/{id}
+		parts := strings.Split(strings.Trim(parsed.Path, "/"), "/")
+		if len(parts) > 1 && parts[0] == "s" {
+			parts = parts[1:]
+		}
+		if len(parts) > 0 && parts[0] != "" {
+			return "@" + parts[0]
+		}
+	}
+
+	return host
+}
@@ -2,87 +2,246 @@ package model
 
 import (
 	"fmt"
+	"html/template"
+	"net/url"
+	"strconv"
 	"strings"
 	"time"
 
+	"github.com/zalhonan/remotejobs-site/internal/domain/content"
 	"github.com/zalhonan/remotejobs-site/internal/domain/entity"
+	"github.com/zalhonan/remotejobs-site/internal/util/slug"
 )
 
 // JobViewModel модель представления для вакансии в списке
 type JobViewModel struct {
-	ID              int64     // ID вакансии
-	Title           string    // Заголовок вакансии
-	Content         string    // HTML содержимое вакансии для полной страницы
-	ContentPreview  string    // Текстовое содержимое вакансии для превью
-	SourceLink      string    // Ссылка на источник вакансии
-	MainTechnology  string    // Основная технология
-	DatePosted      time.Time // Дата публикации
-	DatePostedStr   string    // Форматированная дата публикации
-	Slug            string    // Часть URL для вакансии
-	URL             string    // Полный URL вакансии
-	MetaDescription string    // Мета-описание для SEO
+	ID              int64         // ID вакансии
+	Title           string        // Заголовок вакансии
+	Content         template.HTML // Очищенное HTML содержимое вакансии для полной страницы
+	ContentPreview  template.HTML // HTML превью вакансии для списков
+	SourceLink      string        // Ссылка на источник вакансии
+	MainTechnology  string        // Основная технология
+	DatePosted      time.Time     // Дата публикации
+	DatePostedStr   string        // Форматированная дата публикации
+	Slug            string        // Часть URL для вакансии
+	URL             string        // Полный URL вакансии
+	MetaDescription string        // Мета-описание для SEO
+	StopWords       []string      // Найденные стоп-слова (вакансия скрыта из списков по умолчанию)
+	Salary          string        // Зарплата для отображения (пусто - не указана)
+	Seniority       []string      // Уровни позиции для отображения
+	Location        []string      // Ограничения по месту работы для отображения
+	Expired         bool          // Вакансия устарела (показывается в поиске и за произвольный период)
 }
 
 // JobDetailViewModel модель представления для детальной страницы вакансии
 type JobDetailViewModel struct {
 	JobViewModel                          // Встраиваем базовую модель
 	RelatedJobs     []JobViewModel        // Связанные вакансии
+	AlsoPosted      []JobSourceViewModel  // Публикации этой же вакансии в других источниках
 	PageTitle       string                // Заголовок страницы
 	Technologies    []TechnologyViewModel // Список технологий для меню
 	MetaDescription string                // Мета-описание для SEO
+	StatusNotice    string                // Предупреждение об устаревшей вакансии (пусто - вакансия актуальна)
+	NoIndex         bool                  // Страница не должна индексироваться поисковиками
+}
+
+// JobStatusNotice возвращает предупреждение для страницы вакансии в зависимости от её статуса
+// (пусто - вакансия актуальна)
+func JobStatusNotice(status string) string {
+	switch status {
+	case entity.JobStatusExpired:
+		return "Вакансия опубликована давно и, скорее всего, уже закрыта"
+	case entity.JobStatusArchived:
+		return "Вакансия перенесена в архив и, скорее всего, уже закрыта"
+	default:
+		return ""
+	}
 }
 
 // JobListViewModel модель представления для списка вакансий
 type JobListViewModel struct {
-	Jobs            []JobViewModel        // Список вакансий
-	Technologies    []TechnologyViewModel // Список технологий
-	CurrentPage     int                   // Текущая страница
-	TotalPages      int                   // Общее количество страниц
-	Technology      string                // Текущая технология (если фильтр по технологии)
-	IsFiltered      bool                  // Флаг, указывающий на наличие фильтра
-	PrevPage        int                   // Предыдущая страница
-	NextPage        int                   // Следующая страница
-	PageTitle       string                // Заголовок страницы
-	BaseURL         string                // Базовый URL для пагинации
-	MetaDescription string                // Мета-описание для SEO
+	Jobs             []JobViewModel         // Список вакансий
+	Technologies     []TechnologyViewModel  // Список технологий
+	CurrentPage      int                    // Текущая страница
+	TotalPages       int                    // Общее количество страниц
+	Technology       string                 // Текущая технология (если фильтр по технологии)
+	IsFiltered       bool                   // Флаг, указывающий на наличие фильтра
+	PrevPage         int                    // Предыдущая страница
+	NextPage         int                    // Следующая страница
+	PageTitle        string                 // Заголовок страницы
+	BaseURL          string                 // Базовый URL для пагинации
+	PageInQuery      bool                   // Номер страницы передается в query-параметре page, а не в пути
+	BaseQuery        url.Values             // Параметры URL, определяющие список (поисковый запрос, технологии)
+	Filter           ListFilterViewModel    // Фильтры списка (дата публикации, скрытые вакансии)
+	PostedOptions    []PostedOption         // Варианты фильтра по дате публикации
+	Currencies       []SalaryCurrencyOption // Валюты фильтра по зарплате
+	SeniorityOptions []SeniorityOption      // Варианты фильтра по уровню позиции
+	LocationOptions  []LocationOption       // Варианты фильтра по ограничениям места работы
+	CountryOptions   []CountryOption        // Страны фильтра "можно работать из страны"
+	MetaDescription  string                 // Мета-описание для SEO
+	SearchQuery      string                 // Поисковый запрос (если это страница поиска)
+	IsSearch         bool                   // Флаг, указывающий на страницу результатов поиска
+	SelectedTechs    []string               // Выбранные технологии (если фильтр по нескольким технологиям)
+	IsMultiFilter    bool                   // Флаг, указывающий на фильтр по нескольким технологиям
+	IsCursorMode     bool                   // Навигация "новее/старее" по курсорам вместо номеров страниц
+	OlderURL         string                 // URL страницы с более старыми вакансиями (пусто - их нет)
+	NewerURL         string                 // URL страницы с более новыми вакансиями (пусто - их нет)
 }
 
-// createMetaDescriptionFromContent создает мета-описание из содержимого
-func createMetaDescriptionFromContent(content string, technology string) string {
-	// Очистка от HTML и экстра-пробелов
-	trimmedContent := strings.TrimSpace(content)
+// query возвращает все параметры URL, которые сохраняются при переходе между страницами
+func (m JobListViewModel) query() url.Values {
+	query := url.Values{}
+	for key, values := range m.BaseQuery {
+		query[key] = append([]string(nil), values...)
+	}
+	for key, values := range m.Filter.Query() {
+		query[key] = values
+	}
+	return query
+}
 
-	// Если контент пустой, возвращаем дефолтное описание с технологией
-	if trimmedContent == "" {
-		if technology != "" {
-			return fmt.Sprintf("Удаленная вакансия по %s. Актуальные предложения о работе в IT с возможностью удаленной работы.", technology)
-		}
-		return "Актуальные удаленные вакансии в сфере IT. Работа из любой точки мира."
+// ListPath возвращает путь первой страницы списка без параметров запроса
+func (m JobListViewModel) ListPath() string {
+	if m.BaseURL == "/" {
+		return m.BaseURL
 	}
+	// Первая страница технологии доступна по адресу без завершающего слеша
+	return strings.TrimSuffix(m.BaseURL, "/")
+}
+
+// PageURL возвращает URL указанной страницы списка с сохранением всех фильтров
+func (m JobListViewModel) PageURL(page int) string {
+	path := m.ListPath()
+	query := m.query()
 
-	// Ограничиваем длину контента для мета-описания
-	const maxDescriptionLength = 160
-	if len(trimmedContent) > maxDescriptionLength {
-		// Ищем последний пробел перед ограничением длины
-		lastSpace := strings.LastIndex(trimmedContent[:maxDescriptionLength], " ")
-		if lastSpace > 0 {
-			trimmedContent = trimmedContent[:lastSpace]
+	if page > 1 {
+		if m.PageInQuery {
+			query.Set("page", strconv.Itoa(page))
 		} else {
-			trimmedContent = trimmedContent[:maxDescriptionLength]
+			path = m.BaseURL + strconv.Itoa(page)
+		}
+	}
+
+	if len(query) > 0 {
+		path += "?" + query.Encode()
+	}
+
+	return path
+}
+
+// CursorURL возвращает URL списка с переходом от курсора в указанном направлении
+func (m JobListViewModel) CursorURL(cursor entity.JobCursor, direction entity.CursorDirection) string {
+	query := m.query()
+	if direction == entity.CursorNewer {
+		query.Set("after", cursor.String())
+	} else {
+		query.Set("before", cursor.String())
+	}
+
+	return m.ListPath() + "?" + query.Encode()
+}
+
+// ApplyCursorPage переключает модель на навигацию "новее/старее" по курсорам страницы
+func (m *JobListViewModel) ApplyCursorPage(page entity.JobCursorPage) {
+	m.IsCursorMode = true
+	if page.Older != nil {
+		m.OlderURL = m.CursorURL(*page.Older, entity.CursorOlder)
+	}
+	if page.Newer != nil {
+		m.NewerURL = m.CursorURL(*page.Newer, entity.CursorNewer)
+	}
+}
+
+// PostedURL возвращает URL первой страницы списка с другим значением фильтра по дате публикации
+func (m JobListViewModel) PostedURL(value string) string {
+	viewModel := m
+	viewModel.Filter.Posted = PostedFilterViewModel{Value: value}
+	return viewModel.PageURL(1)
+}
+
+// SalaryURL возвращает URL первой страницы списка без фильтра по зарплате
+func (m JobListViewModel) SalaryURL() string {
+	viewModel := m
+	viewModel.Filter.Salary = SalaryFilterViewModel{}
+	return viewModel.PageURL(1)
+}
+
+// SeniorityURL возвращает URL первой страницы списка, в котором уровень позиции
+// добавлен в фильтр или убран из него
+func (m JobListViewModel) SeniorityURL(level string) string {
+	viewModel := m
+	viewModel.Filter.Seniority = make([]string, 0, len(m.Filter.Seniority)+1)
+
+	// Сохраняем порядок уровней по возрастанию, чтобы у одного фильтра был один URL
+	for _, option := range SeniorityOptions {
+		selected := m.Filter.HasSeniority(option.Level)
+		if option.Level == level {
+			selected = !selected
+		}
+		if selected {
+			viewModel.Filter.Seniority = append(viewModel.Filter.Seniority, option.Level)
+		}
+	}
+
+	return viewModel.PageURL(1)
+}
+
+// LocationURL возвращает URL первой страницы списка, в котором ограничение по месту работы
+// добавлено в фильтр или убрано из него
+func (m JobListViewModel) LocationURL(value string) string {
+	viewModel := m
+	viewModel.Filter.Location = make([]string, 0, len(m.Filter.Location)+1)
+
+	for _, option := range LocationOptions {
+		selected := m.Filter.HasLocation(option.Value)
+		if option.Value == value {
+			selected = !selected
+		}
+		if selected {
+			viewModel.Filter.Location = append(viewModel.Filter.Location, option.Value)
+		}
+	}
+
+	return viewModel.PageURL(1)
+}
+
+// FilterInputs возвращает параметры текущего списка, кроме перечисленных,
+// для передачи скрытыми полями в формах фильтров
+func (m JobListViewModel) FilterInputs(exclude ...string) url.Values {
+	query := m.query()
+	for _, key := range exclude {
+		query.Del(key)
+	}
+	return query
+}
+
+// HiddenURL возвращает URL первой страницы списка с включенным или выключенным показом скрытых вакансий
+func (m JobListViewModel) HiddenURL(show bool) string {
+	viewModel := m
+	viewModel.Filter.ShowHidden = show
+	return viewModel.PageURL(1)
+}
+
+// createMetaDescriptionFromContent создает мета-описание из текста, подготовленного content.Description
+func createMetaDescriptionFromContent(description string, technology string) string {
+	// Если описание пустое, возвращаем дефолтное описание с технологией
+	if description == "" {
+		if technology != "" {
+			return fmt.Sprintf("Удаленная вакансия по %s. Актуальные предложения о работе в IT с возможностью удаленной работы.", technology)
 		}
-		trimmedContent += "..."
+		return "Актуальные удаленные вакансии в сфере IT. Работа из любой точки мира."
 	}
 
 	// Добавляем информацию о технологии, если она указана
 	if technology != "" {
-		return fmt.Sprintf("%s | Удаленная работа по %s", trimmedContent, technology)
+		return fmt.Sprintf("%s | Удаленная работа по %s", description, technology)
 	}
 
-	return trimmedContent
+	return description
 }
 
 // NewJobViewModelFromEntity создает модель представления из доменной сущности
-func NewJobViewModelFromEntity(job entity.JobRaw, slug string) JobViewModel {
+func NewJobViewModelFromEntity(job entity.JobRaw, jobSlug string) JobViewModel {
 	// Форматируем дату для отображения
 	datePostedStr := job.DatePosted.Format("02.01.2006")
 
@@ -93,36 +252,59 @@ func NewJobViewModelFromEntity(job entity.JobRaw, slug string) JobViewModel {
 		title = "Вакансия по " + job.MainTechnology
 	}
 
-	// Проверяем, не пустой ли slug
-	if slug == "" {
-		// Формируем простой fallback slug - просто числовой ID
-		slug = fmt.Sprintf("%d", job.ID)
+	// Формируем URL вакансии, используя только слаг из базы данных
+	jobSlug = slugOrFallback(job.ID, jobSlug, job.Title)
+	url := fmt.Sprintf("/job/%s", jobSlug)
+
+	// Используем текст, подготовленный при загрузке вакансии. Если он еще не подготовлен
+	// (вакансия сохранена до появления этих колонок), готовим его при показе
+	contentHTML := job.ContentSanitized
+	if contentHTML == "" {
+		contentHTML = content.Sanitize(job.Content)
 	}
 
-	// Формируем URL вакансии, используя только слаг из базы данных
-	url := fmt.Sprintf("/job/%s", slug)
+	preview := job.ContentPreview
+	if preview == "" {
+		preview = content.Preview(job.ContentPure, content.PreviewLines, content.PreviewMaxRunes)
+	}
 
-	// Очищаем HTML-контент от лишних пробелов в начале
-	content := strings.TrimLeft(job.Content, " \t\n\r")
+	description := job.MetaDescription
+	if description == "" {
+		description = content.Description(job.ContentPure, content.DescriptionMaxRunes)
+	}
 
-	// Создаем мета-описание из ContentPure
-	metaDescription := createMetaDescriptionFromContent(job.ContentPure, job.MainTechnology)
+	// Создаем мета-описание с названием технологии: технология может смениться после загрузки вакансии
+	metaDescription := createMetaDescriptionFromContent(description, job.MainTechnology)
 
 	return JobViewModel{
 		ID:              job.ID,
 		Title:           title,
-		Content:         content,         // HTML содержимое для полной страницы
-		ContentPreview:  job.ContentPure, // Текстовое содержимое для превью
+		Content:         template.HTML(contentHTML), // Очищено от опасных элементов при подготовке
+		ContentPreview:  template.HTML(preview),     // Текст экранирован при подготовке превью
 		SourceLink:      job.SourceLink,
 		MainTechnology:  job.MainTechnology,
 		DatePosted:      job.DatePosted,
 		DatePostedStr:   datePostedStr,
-		Slug:            slug,
+		Slug:            jobSlug,
 		URL:             url,
 		MetaDescription: metaDescription,
+		StopWords:       job.StopWords,
+		Salary:          FormatSalary(job.Salary),
+		Seniority:       seniorityLabels(job.Seniority),
+		Location:        locationLabels(job.Location),
+		Expired:         job.Status == entity.JobStatusExpired,
 	}
 }
 
+// NewJobViewModels создает модели представления для списка доменных сущностей
+func NewJobViewModels(jobs []entity.JobRaw) []JobViewModel {
+	viewModels := make([]JobViewModel, 0, len(jobs))
+	for _, job := range jobs {
+		viewModels = append(viewModels, NewJobViewModelFromEntity(job, job.Slug))
+	}
+	return viewModels
+}
+
 // NewJobListViewModel создает модель представления списка вакансий
 func NewJobListViewModel(
 	jobs []JobViewModel,
@@ -144,29 +326,98 @@ func NewJobListViewModel(
 	baseURL := "/"
 	pageTitle := "Вакансии, удалённая работа в IT"
 
-	// Создаем мета-описание для списка вакансий
+	// Создаем мета-описание для списка вакансий.
+	// При курсорной навигации количество страниц не считается, поэтому оценки нет
+	offersCount := "Много"
+	if totalPages > 0 {
+		offersCount = fmt.Sprintf("%d+", totalPages*10) // Примерная оценка количества вакансий
+	}
+
 	var metaDescription string
 	if isFiltered {
 		baseURL = "/" + technology + "/"
 		pageTitle = "Вакансии по " + technology
-		metaDescription = fmt.Sprintf("Актуальные удаленные вакансии по технологии %s. %d+ предложений о работе с возможностью работать из любой точки мира. Обновляется ежедневно.",
-			technology, totalPages*10) // Примерная оценка количества вакансий
+		metaDescription = fmt.Sprintf("Актуальные удаленные вакансии по технологии %s. %s предложений о работе с возможностью работать из любой точки мира. Обновляется ежедневно.",
+			technology, offersCount)
 	} else {
-		metaDescription = fmt.Sprintf("Свежие удаленные вакансии в IT. %d+ предложений о работе из любой точки мира. Фильтры по популярным технологиям, ежедневные обновления.",
-			totalPages*10) // Примерная оценка количества вакансий
+		metaDescription = fmt.Sprintf("Свежие удаленные вакансии в IT. %s предложений о работе из любой точки мира. Фильтры по популярным технологиям, ежедневные обновления.",
+			offersCount)
 	}
 
 	return JobListViewModel{
-		Jobs:            jobs,
-		Technologies:    technologies,
-		CurrentPage:     currentPage,
-		TotalPages:      totalPages,
-		Technology:      technology,
-		IsFiltered:      isFiltered,
-		PrevPage:        prevPage,
-		NextPage:        nextPage,
-		PageTitle:       pageTitle,
-		BaseURL:         baseURL,
-		MetaDescription: metaDescription,
+		Jobs:             jobs,
+		Technologies:     technologies,
+		CurrentPage:      currentPage,
+		TotalPages:       totalPages,
+		Technology:       technology,
+		IsFiltered:       isFiltered,
+		PrevPage:         prevPage,
+		NextPage:         nextPage,
+		PageTitle:        pageTitle,
+		BaseURL:          baseURL,
+		BaseQuery:        url.Values{},
+		PostedOptions:    PostedOptions,
+		Currencies:       SalaryCurrencies,
+		SeniorityOptions: SeniorityOptions,
+		LocationOptions:  LocationOptions,
+		CountryOptions:   CountryOptions,
+		MetaDescription:  metaDescription,
 	}
 }
+
+// NewSearchListViewModel создает модель представления для результатов полнотекстового поиска
+func NewSearchListViewModel(
+	jobs []JobViewModel,
+	technologies []TechnologyViewModel,
+	currentPage, totalPages int,
+	searchQuery string,
+) JobListViewModel {
+	viewModel := NewJobListViewModel(jobs, technologies, currentPage, totalPages, "")
+
+	viewModel.BaseURL = "/search"
+	viewModel.PageInQuery = true
+	viewModel.BaseQuery = url.Values{"q": {searchQuery}}
+	viewModel.PageTitle = "Поиск вакансий: " + searchQuery
+	viewModel.MetaDescription = fmt.Sprintf("Удаленные вакансии в IT по запросу «%s». Поиск по заголовкам и описаниям вакансий.", searchQuery)
+	viewModel.SearchQuery = searchQuery
+	viewModel.IsSearch = true
+
+	return viewModel
+}
+
+// NewMultiTechnologyListViewModel создает модель представления для фильтра по нескольким технологиям
+func NewMultiTechnologyListViewModel(
+	jobs []JobViewModel,
+	technologies []TechnologyViewModel,
+	currentPage, totalPages int,
+	selected []string,
+) JobListViewModel {
+	viewModel := NewJobListViewModel(jobs, technologies, currentPage, totalPages, "")
+
+	joined := strings.Join(selected, ", ")
+
+	viewModel.BaseURL = "/filter"
+	viewModel.PageInQuery = true
+	viewModel.BaseQuery = url.Values{"tech": selected}
+	viewModel.PageTitle = "Вакансии по " + joined
+	viewModel.MetaDescription = fmt.Sprintf("Актуальные удаленные вакансии по технологиям %s. Предложения о работе с возможностью работать из любой точки мира.", joined)
+	viewModel.SelectedTechs = selected
+	viewModel.IsMultiFilter = true
+
+	return viewModel
+}
+
+// JobURL возвращает URL страницы вакансии по слагу из базы данных
+func JobURL(id int64, jobSlug, title string) string {
+	return fmt.Sprintf("/job/%s", slugOrFallback(id, jobSlug, title))
+}
+
+// slugOrFallback возвращает слаг вакансии, а для вакансии без слага - запасной слаг из заголовка.
+// Страница вакансии открывается по URL вида /job/{id}-{slug}, поэтому запасной слаг тоже начинается с ID
+func slugOrFallback(id int64, jobSlug, title string) string {
+	if jobSlug == "" {
+		return slug.WithID(id, slug.Make(title, slug.DefaultMaxLength))
+	}
+
+	return jobSlug
+}
@@ -0,0 +1,60 @@
+package model
+
+import "net/url"
+
+// ListFilterViewModel состояние фильтров списка вакансий, сохраняемых в URL
+type ListFilterViewModel struct {
+	Posted     PostedFilterViewModel // Фильтр по дате публикации
+	Salary     SalaryFilterViewModel // Фильтр по зарплате
+	Seniority  []string              // Выбранные уровни позиции
+	Location   []string              // Выбранные ограничения по месту работы
+	Country    string                // Страна, из которой нужно работать
+	ShowHidden bool                  // Показывать вакансии со стоп-словами
+}
+
+// IsActive сообщает, что список отличается от стандартного хотя бы одним фильтром
+func (f ListFilterViewModel) IsActive() bool {
+	return f.Posted.IsActive() || f.Salary.IsActive() || len(f.Seniority) > 0 ||
+		len(f.Location) > 0 || f.Country != "" || f.ShowHidden
+}
+
+// HasSeniority сообщает, выбран ли уровень позиции в фильтре
+func (f ListFilterViewModel) HasSeniority(level string) bool {
+	for _, selected := range f.Seniority {
+		if selected == level {
+			return true
+		}
+	}
+	return false
+}
+
+// HasLocation сообщает, выбрано ли ограничение по месту работы в фильтре
+func (f ListFilterViewModel) HasLocation(value string) bool {
+	for _, selected := range f.Location {
+		if selected == value {
+			return true
+		}
+	}
+	return false
+}
+
+// Query возвращает параметры URL, описывающие фильтры
+func (f ListFilterViewModel) Query() url.Values {
+	query := f.Posted.Query()
+	for key, values := range f.Salary.Query() {
+		query[key] = values
+	}
+	if len(f.Seniority) > 0 {
+		query["level"] = f.Seniority
+	}
+	if len(f.Location) > 0 {
+		query["location"] = f.Location
+	}
+	if f.Country != "" {
+		query.Set("country", f.Country)
+	}
+	if f.ShowHidden {
+		query.Set("hidden", "1")
+	}
+	return query
+}
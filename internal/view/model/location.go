@@ -0,0 +1,91 @@
+package model
+
+import (
+	"strings"
+
+	"github.com/zalhonan/remotejobs-site/internal/domain/entity"
+)
+
+// LocationOption вариант фильтра по ограничениям места работы
+type LocationOption struct {
+	Value string // Значение параметра location в URL
+	Label string // Подпись для отображения
+}
+
+// LocationOptions ограничения по месту работы, доступные в фильтре
+var LocationOptions = []LocationOption{
+	{Value: entity.LocationWorldwide, Label: "Из любой страны"},
+	{Value: entity.LocationCountry, Label: "Только из определенных стран"},
+	{Value: entity.LocationTimezone, Label: "Часовой пояс"},
+	{Value: entity.LocationRelocation, Label: "Релокация"},
+	{Value: entity.LocationHybrid, Label: "Гибрид"},
+}
+
+// FindLocationOption возвращает вариант фильтра по значению параметра location
+func FindLocationOption(value string) (LocationOption, bool) {
+	for _, option := range LocationOptions {
+		if option.Value == value {
+			return option, true
+		}
+	}
+	return LocationOption{}, false
+}
+
+// CountryOption страна в фильтре "можно работать из страны"
+type CountryOption struct {
+	Code string // Код страны в URL
+	Name string // Название для отображения
+}
+
+// CountryOptions страны, которые чаще всего указывают в вакансиях
+var CountryOptions = []CountryOption{
+	{Code: "RU", Name: "Россия"},
+	{Code: "BY", Name: "Беларусь"},
+	{Code: "KZ", Name: "Казахстан"},
+	{Code: "UZ", Name: "Узбекистан"},
+	{Code: "AM", Name: "Армения"},
+	{Code: "GE", Name: "Грузия"},
+	{Code: "RS", Name: "Сербия"},
+	{Code: "UA", Name: "Украина"},
+	{Code: "EU", Name: "Евросоюз"},
+	{Code: "GB", Name: "Великобритания"},
+	{Code: "US", Name: "США"},
+}
+
+// FindCountryOption возвращает страну по коду
+func FindCountryOption(code string) (CountryOption, bool) {
+	for _, option := range CountryOptions {
+		if option.Code == code {
+			return option, true
+		}
+	}
+	return CountryOption{}, false
+}
+
+// locationLabels возвращает подписи ограничений по месту работы для отображения:
+// "Из любой страны", "Только: Россия, Беларусь", "Часовой пояс"
+func locationLabels(location entity.Location) []string {
+	labels := make([]string, 0, len(location.Constraints))
+	for _, constraint := range location.Constraints {
+		option, ok := FindLocationOption(constraint)
+		if !ok {
+			continue
+		}
+
+		if constraint == entity.LocationCountry && len(location.Countries) > 0 {
+			names := make([]string, 0, len(location.Countries))
+			for _, code := range location.Countries {
+				if country, ok := FindCountryOption(code); ok {
+					names = append(names, country.Name)
+				} else {
+					names = append(names, code)
+				}
+			}
+			labels = append(labels, "Только: "+strings.Join(names, ", "))
+			continue
+		}
+
+		labels = append(labels, option.Label)
+	}
+	return labels
+}
@@ -0,0 +1,76 @@
+package model
+
+import (
+	"net/url"
+	"time"
+)
+
+const (
+	// PostedCustom значение фильтра для произвольного периода публикации
+	PostedCustom = "custom"
+
+	// PostedDateLayout формат дат произвольного периода в URL
+	PostedDateLayout = "2006-01-02"
+)
+
+// PostedOption вариант фильтра "опубликовано за"
+type PostedOption struct {
+	Value  string        // Значение параметра posted в URL
+	Label  string        // Подпись для отображения
+	Period time.Duration // Период, отсчитываемый от текущего момента
+}
+
+// PostedOptions доступные варианты фильтра по свежести вакансий
+var PostedOptions = []PostedOption{
+	{Value: "24h", Label: "24 часа", Period: 24 * time.Hour},
+	{Value: "3d", Label: "3 дня", Period: 3 * 24 * time.Hour},
+	{Value: "7d", Label: "7 дней", Period: 7 * 24 * time.Hour},
+	{Value: "30d", Label: "30 дней", Period: 30 * 24 * time.Hour},
+}
+
+// FindPostedOption возвращает вариант фильтра по значению параметра posted
+func FindPostedOption(value string) (PostedOption, bool) {
+	for _, option := range PostedOptions {
+		if option.Value == value {
+			return option, true
+		}
+	}
+	return PostedOption{}, false
+}
+
+// PostedFilterViewModel текущее состояние фильтра по дате публикации
+type PostedFilterViewModel struct {
+	Value string // Выбранный вариант (пусто - за всё время)
+	From  string // Начало произвольного периода в формате ГГГГ-ММ-ДД
+	To    string // Конец произвольного периода в формате ГГГГ-ММ-ДД
+}
+
+// IsActive сообщает, ограничен ли список по дате публикации
+func (f PostedFilterViewModel) IsActive() bool {
+	return f.Value != ""
+}
+
+// IsCustom сообщает, выбран ли произвольный период
+func (f PostedFilterViewModel) IsCustom() bool {
+	return f.Value == PostedCustom
+}
+
+// Query возвращает параметры URL, описывающие фильтр
+func (f PostedFilterViewModel) Query() url.Values {
+	query := url.Values{}
+	if f.Value == "" {
+		return query
+	}
+
+	query.Set("posted", f.Value)
+	if f.Value == PostedCustom {
+		if f.From != "" {
+			query.Set("from", f.From)
+		}
+		if f.To != "" {
+			query.Set("to", f.To)
+		}
+	}
+
+	return query
+}
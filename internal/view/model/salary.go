@@ -0,0 +1,121 @@
+package model
+
+import (
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/zalhonan/remotejobs-site/internal/domain/entity"
+)
+
+// SalaryCurrencyOption вариант валюты в фильтре по зарплате
+type SalaryCurrencyOption struct {
+	Code   string // Код валюты в URL
+	Symbol string // Обозначение для отображения
+}
+
+// SalaryCurrencies валюты, доступные в фильтре по зарплате. Первая используется по умолчанию
+var SalaryCurrencies = []SalaryCurrencyOption{
+	{Code: entity.CurrencyRUB, Symbol: "₽"},
+	{Code: entity.CurrencyUSD, Symbol: "$"},
+	{Code: entity.CurrencyEUR, Symbol: "€"},
+	{Code: entity.CurrencyGBP, Symbol: "£"},
+}
+
+// FindSalaryCurrency возвращает вариант валюты по коду
+func FindSalaryCurrency(code string) (SalaryCurrencyOption, bool) {
+	for _, option := range SalaryCurrencies {
+		if option.Code == code {
+			return option, true
+		}
+	}
+	return SalaryCurrencyOption{}, false
+}
+
+// salaryPeriodLabels подписи периодов зарплаты
+var salaryPeriodLabels = map[string]string{
+	entity.SalaryPeriodHour:  "/час",
+	entity.SalaryPeriodMonth: "/мес",
+	entity.SalaryPeriodYear:  "/год",
+}
+
+// salaryTaxLabels подписи указания налогов
+var salaryTaxLabels = map[string]string{
+	entity.SalaryTaxGross: "до вычета налогов",
+	entity.SalaryTaxNet:   "на руки",
+}
+
+// FormatSalary форматирует зарплату для отображения: "от 250 000 ₽/мес, на руки"
+func FormatSalary(salary entity.Salary) string {
+	if salary.IsZero() {
+		return ""
+	}
+
+	symbol := salary.Currency
+	if option, ok := FindSalaryCurrency(salary.Currency); ok {
+		symbol = option.Symbol
+	}
+
+	var amount string
+	switch {
+	case salary.Min > 0 && salary.Max > 0 && salary.Min != salary.Max:
+		amount = formatAmount(salary.Min) + " – " + formatAmount(salary.Max)
+	case salary.Min > 0 && salary.Max > 0:
+		amount = formatAmount(salary.Min)
+	case salary.Min > 0:
+		amount = "от " + formatAmount(salary.Min)
+	default:
+		amount = "до " + formatAmount(salary.Max)
+	}
+
+	result := amount + " " + symbol + salaryPeriodLabels[salary.Period]
+	if label := salaryTaxLabels[salary.Tax]; label != "" {
+		result += ", " + label
+	}
+
+	return result
+}
+
+// formatAmount разделяет разряды числа пробелами: 250000 -> "250 000"
+func formatAmount(amount int64) string {
+	digits := strconv.FormatInt(amount, 10)
+
+	var builder strings.Builder
+	for i, digit := range digits {
+		if i > 0 && (len(digits)-i)%3 == 0 {
+			builder.WriteRune(' ')
+		}
+		builder.WriteRune(digit)
+	}
+	return builder.String()
+}
+
+// SalaryFilterViewModel текущее состояние фильтра по зарплате
+type SalaryFilterViewModel struct {
+	From     string // Зарплата в месяц от
+	To       string // Зарплата в месяц до
+	Currency string // Код валюты (пусто - фильтр не задан)
+}
+
+// IsActive сообщает, ограничен ли список по зарплате
+func (f SalaryFilterViewModel) IsActive() bool {
+	return f.Currency != ""
+}
+
+// Query возвращает параметры URL, описывающие фильтр
+func (f SalaryFilterViewModel) Query() url.Values {
+	query := url.Values{}
+	if f.Currency == "" {
+		return query
+	}
+
+	query.Set("currency", f.Currency)
+	if f.From != "" {
+		query.Set("salary_from", f.From)
+	}
+	if f.To != "" {
+		query.Set("salary_to", f.To)
+	}
+
+	return query
+}
@@ -0,0 +1,40 @@
+package model
+
+import "github.com/zalhonan/remotejobs-site/internal/domain/entity"
+
+// SeniorityOption вариант фильтра по уровню позиции
+type SeniorityOption struct {
+	Level string // Значение параметра level в URL
+	Label string // Подпись для отображения
+}
+
+// SeniorityOptions уровни позиции в порядке возрастания
+var SeniorityOptions = []SeniorityOption{
+	{Level: entity.SeniorityIntern, Label: "Стажер"},
+	{Level: entity.SeniorityJunior, Label: "Junior"},
+	{Level: entity.SeniorityMiddle, Label: "Middle"},
+	{Level: entity.SenioritySenior, Label: "Senior"},
+	{Level: entity.SeniorityLead, Label: "Lead"},
+	{Level: entity.SeniorityPrincipal, Label: "Principal"},
+}
+
+// FindSeniorityOption возвращает вариант фильтра по значению параметра level
+func FindSeniorityOption(level string) (SeniorityOption, bool) {
+	for _, option := range SeniorityOptions {
+		if option.Level == level {
+			return option, true
+		}
+	}
+	return SeniorityOption{}, false
+}
+
+// seniorityLabels возвращает подписи уровней позиции для отображения
+func seniorityLabels(levels []string) []string {
+	labels := make([]string, 0, len(levels))
+	for _, level := range levels {
+		if option, ok := FindSeniorityOption(level); ok {
+			labels = append(labels, option.Label)
+		}
+	}
+	return labels
+}
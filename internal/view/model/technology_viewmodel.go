@@ -6,12 +6,14 @@ import (
 
 // TechnologyViewModel модель представления для технологии
 type TechnologyViewModel struct {
-	ID        int64    // ID технологии
-	Name      string   // Название технологии
-	Keywords  []string // Ключевые слова
-	SortOrder int      // Порядок сортировки
-	URL       string   // URL для фильтрации вакансий по технологии
-	JobsCount int64    // Количество доступных вакансий
+	ID              int64    // ID технологии
+	Name            string   // Название технологии
+	Keywords        []string // Ключевые слова
+	SortOrder       int      // Порядок сортировки
+	URL             string   // URL для фильтрации вакансий по технологии
+	JobsCount       int64    // Количество доступных вакансий
+	JobsCountRecent int64    // Количество доступных вакансий за последние 30 дней
+	Selected        bool     // Технология выбрана в текущем фильтре
 }
 
 // NewTechnologyViewModelFromEntity создает модель представления из доменной сущности
@@ -20,11 +22,24 @@ func NewTechnologyViewModelFromEntity(tech entity.Technology) TechnologyViewMode
 	url := "/" + tech.Technology
 
 	return TechnologyViewModel{
-		ID:        tech.ID,
-		Name:      tech.Technology,
-		Keywords:  tech.Keywords,
-		SortOrder: tech.SortOrder,
-		URL:       url,
-		JobsCount: tech.Count,
+		ID:              tech.ID,
+		Name:            tech.Technology,
+		Keywords:        tech.Keywords,
+		SortOrder:       tech.SortOrder,
+		URL:             url,
+		JobsCount:       tech.Count,
+		JobsCountRecent: tech.CountRecent,
+	}
+}
+
+// MarkSelectedTechnologies отмечает технологии, выбранные в текущем фильтре
+func MarkSelectedTechnologies(technologies []TechnologyViewModel, selected []string) {
+	selectedSet := make(map[string]struct{}, len(selected))
+	for _, name := range selected {
+		selectedSet[name] = struct{}{}
+	}
+
+	for i := range technologies {
+		_, technologies[i].Selected = selectedSet[technologies[i].Name]
 	}
 }
@@ -0,0 +1,18 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Полнотекстовый индекс по заголовку и тексту вакансии с русской и английской морфологией
+ALTER TABLE jobs_raw ADD COLUMN IF NOT EXISTS search_vector tsvector GENERATED ALWAYS AS (
+    setweight(to_tsvector('russian', coalesce(title, '')), 'A') ||
+    setweight(to_tsvector('english', coalesce(title, '')), 'A') ||
+    setweight(to_tsvector('russian', coalesce(content_pure, '')), 'B') ||
+    setweight(to_tsvector('english', coalesce(content_pure, '')), 'B')
+) STORED;
+
+CREATE INDEX IF NOT EXISTS idx_jobs_raw_search_vector ON jobs_raw USING GIN (search_vector);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS idx_jobs_raw_search_vector;
+ALTER TABLE jobs_raw DROP COLUMN IF EXISTS search_vector;
+-- +goose StatementEnd
@@ -0,0 +1,12 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Индекс для курсорной пагинации по (date_posted, id)
+CREATE INDEX IF NOT EXISTS idx_jobs_raw_date_posted_id ON jobs_raw (date_posted DESC, id DESC);
+CREATE INDEX IF NOT EXISTS idx_jobs_raw_technology_date_posted_id ON jobs_raw (main_technology, date_posted DESC, id DESC);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS idx_jobs_raw_technology_date_posted_id;
+DROP INDEX IF EXISTS idx_jobs_raw_date_posted_id;
+-- +goose StatementEnd
@@ -0,0 +1,12 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Триграммный индекс по заголовку для поиска похожих вакансий
+CREATE EXTENSION IF NOT EXISTS pg_trgm;
+
+CREATE INDEX IF NOT EXISTS idx_jobs_raw_title_trgm ON jobs_raw USING GIN (title gin_trgm_ops);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS idx_jobs_raw_title_trgm;
+-- +goose StatementEnd
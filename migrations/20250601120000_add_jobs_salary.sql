@@ -0,0 +1,41 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Зарплата, найденная в тексте вакансии. Суммы хранятся так, как указаны в вакансии,
+-- а для фильтрации приводятся к сумме в месяц (168 рабочих часов в месяце)
+ALTER TABLE jobs_raw ADD COLUMN IF NOT EXISTS salary_min BIGINT;
+ALTER TABLE jobs_raw ADD COLUMN IF NOT EXISTS salary_max BIGINT;
+ALTER TABLE jobs_raw ADD COLUMN IF NOT EXISTS salary_currency VARCHAR(3);
+ALTER TABLE jobs_raw ADD COLUMN IF NOT EXISTS salary_period VARCHAR(10);
+ALTER TABLE jobs_raw ADD COLUMN IF NOT EXISTS salary_tax VARCHAR(10);
+
+ALTER TABLE jobs_raw ADD COLUMN IF NOT EXISTS salary_month_min BIGINT GENERATED ALWAYS AS (
+    CASE salary_period
+        WHEN 'hour' THEN salary_min * 168
+        WHEN 'year' THEN salary_min / 12
+        ELSE salary_min
+    END
+) STORED;
+
+ALTER TABLE jobs_raw ADD COLUMN IF NOT EXISTS salary_month_max BIGINT GENERATED ALWAYS AS (
+    CASE salary_period
+        WHEN 'hour' THEN salary_max * 168
+        WHEN 'year' THEN salary_max / 12
+        ELSE salary_max
+    END
+) STORED;
+
+CREATE INDEX IF NOT EXISTS idx_jobs_raw_salary ON jobs_raw (salary_currency, salary_month_min, salary_month_max)
+    WHERE salary_currency IS NOT NULL;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS idx_jobs_raw_salary;
+ALTER TABLE jobs_raw DROP COLUMN IF EXISTS salary_month_max;
+ALTER TABLE jobs_raw DROP COLUMN IF EXISTS salary_month_min;
+ALTER TABLE jobs_raw DROP COLUMN IF EXISTS salary_tax;
+ALTER TABLE jobs_raw DROP COLUMN IF EXISTS salary_period;
+ALTER TABLE jobs_raw DROP COLUMN IF EXISTS salary_currency;
+ALTER TABLE jobs_raw DROP COLUMN IF EXISTS salary_max;
+ALTER TABLE jobs_raw DROP COLUMN IF EXISTS salary_min;
+-- +goose StatementEnd
@@ -0,0 +1,30 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Правила определения уровня позиции: ключевые слова для каждого уровня
+CREATE TABLE IF NOT EXISTS seniority_rules (
+    id BIGSERIAL PRIMARY KEY,
+    level VARCHAR(20) NOT NULL UNIQUE CHECK (level IN ('intern', 'junior', 'middle', 'senior', 'lead', 'principal')),
+    keywords TEXT[] NOT NULL
+);
+
+INSERT INTO seniority_rules (level, keywords) VALUES
+    ('intern', ARRAY['intern', 'internship', 'trainee', 'стажер', 'стажёр', 'стажировка']),
+    ('junior', ARRAY['junior', 'entry level', 'entry-level', 'джун', 'джуниор', 'младший', 'начинающий']),
+    ('middle', ARRAY['middle', 'mid-level', 'mid level', 'мидл', 'миддл']),
+    ('senior', ARRAY['senior', 'sr', 'сеньор', 'синьор', 'сениор', 'старший']),
+    ('lead', ARRAY['lead', 'team lead', 'teamlead', 'tech lead', 'techlead', 'тимлид', 'техлид', 'ведущий']),
+    ('principal', ARRAY['principal', 'staff engineer', 'distinguished engineer'])
+ON CONFLICT (level) DO NOTHING;
+
+-- Уровни позиции, найденные в вакансии
+ALTER TABLE jobs_raw ADD COLUMN IF NOT EXISTS seniority TEXT[];
+
+CREATE INDEX IF NOT EXISTS idx_jobs_raw_seniority ON jobs_raw USING GIN (seniority);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS idx_jobs_raw_seniority;
+ALTER TABLE jobs_raw DROP COLUMN IF EXISTS seniority;
+DROP TABLE IF EXISTS seniority_rules;
+-- +goose StatementEnd
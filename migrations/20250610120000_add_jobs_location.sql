@@ -0,0 +1,16 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Ограничения по месту работы: worldwide, country, timezone, relocation, hybrid
+ALTER TABLE jobs_raw ADD COLUMN IF NOT EXISTS location_constraints TEXT[];
+-- Страны, в которых нужно находиться, если указано ограничение country
+ALTER TABLE jobs_raw ADD COLUMN IF NOT EXISTS location_countries TEXT[];
+
+CREATE INDEX IF NOT EXISTS idx_jobs_raw_location_constraints ON jobs_raw USING GIN (location_constraints);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS idx_jobs_raw_location_constraints;
+ALTER TABLE jobs_raw DROP COLUMN IF EXISTS location_countries;
+ALTER TABLE jobs_raw DROP COLUMN IF EXISTS location_constraints;
+-- +goose StatementEnd
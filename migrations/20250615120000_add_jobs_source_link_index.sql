@@ -0,0 +1,37 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Адреса удаленных повторов вакансий: старые ссылки /job/{id} ведут на оставленную вакансию
+CREATE TABLE IF NOT EXISTS job_redirects (
+    old_id BIGINT PRIMARY KEY,
+    job_id BIGINT NOT NULL REFERENCES jobs_raw (id) ON DELETE CASCADE,
+    date_created TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+-- Из повторов одного поста остается вакансия с определенной технологией, среди них - самая ранняя:
+-- её адрес раньше других попал в выдачу и поисковики. Адреса остальных перенаправляются на неё
+WITH ranked AS (
+    SELECT id,
+           first_value(id) OVER (
+               PARTITION BY source_link
+               ORDER BY (main_technology IS NOT NULL AND main_technology != '') DESC, id
+           ) AS keep_id
+    FROM jobs_raw
+)
+INSERT INTO job_redirects (old_id, job_id)
+SELECT id, keep_id
+FROM ranked
+WHERE id != keep_id;
+
+DELETE FROM jobs_raw
+WHERE id IN (SELECT old_id FROM job_redirects);
+
+-- Уникальный индекс не дает сохранить пост повторно, даже если его одновременно загружают несколько процессов
+CREATE UNIQUE INDEX IF NOT EXISTS idx_jobs_raw_source_link ON jobs_raw (source_link);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+-- Удаленные повторы не восстанавливаются
+DROP INDEX IF EXISTS idx_jobs_raw_source_link;
+DROP TABLE IF EXISTS job_redirects;
+-- +goose StatementEnd
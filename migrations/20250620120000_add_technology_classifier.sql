@@ -0,0 +1,15 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Ключевые слова, которые уменьшают оценку технологии (например, "javascript" для java).
+-- Как и в keywords, вес задается суффиксом ":вес", по умолчанию вес равен 1
+ALTER TABLE technologies ADD COLUMN IF NOT EXISTS negative_keywords TEXT[] NOT NULL DEFAULT '{}';
+
+-- Оценки технологий, посчитанные классификатором для вакансии, в порядке убывания
+ALTER TABLE jobs_raw ADD COLUMN IF NOT EXISTS technology_scores JSONB;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE jobs_raw DROP COLUMN IF EXISTS technology_scores;
+ALTER TABLE technologies DROP COLUMN IF EXISTS negative_keywords;
+-- +goose StatementEnd
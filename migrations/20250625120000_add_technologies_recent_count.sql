@@ -0,0 +1,13 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Количество показываемых вакансий технологии за последние 30 дней и время последнего пересчета.
+-- Колонки count и count_recent пересчитываются веб-сервером по расписанию
+ALTER TABLE technologies ADD COLUMN IF NOT EXISTS count_recent BIGINT NOT NULL DEFAULT 0;
+ALTER TABLE technologies ADD COLUMN IF NOT EXISTS date_counted TIMESTAMPTZ;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE technologies DROP COLUMN IF EXISTS date_counted;
+ALTER TABLE technologies DROP COLUMN IF EXISTS count_recent;
+-- +goose StatementEnd
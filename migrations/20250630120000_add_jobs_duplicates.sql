@@ -0,0 +1,30 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Делит SimHash на 7 полос по 9 бит (пакет internal/domain/dedup). Почти одинаковые тексты отличаются
+-- не больше чем 6 битами, поэтому хотя бы одна полоса у них совпадает. Номер полосы хранится
+-- в старших битах значения, чтобы одинаковые биты в разных полосах не совпадали
+CREATE OR REPLACE FUNCTION simhash_bands(hash BIGINT) RETURNS INT[]
+LANGUAGE SQL IMMUTABLE STRICT PARALLEL SAFE AS $$
+    SELECT array_agg((band << 9) | ((hash >> (band * 9)) & 511)::INT ORDER BY band)
+    FROM generate_series(0, 6) AS band
+$$;
+
+-- SimHash текста вакансии и его полосы для поиска почти одинаковых вакансий по индексу
+ALTER TABLE jobs_raw ADD COLUMN IF NOT EXISTS simhash BIGINT;
+ALTER TABLE jobs_raw ADD COLUMN IF NOT EXISTS simhash_bands INT[] GENERATED ALWAYS AS (simhash_bands(simhash)) STORED;
+-- Каноническая вакансия, повтором которой является эта вакансия. В списках показываются только канонические вакансии
+ALTER TABLE jobs_raw ADD COLUMN IF NOT EXISTS canonical_id BIGINT REFERENCES jobs_raw(id) ON DELETE SET NULL;
+
+CREATE INDEX IF NOT EXISTS idx_jobs_raw_simhash_bands ON jobs_raw USING GIN (simhash_bands);
+CREATE INDEX IF NOT EXISTS idx_jobs_raw_canonical_id ON jobs_raw(canonical_id) WHERE canonical_id IS NOT NULL;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS idx_jobs_raw_canonical_id;
+DROP INDEX IF EXISTS idx_jobs_raw_simhash_bands;
+ALTER TABLE jobs_raw DROP COLUMN IF EXISTS canonical_id;
+ALTER TABLE jobs_raw DROP COLUMN IF EXISTS simhash_bands;
+ALTER TABLE jobs_raw DROP COLUMN IF EXISTS simhash;
+DROP FUNCTION IF EXISTS simhash_bands(BIGINT);
+-- +goose StatementEnd
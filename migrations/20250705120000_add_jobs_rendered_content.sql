@@ -0,0 +1,15 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Очищенный HTML вакансии, HTML превью для списков и текст мета-описания.
+-- Заполняются при загрузке вакансии, для уже сохраненных вакансий - командой cmd/enrich
+ALTER TABLE jobs_raw ADD COLUMN IF NOT EXISTS content_sanitized TEXT;
+ALTER TABLE jobs_raw ADD COLUMN IF NOT EXISTS content_preview TEXT;
+ALTER TABLE jobs_raw ADD COLUMN IF NOT EXISTS meta_description TEXT;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE jobs_raw DROP COLUMN IF EXISTS meta_description;
+ALTER TABLE jobs_raw DROP COLUMN IF EXISTS content_preview;
+ALTER TABLE jobs_raw DROP COLUMN IF EXISTS content_sanitized;
+-- +goose StatementEnd
@@ -0,0 +1,21 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Источники вакансий помимо каналов Telegram (например, RSS/Atom-ленты сайтов с вакансиями) и состояние их обработки
+CREATE TABLE IF NOT EXISTS sources (
+    id BIGSERIAL PRIMARY KEY,
+    kind VARCHAR(50) NOT NULL,
+    name VARCHAR(255) NOT NULL UNIQUE,
+    url VARCHAR(2048) NOT NULL,
+    state TEXT NOT NULL DEFAULT '',
+    enabled BOOLEAN NOT NULL DEFAULT TRUE,
+    date_source_added TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    items_parsed BIGINT NOT NULL DEFAULT 0,
+    date_last_parsed TIMESTAMP WITH TIME ZONE,
+    last_error TEXT
+);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS sources;
+-- +goose StatementEnd
@@ -0,0 +1,24 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Жизненный цикл вакансии: active -> expired -> archived -> removed. Статус меняется веб-сервером по расписанию
+-- в зависимости от возраста вакансии. Пороги задаются для технологии, NULL - порог по умолчанию
+ALTER TABLE jobs_raw ADD COLUMN IF NOT EXISTS status VARCHAR(20) NOT NULL DEFAULT 'active'
+    CHECK (status IN ('active', 'expired', 'archived', 'removed'));
+ALTER TABLE jobs_raw ADD COLUMN IF NOT EXISTS date_status_changed TIMESTAMPTZ;
+CREATE INDEX IF NOT EXISTS idx_jobs_raw_status_date_posted ON jobs_raw (status, date_posted);
+
+ALTER TABLE technologies ADD COLUMN IF NOT EXISTS expire_after_days INT CHECK (expire_after_days > 0);
+ALTER TABLE technologies ADD COLUMN IF NOT EXISTS archive_after_days INT CHECK (archive_after_days > 0);
+ALTER TABLE technologies ADD COLUMN IF NOT EXISTS remove_after_days INT CHECK (remove_after_days > 0);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE technologies DROP COLUMN IF EXISTS remove_after_days;
+ALTER TABLE technologies DROP COLUMN IF EXISTS archive_after_days;
+ALTER TABLE technologies DROP COLUMN IF EXISTS expire_after_days;
+
+DROP INDEX IF EXISTS idx_jobs_raw_status_date_posted;
+ALTER TABLE jobs_raw DROP COLUMN IF EXISTS date_status_changed;
+ALTER TABLE jobs_raw DROP COLUMN IF EXISTS status;
+-- +goose StatementEnd
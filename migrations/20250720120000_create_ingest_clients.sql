@@ -0,0 +1,19 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Внешние парсеры, которые отправляют вакансии через POST /api/ingest. Запросы подписываются HMAC-SHA256
+-- с секретом клиента, поэтому секрет хранится в открытом виде
+CREATE TABLE IF NOT EXISTS ingest_clients (
+    id BIGSERIAL PRIMARY KEY,
+    name VARCHAR(255) NOT NULL UNIQUE,
+    secret VARCHAR(255) NOT NULL CHECK (length(secret) >= 32),
+    enabled BOOLEAN NOT NULL DEFAULT TRUE,
+    date_client_added TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    items_received BIGINT NOT NULL DEFAULT 0,
+    date_last_request TIMESTAMP WITH TIME ZONE
+);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS ingest_clients;
+-- +goose StatementEnd
@@ -0,0 +1,19 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Решение модератора по вакансии из очереди модерации: approved - технология подтверждена или назначена вручную,
+-- not_vacancy - пост не является вакансией и не показывается на сайте. NULL - вакансия не проверялась
+ALTER TABLE jobs_raw ADD COLUMN IF NOT EXISTS moderation VARCHAR(20)
+    CHECK (moderation IN ('approved', 'not_vacancy'));
+ALTER TABLE jobs_raw ADD COLUMN IF NOT EXISTS date_moderated TIMESTAMPTZ;
+
+-- Очередь модерации выбирает непроверенные активные вакансии по дате публикации
+CREATE INDEX IF NOT EXISTS idx_jobs_raw_moderation_queue ON jobs_raw (date_posted DESC)
+    WHERE moderation IS NULL AND canonical_id IS NULL AND status = 'active';
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS idx_jobs_raw_moderation_queue;
+ALTER TABLE jobs_raw DROP COLUMN IF EXISTS date_moderated;
+ALTER TABLE jobs_raw DROP COLUMN IF EXISTS moderation;
+-- +goose StatementEnd
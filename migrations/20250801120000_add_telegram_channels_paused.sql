@@ -0,0 +1,10 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Приостановленные каналы не загружаются парсером при обходе всех каналов
+ALTER TABLE telegram_channels ADD COLUMN IF NOT EXISTS paused BOOLEAN NOT NULL DEFAULT FALSE;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE telegram_channels DROP COLUMN IF EXISTS paused;
+-- +goose StatementEnd
@@ -0,0 +1,25 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Вакансия скрыта администратором: не показывается на сайте, но остается в базе
+ALTER TABLE jobs_raw ADD COLUMN IF NOT EXISTS hidden BOOLEAN NOT NULL DEFAULT FALSE;
+
+-- Журнал изменений вакансий в админке. Записи остаются после удаления вакансии, поэтому job_id без внешнего ключа.
+-- changes - массив изменений полей вида {"field": ..., "before": ..., "after": ...}
+CREATE TABLE IF NOT EXISTS job_audit_log (
+    id BIGSERIAL PRIMARY KEY,
+    job_id BIGINT NOT NULL,
+    action VARCHAR(20) NOT NULL CHECK (action IN ('update', 'hide', 'unhide', 'delete')),
+    actor VARCHAR(255) NOT NULL,
+    comment TEXT NOT NULL DEFAULT '',
+    changes JSONB NOT NULL DEFAULT '[]',
+    date_created TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_job_audit_log_job_id ON job_audit_log (job_id, id DESC);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS job_audit_log;
+ALTER TABLE jobs_raw DROP COLUMN IF EXISTS hidden;
+-- +goose StatementEnd
@@ -0,0 +1,16 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Решения модератора тоже записываются в журнал: moderate - назначена технология или пост отмечен
+-- как не вакансия, restore - снята отметка "не вакансия"
+ALTER TABLE job_audit_log DROP CONSTRAINT IF EXISTS job_audit_log_action_check;
+ALTER TABLE job_audit_log ADD CONSTRAINT job_audit_log_action_check
+    CHECK (action IN ('update', 'hide', 'unhide', 'delete', 'moderate', 'restore'));
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DELETE FROM job_audit_log WHERE action IN ('moderate', 'restore');
+ALTER TABLE job_audit_log DROP CONSTRAINT IF EXISTS job_audit_log_action_check;
+ALTER TABLE job_audit_log ADD CONSTRAINT job_audit_log_action_check
+    CHECK (action IN ('update', 'hide', 'unhide', 'delete'));
+-- +goose StatementEnd
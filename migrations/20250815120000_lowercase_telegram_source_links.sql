@@ -0,0 +1,39 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Ссылки на посты Telegram приводятся к нижнему регистру: парсер сохранял имя канала так, как его пишет Telegram,
+-- а импорт - так, как его указал оператор, поэтому один пост мог сохраниться дважды.
+-- Из таких повторов остается удаленная администратором вакансия (иначе пост вернулся бы на сайт),
+-- затем вакансия с определенной технологией, среди них - самая ранняя. Адреса остальных перенаправляются на неё
+WITH ranked AS (
+    SELECT id,
+           first_value(id) OVER (
+               PARTITION BY lower(source_link)
+               ORDER BY status = 'removed' DESC, (main_technology IS NOT NULL AND main_technology != '') DESC, id
+           ) AS keep_id
+    FROM jobs_raw
+    WHERE source_link LIKE 'https://t.me/%'
+)
+INSERT INTO job_redirects (old_id, job_id)
+SELECT id, keep_id
+FROM ranked
+WHERE id != keep_id;
+
+-- Старые перенаправления на удаляемые повторы переводятся на оставленную вакансию
+UPDATE job_redirects AS r
+SET job_id = d.job_id
+FROM job_redirects AS d
+WHERE r.job_id = d.old_id;
+
+DELETE FROM jobs_raw
+WHERE id IN (SELECT old_id FROM job_redirects);
+
+UPDATE jobs_raw
+SET source_link = lower(source_link)
+WHERE source_link LIKE 'https://t.me/%' AND source_link != lower(source_link);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+-- Регистр ссылок и удаленные повторы не восстанавливаются
+SELECT 1;
+-- +goose StatementEnd